@@ -0,0 +1,207 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// AddrFlags are the flags of an address assigned to an interface, such as
+// whether it is still tentative during duplicate address detection (DAD) or a
+// deprecated privacy address.  The values are Linux's IFA_F_* flags from
+// include/uapi/linux/if_addr.h.  They are only reported for IPv6 addresses,
+// as the kernel reuses some of the bits with other meanings for IPv4, and
+// platforms that do not report address flags leave them zero.
+type AddrFlags uint32
+
+const (
+	// AddrFlagTemporary marks a temporary (privacy) IPv6 address.
+	AddrFlagTemporary AddrFlags = 1 << iota
+	AddrFlagNoDAD
+	AddrFlagOptimistic
+	AddrFlagDADFailed
+	AddrFlagHomeAddress
+	AddrFlagDeprecated
+	AddrFlagTentative
+	AddrFlagPermanent
+	AddrFlagManageTempAddr
+	AddrFlagNoPrefixRoute
+	AddrFlagMCAutoJoin
+	AddrFlagStablePrivacy
+)
+
+// addrFlagNames are the names of the AddrFlags, in bit order, as used by
+// AddrFlags.String() and the "flags" include and exclude selectors.
+var addrFlagNames = []string{
+	"temporary",
+	"nodad",
+	"optimistic",
+	"dadfailed",
+	"homeaddress",
+	"deprecated",
+	"tentative",
+	"permanent",
+	"managetempaddr",
+	"noprefixroute",
+	"mcautojoin",
+	"stableprivacy",
+}
+
+// String returns the names of the flags separated by "|", like net.Flags.
+func (f AddrFlags) String() string {
+	var names []string
+	for i, name := range addrFlagNames {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	if unknown := f &^ (1<<uint(len(addrFlagNames)) - 1); unknown != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint32(unknown)))
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, "|")
+}
+
+// addrFlagByName returns the AddrFlags named name.
+func addrFlagByName(name string) (AddrFlags, bool) {
+	for i, flagName := range addrFlagNames {
+		if flagName == name {
+			return 1 << uint(i), true
+		}
+	}
+	return 0, false
+}
+
+// parseAddrFlags parses the "|" separated output of AddrFlags.String().
+func parseAddrFlags(s string) (AddrFlags, error) {
+	var flags AddrFlags
+	if s == "" {
+		return flags, nil
+	}
+
+	for flagName := range strings.SplitSeq(s, "|") {
+		flag, found := addrFlagByName(flagName)
+		if !found {
+			return 0, fmt.Errorf("unknown address flag: %+q", flagName)
+		}
+		flags |= flag
+	}
+	return flags, nil
+}
+
+// AddrScope is the scope of an address assigned to an interface: the part of
+// the network in which the address is valid.  The zero value means the
+// platform did not report the scope.
+type AddrScope uint8
+
+const (
+	AddrScopeUnknown AddrScope = iota
+	AddrScopeGlobal
+	AddrScopeSite
+	AddrScopeLink
+	AddrScopeHost
+	AddrScopeNowhere
+)
+
+// addrScopeNames are the names of the AddrScopes, indexed by AddrScope.
+var addrScopeNames = []string{
+	AddrScopeUnknown: "",
+	AddrScopeGlobal:  "global",
+	AddrScopeSite:    "site",
+	AddrScopeLink:    "link",
+	AddrScopeHost:    "host",
+	AddrScopeNowhere: "nowhere",
+}
+
+// String returns the name of the scope as used by `ip address`, or an empty
+// string if the scope is unknown.
+func (s AddrScope) String() string {
+	if int(s) < len(addrScopeNames) {
+		return addrScopeNames[s]
+	}
+	return fmt.Sprintf("AddrScope(%d)", uint8(s))
+}
+
+// parseAddrScope parses the output of AddrScope.String().
+func parseAddrScope(s string) (AddrScope, error) {
+	for scope, name := range addrScopeNames {
+		if name == s {
+			return AddrScope(scope), nil
+		}
+	}
+	return AddrScopeUnknown, fmt.Errorf("unknown address scope: %+q", s)
+}
+
+// AddrInfo is the state of an address assigned to an interface that
+// net.Interface.Addrs() does not report.
+type AddrInfo struct {
+	Flags AddrFlags
+	Scope AddrScope
+}
+
+// IPv6 address scopes used by /proc/net/if_inet6, from IPV6_ADDR_* in
+// include/net/ipv6.h.
+const (
+	ipv6AddrScopeMask      = 0x00f0
+	ipv6AddrScopeLoopback  = 0x0010
+	ipv6AddrScopeLinkLocal = 0x0020
+	ipv6AddrScopeSiteLocal = 0x0040
+)
+
+// parseProcNetIfInet6 parses Linux's /proc/net/if_inet6 into the AddrInfo of
+// each IPv6 address, keyed by interface index.  The kernel truncates the flags
+// in this file to their low eight bits, so AddrFlagNoPrefixRoute and the
+// later flags are never reported by it.
+func parseProcNetIfInet6(out string) (map[int]map[netip.Addr]AddrInfo, error) {
+	infos := make(map[int]map[netip.Addr]AddrInfo)
+	for _, line := range strings.Split(out, "\n") {
+		// Address IfIndex PrefixLen Scope Flags Iface
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+
+		b, err := hex.DecodeString(fields[0])
+		if err != nil || len(b) != 16 {
+			return nil, fmt.Errorf("unable to parse address %+q", fields[0])
+		}
+		ifIndex, err := strconv.ParseUint(fields[1], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse interface index %+q: %w", fields[1], err)
+		}
+		scope, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse scope %+q: %w", fields[3], err)
+		}
+		flags, err := strconv.ParseUint(fields[4], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse flags %+q: %w", fields[4], err)
+		}
+
+		info := AddrInfo{Flags: AddrFlags(flags)}
+		switch scope & ipv6AddrScopeMask {
+		case ipv6AddrScopeLoopback:
+			info.Scope = AddrScopeHost
+		case ipv6AddrScopeLinkLocal:
+			info.Scope = AddrScopeLink
+		case ipv6AddrScopeSiteLocal:
+			info.Scope = AddrScopeSite
+		default:
+			info.Scope = AddrScopeGlobal
+		}
+
+		if infos[int(ifIndex)] == nil {
+			infos[int(ifIndex)] = make(map[netip.Addr]AddrInfo)
+		}
+		infos[int(ifIndex)][netip.AddrFrom16([16]byte(b))] = info
+	}
+
+	return infos, nil
+}
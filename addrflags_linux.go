@@ -0,0 +1,151 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"syscall"
+)
+
+// procNetIfInet6Path lists the IPv6 addresses and their flags, which are read
+// when netlink is not available.
+var procNetIfInet6Path = "/proc/net/if_inet6"
+
+// IFA_FLAGS from include/uapi/linux/if_addr.h, which carries the 32-bit flags
+// of an address; the ifaddrmsg header only has room for the low eight bits.
+const ifaFlags = 0x8
+
+// Address scopes from RT_SCOPE_* in include/uapi/linux/rtnetlink.h.
+const (
+	rtScopeUniverse = 0
+	rtScopeSite     = 200
+	rtScopeLink     = 253
+	rtScopeHost     = 254
+	rtScopeNowhere  = 255
+)
+
+// interfaceAddrInfo returns the AddrInfo of the addresses assigned to intf.
+func interfaceAddrInfo(intf net.Interface) (map[netip.Addr]AddrInfo, error) {
+	infos, err := allInterfaceAddrInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if infos[intf.Index] == nil {
+		return map[netip.Addr]AddrInfo{}, nil
+	}
+	return infos[intf.Index], nil
+}
+
+// allInterfaceAddrInfo returns the AddrInfo of the addresses of all
+// interfaces, keyed by interface index, read over netlink or, failing that,
+// from /proc/net/if_inet6.  If neither is available an empty map is returned.
+func allInterfaceAddrInfo() (map[int]map[netip.Addr]AddrInfo, error) {
+	infos, err := netlinkAddrInfo()
+	if err != nil {
+		out, err := os.ReadFile(procNetIfInet6Path)
+		if err != nil {
+			return map[int]map[netip.Addr]AddrInfo{}, nil
+		}
+
+		if infos, err = parseProcNetIfInet6(string(out)); err != nil {
+			return nil, err
+		}
+	}
+
+	return infos, nil
+}
+
+// netlinkAddrInfo dumps the addresses of all interfaces over netlink.
+func netlinkAddrInfo() (map[int]map[netip.Addr]AddrInfo, error) {
+	tab, err := syscall.NetlinkRIB(syscall.RTM_GETADDR, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, os.NewSyscallError("netlinkrib", err)
+	}
+
+	msgs, err := syscall.ParseNetlinkMessage(tab)
+	if err != nil {
+		return nil, os.NewSyscallError("parsenetlinkmessage", err)
+	}
+
+	return parseNetlinkAddrMsgs(msgs)
+}
+
+// parseNetlinkAddrMsgs parses the RTM_NEWADDR messages of a netlink address
+// dump into the AddrInfo of each address, keyed by interface index.  Flags are
+// only attached to IPv6 addresses: for IPv4 the kernel reuses
+// IFA_F_TEMPORARY as IFA_F_SECONDARY.
+func parseNetlinkAddrMsgs(msgs []syscall.NetlinkMessage) (map[int]map[netip.Addr]AddrInfo, error) {
+	infos := make(map[int]map[netip.Addr]AddrInfo)
+	for i := range msgs {
+		m := &msgs[i]
+		if m.Header.Type == syscall.NLMSG_DONE {
+			break
+		}
+		if m.Header.Type != syscall.RTM_NEWADDR {
+			continue
+		}
+		if len(m.Data) < syscall.SizeofIfAddrmsg {
+			return nil, fmt.Errorf("truncated address message of %d bytes", len(m.Data))
+		}
+
+		// struct ifaddrmsg: family, prefixlen, flags, scope, index.
+		var info AddrInfo
+		isIPv6 := m.Data[0] == syscall.AF_INET6
+		if isIPv6 {
+			info.Flags = AddrFlags(m.Data[2])
+		}
+		ifIndex := int(binary.NativeEndian.Uint32(m.Data[4:8]))
+		switch m.Data[3] {
+		case rtScopeUniverse:
+			info.Scope = AddrScopeGlobal
+		case rtScopeSite:
+			info.Scope = AddrScopeSite
+		case rtScopeLink:
+			info.Scope = AddrScopeLink
+		case rtScopeHost:
+			info.Scope = AddrScopeHost
+		case rtScopeNowhere:
+			info.Scope = AddrScopeNowhere
+		}
+
+		attrs, err := syscall.ParseNetlinkRouteAttr(m)
+		if err != nil {
+			return nil, os.NewSyscallError("parsenetlinkrouteattr", err)
+		}
+
+		// IFA_LOCAL is the local address of a point-to-point link, whose
+		// IFA_ADDRESS is the address of the peer.
+		var addr, local netip.Addr
+		for _, attr := range attrs {
+			switch attr.Attr.Type {
+			case syscall.IFA_ADDRESS:
+				addr, _ = netip.AddrFromSlice(attr.Value)
+			case syscall.IFA_LOCAL:
+				local, _ = netip.AddrFromSlice(attr.Value)
+			case ifaFlags:
+				if isIPv6 && len(attr.Value) >= 4 {
+					info.Flags = AddrFlags(binary.NativeEndian.Uint32(attr.Value))
+				}
+			}
+		}
+		if local.IsValid() {
+			addr = local
+		}
+		if !addr.IsValid() {
+			continue
+		}
+
+		if infos[ifIndex] == nil {
+			infos[ifIndex] = make(map[netip.Addr]AddrInfo)
+		}
+		infos[ifIndex][addr] = info
+	}
+
+	return infos, nil
+}
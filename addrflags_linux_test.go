@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"syscall"
+	"testing"
+)
+
+// netlinkAddrMsg returns an RTM_NEWADDR message for an address of ifIndex with
+// the given header flags and scope, followed by attrs.
+func netlinkAddrMsg(family, flags, scope uint8, ifIndex uint32, attrs ...[]byte) syscall.NetlinkMessage {
+	data := []byte{family, 64, flags, scope, 0, 0, 0, 0}
+	binary.NativeEndian.PutUint32(data[4:], ifIndex)
+	for _, attr := range attrs {
+		data = append(data, attr...)
+	}
+
+	return syscall.NetlinkMessage{
+		Header: syscall.NlMsghdr{Type: syscall.RTM_NEWADDR},
+		Data:   data,
+	}
+}
+
+// netlinkAttr returns a route attribute of type attrType, padded to four bytes.
+func netlinkAttr(attrType uint16, value []byte) []byte {
+	b := make([]byte, 4, 4+len(value)+3)
+	binary.NativeEndian.PutUint16(b[0:], uint16(4+len(value)))
+	binary.NativeEndian.PutUint16(b[2:], attrType)
+	b = append(b, value...)
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func Test_parseNetlinkAddrMsgs(t *testing.T) {
+	flags := make([]byte, 4)
+	binary.NativeEndian.PutUint32(flags, uint32(AddrFlagPermanent|AddrFlagNoPrefixRoute))
+
+	msgs := []syscall.NetlinkMessage{
+		netlinkAddrMsg(syscall.AF_INET, 0x80, rtScopeHost, 1,
+			netlinkAttr(syscall.IFA_ADDRESS, []byte{127, 0, 0, 1}),
+			netlinkAttr(syscall.IFA_LOCAL, []byte{127, 0, 0, 1})),
+		// A point-to-point link, whose IFA_ADDRESS is the peer.
+		netlinkAddrMsg(syscall.AF_INET, 0x80, rtScopeUniverse, 3,
+			netlinkAttr(syscall.IFA_ADDRESS, []byte{172, 16, 0, 1}),
+			netlinkAttr(syscall.IFA_LOCAL, []byte{172, 16, 0, 2})),
+		// IFA_F_SECONDARY shares its bit with IFA_F_TEMPORARY, so IPv4
+		// addresses carry no flags.
+		netlinkAddrMsg(syscall.AF_INET, 0x81, rtScopeUniverse, 3,
+			netlinkAttr(syscall.IFA_ADDRESS, []byte{172, 16, 0, 3}),
+			netlinkAttr(ifaFlags, netlinkUint32(0x81))),
+		// IFA_FLAGS carries the flags that do not fit in the header.
+		netlinkAddrMsg(syscall.AF_INET6, 0x80, rtScopeUniverse, 2,
+			netlinkAttr(syscall.IFA_ADDRESS, netip.MustParseAddr("2001:db8::5").AsSlice()),
+			netlinkAttr(ifaFlags, flags)),
+		netlinkAddrMsg(syscall.AF_INET6, 0x40, rtScopeLink, 2,
+			netlinkAttr(syscall.IFA_ADDRESS, netip.MustParseAddr("fe80::5").AsSlice())),
+		{Header: syscall.NlMsghdr{Type: syscall.NLMSG_DONE}},
+	}
+
+	infos, err := parseNetlinkAddrMsgs(msgs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		ifIndex int
+		addr    string
+		want    AddrInfo
+	}{
+		{1, "127.0.0.1", AddrInfo{Scope: AddrScopeHost}},
+		{3, "172.16.0.2", AddrInfo{Scope: AddrScopeGlobal}},
+		{3, "172.16.0.3", AddrInfo{Scope: AddrScopeGlobal}},
+		{2, "2001:db8::5", AddrInfo{Flags: AddrFlagPermanent | AddrFlagNoPrefixRoute, Scope: AddrScopeGlobal}},
+		{2, "fe80::5", AddrInfo{Flags: AddrFlagTentative, Scope: AddrScopeLink}},
+	}
+	for _, test := range tests {
+		info, ok := infos[test.ifIndex][netip.MustParseAddr(test.addr)]
+		if !ok || info != test.want {
+			t.Errorf("interface %d address %s: got %+v, want %+v", test.ifIndex, test.addr, info, test.want)
+		}
+	}
+	if _, ok := infos[3][netip.MustParseAddr("172.16.0.1")]; ok {
+		t.Errorf("the peer of a point-to-point link was reported as an address")
+	}
+
+	truncated := []syscall.NetlinkMessage{{Header: syscall.NlMsghdr{Type: syscall.RTM_NEWADDR}, Data: []byte{2, 8}}}
+	if _, err := parseNetlinkAddrMsgs(truncated); err == nil {
+		t.Errorf("expected an error for a truncated message")
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !linux
+
+package sockaddr
+
+import (
+	"net"
+	"net/netip"
+)
+
+// interfaceAddrInfo returns an empty map, as address flags and scopes are not
+// reported on this platform.
+func interfaceAddrInfo(net.Interface) (map[netip.Addr]AddrInfo, error) {
+	return map[netip.Addr]AddrInfo{}, nil
+}
+
+// allInterfaceAddrInfo returns an empty map, as address flags and scopes are
+// not reported on this platform.
+func allInterfaceAddrInfo() (map[int]map[netip.Addr]AddrInfo, error) {
+	return map[int]map[netip.Addr]AddrInfo{}, nil
+}
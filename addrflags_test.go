@@ -0,0 +1,252 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func Test_parseProcNetIfInet6(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[int]map[string]AddrInfo
+	}{
+		{
+			name: "host",
+			input: `fd000000000000000000000000000002 04 40 00 82     eth0
+00000000000000000000000000000001 01 80 10 80       lo
+fe8000000000000000fc00fffe000001 04 40 20 80     eth0
+`,
+			want: map[int]map[string]AddrInfo{
+				1: {
+					"::1": {Flags: AddrFlagPermanent, Scope: AddrScopeHost},
+				},
+				4: {
+					"fd00::2":            {Flags: AddrFlagNoDAD | AddrFlagPermanent, Scope: AddrScopeGlobal},
+					"fe80::fc:ff:fe00:1": {Flags: AddrFlagPermanent, Scope: AddrScopeLink},
+				},
+			},
+		},
+		{
+			name: "privacy addresses and dad",
+			input: `20010db8000000000000000000000005 02 40 00 40     eth0
+20010db80000000011223344aabbccdd 02 40 00 21     eth0
+20010db8000000000000000000000006 02 40 00 88     eth0
+fec00000000000000000000000000001 03 40 40 80     eth1
+`,
+			want: map[int]map[string]AddrInfo{
+				2: {
+					"2001:db8::5":                   {Flags: AddrFlagTentative, Scope: AddrScopeGlobal},
+					"2001:db8::1122:3344:aabb:ccdd": {Flags: AddrFlagTemporary | AddrFlagDeprecated, Scope: AddrScopeGlobal},
+					"2001:db8::6":                   {Flags: AddrFlagDADFailed | AddrFlagPermanent, Scope: AddrScopeGlobal},
+				},
+				3: {
+					"fec0::1": {Flags: AddrFlagPermanent, Scope: AddrScopeSite},
+				},
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  map[int]map[string]AddrInfo{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			infos, err := parseProcNetIfInet6(test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(infos) != len(test.want) {
+				t.Fatalf("got %d interfaces, want %d: %v", len(infos), len(test.want), infos)
+			}
+			for ifIndex, want := range test.want {
+				if len(infos[ifIndex]) != len(want) {
+					t.Errorf("interface %d: got %d addresses, want %d: %v", ifIndex, len(infos[ifIndex]), len(want), infos[ifIndex])
+				}
+				for addr, wantInfo := range want {
+					if got := infos[ifIndex][netip.MustParseAddr(addr)]; got != wantInfo {
+						t.Errorf("interface %d address %s: got %+v, want %+v", ifIndex, addr, got, wantInfo)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_parseProcNetIfInet6_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "short address",
+			input: "fd00 04 40 00 82     eth0\n",
+		},
+		{
+			name:  "invalid interface index",
+			input: "fd000000000000000000000000000002 zz 40 00 82     eth0\n",
+		},
+		{
+			name:  "invalid scope",
+			input: "fd000000000000000000000000000002 04 40 zz 82     eth0\n",
+		},
+		{
+			name:  "invalid flags",
+			input: "fd000000000000000000000000000002 04 40 00 zz     eth0\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := parseProcNetIfInet6(test.input); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestAddrFlags_String(t *testing.T) {
+	tests := []struct {
+		flags AddrFlags
+		want  string
+	}{
+		{0, "0"},
+		{AddrFlagPermanent, "permanent"},
+		{AddrFlagTentative | AddrFlagOptimistic, "optimistic|tentative"},
+		{AddrFlagPermanent | AddrFlagNoPrefixRoute, "permanent|noprefixroute"},
+		{AddrFlagTemporary | 0x10000, "temporary|0x10000"},
+	}
+
+	for _, test := range tests {
+		if got := test.flags.String(); got != test.want {
+			t.Errorf("AddrFlags(%#x).String() = %q, want %q", uint32(test.flags), got, test.want)
+		}
+		if test.flags&0x10000 != 0 {
+			continue
+		}
+
+		flags, err := parseAddrFlags(test.want)
+		if test.flags == 0 {
+			// "0" is not a flag name, only the empty string parses to 0.
+			if err == nil {
+				t.Errorf("expected an error parsing %q", test.want)
+			}
+			continue
+		}
+		if err != nil || flags != test.flags {
+			t.Errorf("parseAddrFlags(%q) = %v, %v, want %v", test.want, flags, err, test.flags)
+		}
+	}
+}
+
+func TestGetAllInterfacesFrom_AddrInfo(t *testing.T) {
+	eth0 := net.Interface{Index: 2, MTU: 1500, Name: "eth0", Flags: net.FlagUp | net.FlagBroadcast}
+	p := NewStaticInterfaceProvider(IfAddrs{
+		{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: eth0, AddrFlags: AddrFlagPermanent | AddrFlagNoPrefixRoute, AddrScope: AddrScopeGlobal},
+		{SockAddr: MustIPv6Addr("2001:db8::5/64"), Interface: eth0, AddrFlags: AddrFlagPermanent, AddrScope: AddrScopeGlobal},
+		{SockAddr: MustIPv6Addr("2001:db8::1122:3344:aabb:ccdd/64"), Interface: eth0, AddrFlags: AddrFlagTemporary | AddrFlagDeprecated, AddrScope: AddrScopeGlobal},
+		{SockAddr: MustIPv6Addr("2001:db8::7/64"), Interface: eth0, AddrFlags: AddrFlagTentative, AddrScope: AddrScopeGlobal},
+		{SockAddr: MustIPv6Addr("fe80::1/64"), Interface: eth0, AddrFlags: AddrFlagPermanent, AddrScope: AddrScopeLink},
+	}, "eth0")
+
+	ifAddrs, err := GetAllInterfacesFrom(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ifAddrs[4]; got.AddrFlags != AddrFlagPermanent || got.AddrScope != AddrScopeLink {
+		t.Errorf("flags of the zoned link-local address not attached: %+v", got)
+	}
+
+	tests := []struct {
+		name    string
+		include bool
+		flags   string
+		want    string
+	}{
+		{
+			name:    "include permanent",
+			include: true,
+			flags:   "permanent",
+			want:    "10.0.0.5 2001:db8::5 fe80::1",
+		},
+		{
+			name:  "exclude tentative",
+			flags: "tentative",
+			want:  "10.0.0.5 2001:db8::5 2001:db8::1122:3344:aabb:ccdd fe80::1",
+		},
+		{
+			name:    "include noprefixroute",
+			include: true,
+			flags:   "noprefixroute",
+			want:    "10.0.0.5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := ExcludeIfs
+			if test.include {
+				filter = IncludeIfs
+			}
+			filtered, err := filter("flags", test.flags, ifAddrs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := JoinIfAddrs("address", " ", filtered)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// dumpingProvider is a StaticInterfaceProvider that reads the AddrInfo of all
+// interfaces at once and counts the reads.
+type dumpingProvider struct {
+	*StaticInterfaceProvider
+	dumps int
+}
+
+func (p *dumpingProvider) AddrInfo(net.Interface) (map[netip.Addr]AddrInfo, error) {
+	panic("AddrInfo called on a provider that reads all interfaces at once")
+}
+
+func (p *dumpingProvider) allAddrInfo() (map[int]map[netip.Addr]AddrInfo, error) {
+	p.dumps++
+	return map[int]map[netip.Addr]AddrInfo{
+		2: {netip.MustParseAddr("2001:db8::5"): {Flags: AddrFlagPermanent, Scope: AddrScopeGlobal}},
+		3: {netip.MustParseAddr("fe80::1"): {Flags: AddrFlagTentative, Scope: AddrScopeLink}},
+	}, nil
+}
+
+func TestGetAllInterfacesFrom_AllAddrInfo(t *testing.T) {
+	eth0 := net.Interface{Index: 2, MTU: 1500, Name: "eth0", Flags: net.FlagUp | net.FlagBroadcast}
+	eth1 := net.Interface{Index: 3, MTU: 1500, Name: "eth1", Flags: net.FlagUp | net.FlagBroadcast}
+	p := &dumpingProvider{StaticInterfaceProvider: NewStaticInterfaceProvider(IfAddrs{
+		{SockAddr: MustIPv6Addr("2001:db8::5/64"), Interface: eth0},
+		{SockAddr: MustIPv6Addr("fe80::1/64"), Interface: eth1},
+	}, "eth0")}
+
+	ifAddrs, err := GetAllInterfacesFrom(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.dumps != 1 {
+		t.Errorf("got %d reads of all interfaces, want 1", p.dumps)
+	}
+	if got := ifAddrs[0]; got.AddrFlags != AddrFlagPermanent || got.AddrScope != AddrScopeGlobal {
+		t.Errorf("info of eth0 not attached: %+v", got)
+	}
+	if got := ifAddrs[1]; got.AddrFlags != AddrFlagTentative || got.AddrScope != AddrScopeLink {
+		t.Errorf("info of eth1 not attached: %+v", got)
+	}
+}
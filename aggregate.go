@@ -0,0 +1,159 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"sort"
+)
+
+// Aggregate returns the minimal list of networks that covers exactly the same
+// addresses as the receiver.  Networks contained within another network are
+// dropped and adjacent networks are merged into their common parent (e.g.
+// "10.0.0.0/25" and "10.0.0.128/25" become "10.0.0.0/24").  The result is
+// sorted with IPv4 networks before IPv6 networks and by ascending address
+// within each family.  Ports are discarded.  IPv6 networks in different zones
+// are aggregated separately.
+func (s IPAddrs) Aggregate() IPAddrs {
+	var (
+		ipv4Ranges []ipRange
+		ipv6Ranges = make(map[string][]ipRange)
+		ipv6Zones  []string
+	)
+	for _, ip := range s {
+		switch v := ip.(type) {
+		case IPv4Addr:
+			ipv4Ranges = append(ipv4Ranges, ipv4Range(v))
+		case IPv6Addr:
+			if _, found := ipv6Ranges[v.Zone]; !found {
+				ipv6Zones = append(ipv6Zones, v.Zone)
+			}
+			ipv6Ranges[v.Zone] = append(ipv6Ranges[v.Zone], ipv6Range(v))
+		}
+	}
+	sort.Strings(ipv6Zones)
+
+	out := make(IPAddrs, 0, len(s))
+	for _, r := range mergeIPRanges(ipv4Ranges) {
+		out = r.appendPrefixes(out, IPv4len*8, "")
+	}
+	for _, zone := range ipv6Zones {
+		for _, r := range mergeIPRanges(ipv6Ranges[zone]) {
+			out = r.appendPrefixes(out, IPv6len*8, zone)
+		}
+	}
+
+	return out
+}
+
+// Aggregate returns the IP networks of the receiver aggregated with
+// IPAddrs.Aggregate(), followed by all non-IP SockAddrs in their original
+// order.
+func (sas SockAddrs) Aggregate() SockAddrs {
+	ips := make(IPAddrs, 0, len(sas))
+	var others SockAddrs
+	for _, sa := range sas {
+		if ip, ok := sa.(IPAddr); ok {
+			ips = append(ips, ip)
+		} else {
+			others = append(others, sa)
+		}
+	}
+
+	out := make(SockAddrs, 0, len(sas))
+	for _, ip := range ips.Aggregate() {
+		out = append(out, ip)
+	}
+	return append(out, others...)
+}
+
+// ipRange is an inclusive range of addresses within a single address family.
+// IPv4 addresses are stored in the low 32 bits.
+type ipRange struct {
+	start, end Uint128
+}
+
+// ipv4Range returns the range of addresses in the network of ipv4.
+func ipv4Range(ipv4 IPv4Addr) ipRange {
+	return ipRange{
+		start: Uint128{Lo: uint64(ipv4.NetworkAddress())},
+		end:   Uint128{Lo: uint64(ipv4.BroadcastAddress())},
+	}
+}
+
+// ipv6Range returns the range of addresses in the network of ipv6.
+func ipv6Range(ipv6 IPv6Addr) ipRange {
+	return ipRange{
+		start: Uint128(ipv6.NetworkAddress()),
+		end:   ipv6.lastAddress(),
+	}
+}
+
+// mergeIPRanges sorts ranges and merges overlapping and adjacent ranges.  The
+// input slice is reordered in place.
+func mergeIPRanges(ranges []ipRange) []ipRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Cmp(ranges[j].start) < 0
+	})
+
+	merged := []ipRange{ranges[0]}
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		// Adjacent ranges merge as well, taking care not to overflow when
+		// the current range ends at the top of the address space.
+		if last.end == maxUint128 || r.start.Cmp(last.end.Add(Uint128{Lo: 1})) <= 0 {
+			if r.end.Cmp(last.end) > 0 {
+				last.end = r.end
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+// appendPrefixes appends the minimal list of CIDR networks covering r to out.
+// width is the number of bits in the address family.
+func (r ipRange) appendPrefixes(out IPAddrs, width int, zone string) IPAddrs {
+	start := r.start
+	for {
+		// Find the largest aligned block beginning at start that does not
+		// extend past the end of the range.
+		hostBits := min(start.TrailingZeros(), width)
+		var last Uint128
+		for ; ; hostBits-- {
+			last = start.Or(Uint128{Lo: 1}.Lsh(uint(hostBits)).Sub(Uint128{Lo: 1}))
+			if last.Cmp(r.end) <= 0 {
+				break
+			}
+		}
+
+		out = append(out, newIPAddrFromRange(start, width-hostBits, width, zone))
+		if last == r.end {
+			return out
+		}
+		start = last.Add(Uint128{Lo: 1})
+	}
+}
+
+// newIPAddrFromRange returns the network beginning at start with a prefix
+// length of prefixLen in the address family with width bits.
+func newIPAddrFromRange(start Uint128, prefixLen, width int, zone string) IPAddr {
+	if width == IPv4len*8 {
+		return IPv4Addr{
+			Address: IPv4Address(start.Lo),
+			Mask:    IPv4Mask(uint64(IPv4HostMask) << uint(width-prefixLen)),
+		}
+	}
+
+	return IPv6Addr{
+		Address: IPv6Address(start),
+		Mask:    IPv6Mask(mask128(prefixLen)),
+		Zone:    zone,
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-sockaddr"
+)
+
+func TestSockAddr_IPAddrs_Aggregate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []string
+		output []string
+	}{
+		{
+			name:   "empty",
+			input:  []string{},
+			output: []string{},
+		},
+		{
+			name:   "siblings merge",
+			input:  []string{"10.0.0.128/25", "10.0.0.0/25"},
+			output: []string{"10.0.0.0/24"},
+		},
+		{
+			name:   "contained networks drop",
+			input:  []string{"10.1.2.3", "10.0.0.0/8", "10.255.0.0/16"},
+			output: []string{"10.0.0.0/8"},
+		},
+		{
+			name:   "non-siblings do not merge",
+			input:  []string{"10.0.1.0/24", "10.0.2.0/24"},
+			output: []string{"10.0.1.0/24", "10.0.2.0/24"},
+		},
+		{
+			name:   "cascading merge",
+			input:  []string{"192.168.0.0/24", "192.168.1.0/25", "192.168.1.128/25", "192.168.2.0/23"},
+			output: []string{"192.168.0.0/22"},
+		},
+		{
+			name:   "host bits and ports are discarded",
+			input:  []string{"192.0.2.77/24", "192.0.2.1:80"},
+			output: []string{"192.0.2.0/24"},
+		},
+		{
+			name:   "unaligned range splits",
+			input:  []string{"10.0.0.1", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8"},
+			output: []string{"10.0.0.1", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8"},
+		},
+		{
+			name:   "whole ipv4 space",
+			input:  []string{"128.0.0.0/1", "0.0.0.0/1", "255.255.255.255"},
+			output: []string{"0.0.0.0/0"},
+		},
+		{
+			name:   "mixed families",
+			input:  []string{"2001:db8:1::/48", "10.0.0.0/9", "2001:db8::/48", "10.128.0.0/9"},
+			output: []string{"10.0.0.0/8", "2001:db8::/47"},
+		},
+		{
+			name:   "whole ipv6 space",
+			input:  []string{"8000::/1", "::/1"},
+			output: []string{"::/0"},
+		},
+		{
+			name:   "top of ipv6 space",
+			input:  []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+			output: []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127"},
+		},
+		{
+			name:   "zones aggregate separately",
+			input:  []string{"fe80::%eth1/65", "fe80::%eth0/65", "fe80::8000:0:0:0%eth0/65"},
+			output: []string{"fe80::%eth0/64", "fe80::%eth1/65"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ips := make(sockaddr.IPAddrs, 0, len(test.input))
+			for _, s := range test.input {
+				ips = append(ips, sockaddr.MustIPAddr(s))
+			}
+
+			output := make([]string, 0, len(test.output))
+			for _, ip := range ips.Aggregate() {
+				output = append(output, ip.String())
+			}
+
+			if !reflect.DeepEqual(output, test.output) {
+				t.Errorf("expected %v, received %v", test.output, output)
+			}
+		})
+	}
+}
+
+// TestSockAddr_IPAddrs_AggregateCoverage checks random inputs against a brute
+// force enumeration of a /24 to ensure the aggregated networks cover exactly
+// the same addresses and that no two output networks could be merged.
+func TestSockAddr_IPAddrs_AggregateCoverage(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		var ips sockaddr.IPAddrs
+		var covered [256]bool
+		for j := r.Intn(8); j >= 0; j-- {
+			maskBits := 24 + r.Intn(9)
+			ip := sockaddr.MustIPv4Addr(fmt.Sprintf("192.0.2.%d/%d", r.Intn(256), maskBits))
+			ips = append(ips, ip)
+			for host := range ip.Network().Subnets(32) {
+				covered[host.(sockaddr.IPv4Addr).Octets()[3]] = true
+			}
+		}
+
+		aggregated := ips.Aggregate()
+		var aggCovered [256]bool
+		for j, agg := range aggregated {
+			for host := range agg.Subnets(32) {
+				octet := host.(sockaddr.IPv4Addr).Octets()[3]
+				if aggCovered[octet] {
+					t.Fatalf("%v: overlapping output %v", ips, aggregated)
+				}
+				aggCovered[octet] = true
+			}
+
+			if j > 0 && aggregated[j-1].Maskbits() == agg.Maskbits() {
+				parent := sockaddr.MustIPv4Addr(fmt.Sprintf("%s/%d", aggregated[j-1].NetIP(), agg.Maskbits()-1))
+				if parent.Network().Equal(aggregated[j-1]) && parent.Contains(agg) {
+					t.Fatalf("%v: unmerged siblings in %v", ips, aggregated)
+				}
+			}
+		}
+
+		if covered != aggCovered {
+			t.Fatalf("%v: aggregated to %v with different coverage", ips, aggregated)
+		}
+	}
+}
+
+func TestSockAddr_SockAddrs_Aggregate(t *testing.T) {
+	sas := sockaddr.SockAddrs{
+		sockaddr.MustUnixSock("/tmp/foo"),
+		sockaddr.MustIPv4Addr("10.0.0.0/25"),
+		sockaddr.MustIPv6Addr("2001:db8::/64"),
+		sockaddr.MustIPv4Addr("10.0.0.128/25"),
+	}
+
+	expected := []string{"10.0.0.0/24", "2001:db8::/64", `"/tmp/foo"`}
+
+	var output []string
+	for _, sa := range sas.Aggregate() {
+		output = append(output, sa.String())
+	}
+
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected %v, received %v", expected, output)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/errwrap"
+	sockaddr "github.com/hashicorp/go-sockaddr"
+	"github.com/mitchellh/cli"
+)
+
+type AggregateCommand struct {
+	Ui cli.Ui
+
+	// flags is a list of options belonging to this command
+	flags *flag.FlagSet
+}
+
+// Description is the long-form command help.
+func (c *AggregateCommand) Description() string {
+	return `Aggregates a list of IPv4 and IPv6 networks into the minimal list of CIDRs that covers exactly the same addresses.  Contained networks are dropped and adjacent networks are merged.  If no networks are given as arguments, or the argument is a dash (` + "`-`" + `), networks are read from stdin separated by whitespace.  Text following a '#' on a line is ignored.`
+}
+
+// Help returns the full help output expected by `sockaddr -h cmd`
+func (c *AggregateCommand) Help() string {
+	return MakeHelp(c)
+}
+
+// InitOpts is responsible for setup of this command's configuration via the
+// command line.  InitOpts() does not parse the arguments (see parseOpts()).
+func (c *AggregateCommand) InitOpts() {
+	c.flags = flag.NewFlagSet("aggregate", flag.ContinueOnError)
+	c.flags.Usage = func() { c.Ui.Output(c.Help()) }
+}
+
+// Run executes this command.
+func (c *AggregateCommand) Run(args []string) int {
+	c.InitOpts()
+	inputs, err := c.parseOpts(args)
+	if err != nil {
+		if errwrap.Contains(err, "flag: help requested") {
+			return 0
+		}
+		return 1
+	}
+
+	if len(inputs) == 0 || (len(inputs) == 1 && inputs[0] == "-") {
+		inputs, err = readNetworks(os.Stdin)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("ERROR: Error reading from stdin: %v", err))
+			return 1
+		}
+	}
+
+	ips := make(sockaddr.IPAddrs, 0, len(inputs))
+	for _, in := range inputs {
+		ip, err := sockaddr.NewIPAddr(in)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("ERROR: Invalid IP network %+q: %v", in, err))
+			return 1
+		}
+		ips = append(ips, ip)
+	}
+
+	for _, ip := range ips.Aggregate() {
+		c.Ui.Output(ip.String())
+	}
+
+	return 0
+}
+
+// Synopsis returns a terse description used when listing sub-commands.
+func (c *AggregateCommand) Synopsis() string {
+	return `Aggregates IP networks into a minimal list of CIDRs`
+}
+
+// Usage is the one-line usage description
+func (c *AggregateCommand) Usage() string {
+	return `sockaddr aggregate [IP network ...]`
+}
+
+// VisitAllFlags forwards the visitor function to the FlagSet
+func (c *AggregateCommand) VisitAllFlags(fn func(*flag.Flag)) {
+	c.flags.VisitAll(fn)
+}
+
+// parseOpts is responsible for parsing the options set in InitOpts().  Returns
+// a list of non-parsed flags.
+func (c *AggregateCommand) parseOpts(args []string) ([]string, error) {
+	if err := c.flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return c.flags.Args(), nil
+}
+
+// readNetworks returns the whitespace-separated words of r, ignoring any text
+// following a '#' on each line.
+func readNetworks(r io.Reader) ([]string, error) {
+	var networks []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		networks = append(networks, strings.Fields(line)...)
+	}
+
+	return networks, scanner.Err()
+}
@@ -224,6 +224,13 @@ func (c *DumpCommand) dumpSockAddr(sa sockaddr.SockAddr) {
 		}
 	}
 
+	if sa.Type() == sockaddr.TypeVSock {
+		vs := *sockaddr.ToVSock(sa)
+		for _, attr := range sockaddr.VSockAttrs() {
+			output = outFmt(output, attr, sockaddr.VSockAttr(vs, attr))
+		}
+	}
+
 	// Developer-focused arguments
 	{
 		arg1, arg2 := sa.DialPacketArgs()
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/errwrap"
+	sockaddr "github.com/hashicorp/go-sockaddr"
 	"github.com/hashicorp/go-sockaddr/template"
 	"github.com/mitchellh/cli"
 )
@@ -29,6 +30,11 @@ type EvalCommand struct {
 	// handlebars.
 	rawInput bool
 
+	// snapshotFile is the path of a snapshot created by `sockaddr
+	// tech-support -output snapshot` to evaluate templates against instead
+	// of the host's interfaces.
+	snapshotFile string
+
 	// suppressNewline changes whether or not there's a newline between each
 	// arg passed to the eval subcommand.
 	suppressNewline bool
@@ -44,7 +50,9 @@ func (c *EvalCommand) Description() string {
 		"the `{{` and `}}` template delimiters unless the `-r` command is specified, in " +
 		"which case `eval` parses the raw input.  If the `template` argument passed to " +
 		"`eval` is a dash (`-`), then `sockaddr eval` will read from stdin and " +
-		"automatically sets the `-r` flag."
+		"automatically sets the `-r` flag.  The `-snapshot` flag evaluates templates " +
+		"against a snapshot created by `sockaddr tech-support -output snapshot` " +
+		"instead of the host's interfaces."
 
 }
 
@@ -61,6 +69,7 @@ func (c *EvalCommand) InitOpts() {
 	c.flags.BoolVar(&c.debugOutput, "d", false, "Debug output")
 	c.flags.BoolVar(&c.suppressNewline, "n", false, "Suppress newlines between args")
 	c.flags.BoolVar(&c.rawInput, "r", false, "Suppress wrapping the input with {{ }} delimiters")
+	c.flags.StringVar(&c.snapshotFile, "snapshot", "", "Evaluate against the interfaces of a JSON snapshot file")
 }
 
 // Run executes this command.
@@ -78,6 +87,19 @@ func (c *EvalCommand) Run(args []string) int {
 		}
 		return 1
 	}
+
+	parse := template.Parse
+	if c.snapshotFile != "" {
+		p, err := loadSnapshotProvider(c.snapshotFile)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("[ERROR]: Error loading snapshot %q: %v", c.snapshotFile, err))
+			return 1
+		}
+		parse = func(in string) (string, error) {
+			return template.ParseWithProvider(in, p)
+		}
+	}
+
 	inputs, outputs := make([]string, len(tmpls)), make([]string, len(tmpls))
 	var rawInput, readStdin bool
 	for i, in := range tmpls {
@@ -107,7 +129,7 @@ func (c *EvalCommand) Run(args []string) int {
 			inputs[i] = in
 		}
 
-		out, err := template.Parse(in)
+		out, err := parse(in)
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("ERROR[%d] in: %q\n[%d] msg: %v\n", i, in, i, err))
 			return 1
@@ -159,3 +181,20 @@ func (c *EvalCommand) parseOpts(args []string) ([]string, error) {
 
 	return c.flags.Args(), nil
 }
+
+// loadSnapshotProvider reads the snapshot at path and returns a provider that
+// replays it.
+func loadSnapshotProvider(path string) (sockaddr.InterfaceProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	snapshot, err := sockaddr.ReadSnapshot(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.Provider()
+}
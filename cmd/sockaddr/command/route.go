@@ -0,0 +1,175 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/errwrap"
+	sockaddr "github.com/hashicorp/go-sockaddr"
+	"github.com/mitchellh/cli"
+	"github.com/ryanuber/columnize"
+)
+
+type RouteCommand struct {
+	Ui cli.Ui
+
+	// flags is a list of options belonging to this command
+	flags *flag.FlagSet
+
+	// machineMode changes the output format to be machine friendly
+	// (i.e. tab-separated values without a header).
+	machineMode bool
+
+	// v4Only lists only IPv4 routes
+	v4Only bool
+
+	// v6Only lists only IPv6 routes
+	v6Only bool
+}
+
+// Description is the long-form command help.
+func (c *RouteCommand) Description() string {
+	return `Lists the host's routing table.  Each route is printed with its destination, gateway, interface, metric, preferred source address and table ID.  Values the platform does not report are printed as "-".`
+}
+
+// Help returns the full help output expected by `sockaddr -h cmd`
+func (c *RouteCommand) Help() string {
+	return MakeHelp(c)
+}
+
+// InitOpts is responsible for setup of this command's configuration via the
+// command line.  InitOpts() does not parse the arguments (see parseOpts()).
+func (c *RouteCommand) InitOpts() {
+	c.flags = flag.NewFlagSet("route", flag.ContinueOnError)
+	c.flags.Usage = func() { c.Ui.Output(c.Help()) }
+	c.flags.BoolVar(&c.machineMode, "H", false, "Machine readable output")
+	c.flags.BoolVar(&c.v4Only, "4", false, "List only IPv4 routes")
+	c.flags.BoolVar(&c.v6Only, "6", false, "List only IPv6 routes")
+}
+
+// Run executes this command.
+func (c *RouteCommand) Run(args []string) int {
+	c.InitOpts()
+	rest, err := c.parseOpts(args)
+	if err != nil {
+		if errwrap.Contains(err, "flag: help requested") {
+			return 0
+		}
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	if len(rest) != 0 {
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	ri, err := sockaddr.NewRouteInfo()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error loading route information: %v", err))
+		return 1
+	}
+
+	routes, err := ri.Routes()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error reading routing table: %v", err))
+		return 1
+	}
+
+	sep := " | "
+	var output []string
+	if c.machineMode {
+		sep = "\t"
+	} else {
+		output = append(output, strings.Join([]string{"Destination", "Gateway", "Interface", "Metric", "Source", "Table"}, sep))
+	}
+
+	for _, route := range routes {
+		switch {
+		case c.v4Only && route.Family != sockaddr.TypeIPv4,
+			c.v6Only && route.Family != sockaddr.TypeIPv6:
+			continue
+		}
+
+		dest := "default"
+		if !route.IsDefault() {
+			dest = route.Destination.String()
+		}
+
+		ifName := route.Interface
+		if ifName == "" {
+			ifName = "-"
+		}
+
+		output = append(output, strings.Join([]string{
+			dest,
+			routeAddr(route.Gateway),
+			ifName,
+			strconv.FormatUint(uint64(route.Metric), 10),
+			routeAddr(route.Source),
+			routeTable(route.Table),
+		}, sep))
+	}
+
+	if c.machineMode {
+		for _, line := range output {
+			c.Ui.Output(line)
+		}
+		return 0
+	}
+
+	c.Ui.Output(columnize.SimpleFormat(output))
+	return 0
+}
+
+// Synopsis returns a terse description used when listing sub-commands.
+func (c *RouteCommand) Synopsis() string {
+	return `Lists the host's routing table`
+}
+
+// Usage is the one-line usage description
+func (c *RouteCommand) Usage() string {
+	return `sockaddr route [options]`
+}
+
+// VisitAllFlags forwards the visitor function to the FlagSet
+func (c *RouteCommand) VisitAllFlags(fn func(*flag.Flag)) {
+	c.flags.VisitAll(fn)
+}
+
+// parseOpts is responsible for parsing the options set in InitOpts().  Returns
+// a list of non-parsed flags.
+func (c *RouteCommand) parseOpts(args []string) ([]string, error) {
+	if err := c.flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if c.v4Only && c.v6Only {
+		return nil, fmt.Errorf("conflicting options specified, only one of -4 or -6 may be specified")
+	}
+
+	return c.flags.Args(), nil
+}
+
+// routeAddr formats an address of a route, printing "-" for addresses that
+// the platform did not report.
+func routeAddr(ip sockaddr.IPAddr) string {
+	if ip == nil {
+		return "-"
+	}
+	return ip.String()
+}
+
+// routeTable formats the routing table of a route, printing "-" for platforms
+// that do not report one.  Unlike the table, a metric of zero is a real
+// metric and is printed as is.
+func routeTable(v uint32) string {
+	if v == 0 {
+		return "-"
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
@@ -0,0 +1,213 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/errwrap"
+	sockaddr "github.com/hashicorp/go-sockaddr"
+	"github.com/mitchellh/cli"
+)
+
+type SetCommand struct {
+	Ui cli.Ui
+
+	// flags is a list of options belonging to this command
+	flags *flag.FlagSet
+
+	// silentMode prevents any output from the contains and overlaps
+	// operations, which only return an exit code.
+	silentMode bool
+}
+
+// Description is the long-form command help.
+func (c *SetCommand) Description() string {
+	return `Computes a set operation over two or more sets of IP addresses and prints the result as a minimal list of CIDRs.  Each set is a comma separated list of IP addresses, networks, ranges (e.g. "10.0.0.5-10.0.1.200") or known RFCs (e.g. "RFC1918").  A set of "-" is read from stdin separated by whitespace.  The operations are:
+
+  union       Addresses in any set
+  intersect   Addresses in every set
+  difference  Addresses in the first set and no other
+  contains    Exit 0 if the first set contains all others
+  overlaps    Exit 0 if the first set overlaps any other`
+}
+
+// Help returns the full help output expected by `sockaddr -h cmd`
+func (c *SetCommand) Help() string {
+	return MakeHelp(c)
+}
+
+// InitOpts is responsible for setup of this command's configuration via the
+// command line.  InitOpts() does not parse the arguments (see parseOpts()).
+func (c *SetCommand) InitOpts() {
+	c.flags = flag.NewFlagSet("set", flag.ContinueOnError)
+	c.flags.Usage = func() { c.Ui.Output(c.Help()) }
+	c.flags.BoolVar(&c.silentMode, "s", false, "Silent, only return exit codes for contains and overlaps")
+}
+
+// Run executes this command.
+func (c *SetCommand) Run(args []string) int {
+	if len(args) == 0 {
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	c.InitOpts()
+	unprocessedArgs, err := c.parseOpts(args)
+	if err != nil {
+		if errwrap.Contains(err, "flag: help requested") {
+			return 0
+		}
+		return 1
+	}
+
+	if len(unprocessedArgs) < 3 {
+		c.Ui.Error(`ERROR: Need an operation and at least two sets.`)
+		return 1
+	}
+
+	op := strings.ToLower(unprocessedArgs[0])
+	switch op {
+	case "union", "intersect", "difference", "contains", "overlaps":
+	default:
+		c.Ui.Error(fmt.Sprintf("ERROR: Unknown set operation %+q", unprocessedArgs[0]))
+		return 1
+	}
+
+	sets := make([]sockaddr.IPSet, 0, len(unprocessedArgs)-1)
+	for _, arg := range unprocessedArgs[1:] {
+		set, err := parseIPSet(arg)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("ERROR: %v", err))
+			return 1
+		}
+		sets = append(sets, set)
+	}
+
+	first, others := unprocessedArgs[1], strings.Join(unprocessedArgs[2:], " ")
+	switch op {
+	case "contains":
+		for _, set := range sets[1:] {
+			if !set.Difference(sets[0]).IsEmpty() {
+				return c.printBool(false, fmt.Sprintf("%s does not contain %s", first, others))
+			}
+		}
+		return c.printBool(true, fmt.Sprintf("%s contains %s", first, others))
+	case "overlaps":
+		for _, set := range sets[1:] {
+			if sets[0].Overlaps(set) {
+				return c.printBool(true, fmt.Sprintf("%s overlaps %s", first, others))
+			}
+		}
+		return c.printBool(false, fmt.Sprintf("%s does not overlap %s", first, others))
+	}
+
+	result := sets[0]
+	for _, set := range sets[1:] {
+		switch op {
+		case "union":
+			result = result.Union(set)
+		case "intersect":
+			result = result.Intersect(set)
+		case "difference":
+			result = result.Difference(set)
+		}
+	}
+
+	for _, ip := range result.Prefixes() {
+		c.Ui.Output(ip.String())
+	}
+
+	return 0
+}
+
+// Synopsis returns a terse description used when listing sub-commands.
+func (c *SetCommand) Synopsis() string {
+	return `Computes unions, intersections and differences of IP sets`
+}
+
+// Usage is the one-line usage description
+func (c *SetCommand) Usage() string {
+	return `sockaddr set [options] [operation] [set] [set ...]`
+}
+
+// VisitAllFlags forwards the visitor function to the FlagSet
+func (c *SetCommand) VisitAllFlags(fn func(*flag.Flag)) {
+	c.flags.VisitAll(fn)
+}
+
+// parseOpts is responsible for parsing the options set in InitOpts().  Returns
+// a list of non-parsed flags.
+func (c *SetCommand) parseOpts(args []string) ([]string, error) {
+	if err := c.flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return c.flags.Args(), nil
+}
+
+// printBool prints msg unless in silent mode and returns the exit code for
+// result.
+func (c *SetCommand) printBool(result bool, msg string) int {
+	if !c.silentMode {
+		c.Ui.Output(msg)
+	}
+
+	if result {
+		return 0
+	}
+	return 1
+}
+
+// parseIPSet parses a comma separated list of IP addresses, networks, ranges
+// and RFCs into an IPSet.  A setStr of "-" reads a whitespace separated list
+// from stdin.
+func parseIPSet(setStr string) (sockaddr.IPSet, error) {
+	var elements []string
+	if setStr == "-" {
+		var err error
+		elements, err = readNetworks(os.Stdin)
+		if err != nil {
+			return sockaddr.IPSet{}, fmt.Errorf("Error reading from stdin: %v", err)
+		}
+	} else {
+		elements = strings.Split(setStr, ",")
+	}
+
+	var sas sockaddr.SockAddrs
+	var ranges []sockaddr.IPRange
+	for _, element := range elements {
+		element = strings.TrimSpace(element)
+		if rfcStr, found := strings.CutPrefix(strings.ToUpper(element), "RFC"); found {
+			rfcNum, err := strconv.ParseUint(rfcStr, 10, 32)
+			if err != nil {
+				return sockaddr.IPSet{}, fmt.Errorf("Invalid RFC Number %+q: %v", element, err)
+			}
+
+			rfcAddrs, found := sockaddr.KnownRFCs()[uint(rfcNum)]
+			if !found {
+				return sockaddr.IPSet{}, fmt.Errorf("Unknown RFC %+q", element)
+			}
+			sas = append(sas, rfcAddrs...)
+			continue
+		}
+
+		if ipAddr, err := sockaddr.NewIPAddr(element); err == nil {
+			sas = append(sas, ipAddr)
+			continue
+		}
+
+		ipRange, err := sockaddr.NewIPRange(element)
+		if err != nil {
+			return sockaddr.IPSet{}, fmt.Errorf("Invalid IP address, network or range %+q", element)
+		}
+		ranges = append(ranges, ipRange)
+	}
+
+	return sockaddr.NewIPSet(sas).Union(sockaddr.NewIPSetFromIPRanges(ranges)), nil
+}
@@ -0,0 +1,134 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/errwrap"
+	sockaddr "github.com/hashicorp/go-sockaddr"
+	"github.com/mitchellh/cli"
+)
+
+type SubnetCommand struct {
+	Ui cli.Ui
+
+	// flags is a list of options belonging to this command
+	flags *flag.FlagSet
+
+	// hostsMode prints the usable host addresses of the network instead of
+	// its subnets.
+	hostsMode bool
+
+	// limit is the maximum number of results to print.  Zero means no limit.
+	limit uint
+}
+
+// Description is the long-form command help.
+func (c *SubnetCommand) Description() string {
+	return `Splits an IP network into the subnets of a longer prefix length and prints them in ascending order.  The prefix length may be written with or without a leading slash (e.g. "26" or "/26").  With -H, prints the usable host addresses of the network instead.`
+}
+
+// Help returns the full help output expected by `sockaddr -h cmd`
+func (c *SubnetCommand) Help() string {
+	return MakeHelp(c)
+}
+
+// InitOpts is responsible for setup of this command's configuration via the
+// command line.  InitOpts() does not parse the arguments (see parseOpts()).
+func (c *SubnetCommand) InitOpts() {
+	c.flags = flag.NewFlagSet("subnet", flag.ContinueOnError)
+	c.flags.Usage = func() { c.Ui.Output(c.Help()) }
+	c.flags.BoolVar(&c.hostsMode, "H", false, "Print the usable host addresses instead of subnets")
+	c.flags.UintVar(&c.limit, "l", 0, "Maximum number of results to print (0 is unlimited)")
+}
+
+// Run executes this command.
+func (c *SubnetCommand) Run(args []string) int {
+	if len(args) == 0 {
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	c.InitOpts()
+	unprocessedArgs, err := c.parseOpts(args)
+	if err != nil {
+		if errwrap.Contains(err, "flag: help requested") {
+			return 0
+		}
+		return 1
+	}
+
+	numArgs := len(unprocessedArgs)
+	switch {
+	case c.hostsMode && numArgs != 1:
+		c.Ui.Error(`ERROR: Need a single IP network.`)
+		return 1
+	case !c.hostsMode && numArgs != 2:
+		c.Ui.Error(`ERROR: Need an IP network and a new prefix length.`)
+		return 1
+	}
+
+	ipAddr, err := sockaddr.NewIPAddr(unprocessedArgs[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("ERROR: Invalid IP network %+q: %v", unprocessedArgs[0], err))
+		return 1
+	}
+
+	results := ipAddr.Hosts()
+	if !c.hostsMode {
+		prefixLen, err := strconv.ParseUint(strings.TrimPrefix(unprocessedArgs[1], "/"), 10, 8)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("ERROR: Invalid prefix length %+q: %v", unprocessedArgs[1], err))
+			return 1
+		}
+
+		maxPrefixLen := len(*ipAddr.NetIP()) * 8
+		if int(prefixLen) < ipAddr.Maskbits() || int(prefixLen) > maxPrefixLen {
+			c.Ui.Error(fmt.Sprintf("ERROR: Prefix length %d must be between %d and %d for %s", prefixLen, ipAddr.Maskbits(), maxPrefixLen, ipAddr))
+			return 1
+		}
+
+		results = ipAddr.Subnets(int(prefixLen))
+	}
+
+	var n uint
+	for result := range results {
+		if c.limit != 0 && n == c.limit {
+			break
+		}
+		c.Ui.Output(result.String())
+		n++
+	}
+
+	return 0
+}
+
+// Synopsis returns a terse description used when listing sub-commands.
+func (c *SubnetCommand) Synopsis() string {
+	return `Splits an IP network into subnets`
+}
+
+// Usage is the one-line usage description
+func (c *SubnetCommand) Usage() string {
+	return `sockaddr subnet [options] [IP network] [new prefix length]`
+}
+
+// VisitAllFlags forwards the visitor function to the FlagSet
+func (c *SubnetCommand) VisitAllFlags(fn func(*flag.Flag)) {
+	c.flags.VisitAll(fn)
+}
+
+// parseOpts is responsible for parsing the options set in InitOpts().  Returns
+// a list of non-parsed flags.
+func (c *SubnetCommand) parseOpts(args []string) ([]string, error) {
+	if err := c.flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return c.flags.Args(), nil
+}
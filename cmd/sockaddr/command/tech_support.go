@@ -4,6 +4,7 @@
 package command
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"net"
@@ -34,7 +35,9 @@ func (c *TechSupportCommand) Description() string {
 		"network details required to debug why a given `sockaddr` API call is behaving " +
 		"differently than expected.  The `-output` flag controls the output format. " +
 		"The default output mode is Markdown (`md`) however a raw mode (`raw`) is " +
-		"available to obtain the original output."
+		"available to obtain the original output.  The `snapshot` mode emits a JSON " +
+		"snapshot of the interfaces, addresses, routing table and route command " +
+		"output that `sockaddr eval -snapshot` can replay on another host."
 }
 
 // Help returns the full help output expected by `sockaddr -h cmd`
@@ -47,7 +50,7 @@ func (c *TechSupportCommand) Help() string {
 func (c *TechSupportCommand) InitOpts() {
 	c.flags = flag.NewFlagSet("tech-support", flag.ContinueOnError)
 	c.flags.Usage = func() { c.Ui.Output(c.Help()) }
-	c.flags.StringVar(&c.outputMode, "output", "md", `Encode the output using one of Markdown ("md") or Raw ("raw")`)
+	c.flags.StringVar(&c.outputMode, "output", "md", `Encode the output using one of Markdown ("md"), Raw ("raw") or a JSON snapshot ("snapshot")`)
 }
 
 // Run executes this command.
@@ -65,6 +68,10 @@ func (c *TechSupportCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.outputMode == "snapshot" {
+		return c.outputSnapshot()
+	}
+
 	ri, err := sockaddr.NewRouteInfo()
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("error loading route information: %v", err))
@@ -163,13 +170,31 @@ func (c *TechSupportCommand) parseOpts(args []string) ([]string, error) {
 	switch c.outputMode {
 	case "md", "markdown":
 		c.outputMode = "md"
-	case "raw":
+	case "raw", "snapshot":
 	default:
-		return nil, fmt.Errorf(`invalid output mode %q, supported output types are "md" (default) and "raw"`, c.outputMode)
+		return nil, fmt.Errorf(`invalid output mode %q, supported output types are "md" (default), "raw" and "snapshot"`, c.outputMode)
 	}
 	return c.flags.Args(), nil
 }
 
+// outputSnapshot prints a JSON encoded sockaddr.Snapshot of the host.
+func (c *TechSupportCommand) outputSnapshot() int {
+	snapshot, err := sockaddr.TakeSnapshot()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error taking snapshot: %v", err))
+		return 1
+	}
+
+	out, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error encoding snapshot: %v", err))
+		return 1
+	}
+
+	c.Ui.Output(string(out))
+	return 0
+}
+
 func (c *TechSupportCommand) rowWriterOutputFactory() func(valueVerb, key string, val any) {
 	type _Fmt string
 	type _Verb string
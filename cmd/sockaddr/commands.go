@@ -17,6 +17,11 @@ func init() {
 	ui := &cli.BasicUi{Writer: os.Stdout}
 
 	Commands = map[string]cli.CommandFactory{
+		"aggregate": func() (cli.Command, error) {
+			return &command.AggregateCommand{
+				Ui: ui,
+			}, nil
+		},
 		"dump": func() (cli.Command, error) {
 			return &command.DumpCommand{
 				Ui: ui,
@@ -37,6 +42,21 @@ func init() {
 				Ui: ui,
 			}, nil
 		},
+		"route": func() (cli.Command, error) {
+			return &command.RouteCommand{
+				Ui: ui,
+			}, nil
+		},
+		"set": func() (cli.Command, error) {
+			return &command.SetCommand{
+				Ui: ui,
+			}, nil
+		},
+		"subnet": func() (cli.Command, error) {
+			return &command.SubnetCommand{
+				Ui: ui,
+			}, nil
+		},
 		"tech-support": func() (cli.Command, error) {
 			return &command.TechSupportCommand{
 				Ui: ui,
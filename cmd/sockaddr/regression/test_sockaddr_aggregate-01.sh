@@ -0,0 +1,8 @@
+#!/bin/sh --
+# Copyright IBM Corp. 2016, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+
+set -e
+exec 2>&1
+printf '10.0.0.128/25\n10.0.0.0/25 # comment\n\n2001:db8:1::/48 2001:db8::/48\n10.0.0.7\n' | ../sockaddr aggregate
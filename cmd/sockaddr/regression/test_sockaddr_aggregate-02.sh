@@ -0,0 +1,8 @@
+#!/bin/sh --
+# Copyright IBM Corp. 2016, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+
+set -e
+exec 2>&1
+exec ../sockaddr aggregate 192.168.0.0/24 192.168.1.0/24 192.168.3.0/24 192.168.2.0/23
@@ -0,0 +1,8 @@
+#!/bin/sh --
+# Copyright IBM Corp. 2016, 2025
+# SPDX-License-Identifier: MPL-2.0
+
+
+set -e
+exec 2>&1
+exec ../sockaddr dump vsock://3:1024
@@ -0,0 +1,52 @@
+#!/bin/sh --
+# Copyright IBM Corp. 2016, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+
+set -e
+exec 2>&1
+
+snapshot="$(mktemp)"
+trap 'rm -f "${snapshot}"' EXIT
+cat > "${snapshot}" <<'EOF'
+{
+  "version": 1,
+  "goos": "linux",
+  "goarch": "amd64",
+  "default_interface": "eth0",
+  "interfaces": [
+    {
+      "name": "lo",
+      "index": 1,
+      "mtu": 65536,
+      "flags": "up|loopback|running",
+      "addresses": ["127.0.0.1/8", "::1/128"]
+    },
+    {
+      "name": "eth0",
+      "index": 2,
+      "mtu": 1500,
+      "hardware_addr": "02:42:ac:11:00:02",
+      "flags": "up|broadcast|multicast|running",
+      "addresses": ["10.1.2.3/16", "17.5.6.7/24", "fe80::42:acff:fe11:2/64"]
+    },
+    {
+      "name": "docker0",
+      "index": 3,
+      "mtu": 1500,
+      "hardware_addr": "02:42:9b:4d:c1:01",
+      "flags": "up|broadcast|multicast",
+      "addresses": ["172.17.0.1/16"]
+    }
+  ]
+}
+EOF
+
+../sockaddr eval -snapshot "${snapshot}" \
+	'GetPrivateIP' \
+	'GetPrivateIPs' \
+	'GetPublicIP' \
+	'GetDefaultInterfaces | join "address" " "' \
+	'GetAllInterfaces | include "type" "IPv6" | join "address" " "' \
+	'GetAllInterfaces | sort "default,name" | unique "name" | join "name" " "' \
+	'GetInterfaceIP "docker0"'
@@ -0,0 +1,55 @@
+#!/bin/sh --
+# Copyright IBM Corp. 2016, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+
+set -e
+exec 2>&1
+
+snapshot="$(mktemp)"
+trap 'rm -f "${snapshot}"' EXIT
+cat > "${snapshot}" <<'EOF'
+{
+  "version": 1,
+  "goos": "linux",
+  "goarch": "amd64",
+  "default_interface": "eth0",
+  "interfaces": [
+    {
+      "name": "lo",
+      "index": 1,
+      "mtu": 65536,
+      "flags": "up|loopback|running",
+      "addresses": ["127.0.0.1/8", "::1/128"]
+    },
+    {
+      "name": "eth0",
+      "index": 2,
+      "mtu": 1500,
+      "flags": "up|broadcast|multicast|running",
+      "addresses": ["10.1.2.3/16", "fe80::42:acff:fe11:2/64"]
+    },
+    {
+      "name": "wg0",
+      "index": 3,
+      "mtu": 1420,
+      "flags": "up|pointtopoint|running",
+      "addresses": ["10.99.0.2/24", "2001:db8:99::2/64"]
+    }
+  ],
+  "routes": [
+    {"destination": "0.0.0.0/0", "gateway": "10.1.0.1", "interface": "eth0", "metric": 100, "table": 254},
+    {"destination": "10.1.0.0/16", "interface": "eth0", "source": "10.1.2.3", "metric": 100, "table": 254},
+    {"destination": "10.99.0.0/24", "interface": "wg0", "source": "10.99.0.2", "table": 254},
+    {"destination": "192.168.0.0/16", "gateway": "10.99.0.1", "interface": "wg0", "table": 254},
+    {"destination": "2001:db8:99::/64", "interface": "wg0", "metric": 256, "table": 254},
+    {"destination": "::/0", "gateway": "2001:db8:99::1", "interface": "wg0", "metric": 1024, "table": 254}
+  ]
+}
+EOF
+
+../sockaddr eval -snapshot "${snapshot}" \
+	'GetInterfacesForDestination "8.8.8.8" | join "address" " "' \
+	'GetInterfacesForDestination "192.168.7.1" | join "address" " "' \
+	'GetDefaultIPv4Interfaces | join "address" " "' \
+	'GetDefaultIPv6Interfaces | join "address" " "'
@@ -0,0 +1,8 @@
+#!/bin/sh --
+# Copyright IBM Corp. 2016, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+
+set -e
+exec 2>&1
+printf '2001:db8::/33\n10.0.0.0/8 # comment\n2001:db8:8000::/33\n' | ../sockaddr set union - 11.0.0.0/8
@@ -0,0 +1,110 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"fmt"
+	"net"
+)
+
+// eui64Marker is the pair of bytes inserted in the middle of a 48-bit MAC
+// address to form a modified EUI-64 interface identifier (RFC 4291, Appendix
+// A).
+const eui64Marker = 0xfffe
+
+// eui64ULBit is the universal/local bit of the first byte of a MAC address,
+// which is inverted in a modified EUI-64 interface identifier.
+const eui64ULBit = 0x02
+
+// NewIPv6AddrFromEUI64 returns the stateless address autoconfiguration (SLAAC)
+// address a host with the hardware address mac will derive from prefix: the
+// upper 64 bits of the network of prefix followed by the modified EUI-64
+// interface identifier of mac (e.g. `2001:db8::/64` and `00:25:96:12:34:56`
+// return `2001:db8::225:96ff:fe12:3456/64`).  mac must be a 48-bit MAC or a
+// 64-bit EUI-64, and prefix must not be longer than a /64.  The mask and zone
+// of prefix are preserved.
+func NewIPv6AddrFromEUI64(prefix IPv6Addr, mac net.HardwareAddr) (IPv6Addr, error) {
+	if maskBits := prefix.Maskbits(); maskBits > 64 {
+		return IPv6Addr{}, fmt.Errorf("unable to derive an EUI-64 address from %s: prefix length %d is longer than 64 bits", prefix, maskBits)
+	}
+
+	var id [8]byte
+	switch len(mac) {
+	case 6:
+		copy(id[0:3], mac[0:3])
+		id[3], id[4] = eui64Marker>>8, eui64Marker&0xff
+		copy(id[5:8], mac[3:6])
+	case 8:
+		copy(id[:], mac)
+	default:
+		return IPv6Addr{}, fmt.Errorf("unable to derive an EUI-64 address from hardware address %q: must be 6 or 8 bytes long", mac.String())
+	}
+	id[0] ^= eui64ULBit
+
+	b := Uint128(prefix.NetworkAddress()).Bytes()
+	copy(b[8:], id[:])
+
+	return IPv6Addr{
+		Address: IPv6Address(Uint128FromBytes(b)),
+		Mask:    prefix.Mask,
+		Zone:    prefix.Zone,
+	}, nil
+}
+
+// EUI64HardwareAddr returns the 48-bit MAC address embedded in the modified
+// EUI-64 interface identifier of the receiver.  ok is false if the receiver
+// is not EUI-64 derived (see IsEUI64()).
+func (ipv6 IPv6Addr) EUI64HardwareAddr() (mac net.HardwareAddr, ok bool) {
+	if !ipv6.IsEUI64() {
+		return nil, false
+	}
+
+	b := Uint128(ipv6.Address).Bytes()
+	mac = net.HardwareAddr{b[8] ^ eui64ULBit, b[9], b[10], b[13], b[14], b[15]}
+	return mac, true
+}
+
+// IsEUI64 returns true if the interface identifier of the receiver was
+// derived from a 48-bit MAC address using the modified EUI-64 format, which
+// is identified by the bytes `ff:fe` in the middle of the lower 64 bits (e.g.
+// `fe80::225:96ff:fe12:3456`).  Only unicast addresses have an interface
+// identifier, so IPv4-mapped (e.g. `::ffff:254.0.0.1`), multicast, loopback
+// and unspecified addresses are never EUI-64.
+func (ipv6 IPv6Addr) IsEUI64() bool {
+	addr := ipv6.NetipAddr()
+	if addr.Is4In6() || addr.IsMulticast() || addr.IsLoopback() || addr.IsUnspecified() {
+		return false
+	}
+
+	b := Uint128(ipv6.Address).Bytes()
+	return b[11] == eui64Marker>>8 && b[12] == eui64Marker&0xff
+}
+
+// EUI64IfAddrs replaces every IPv6 IfAddr with the SLAAC address its
+// interface's hardware address derives from the IfAddr's network (see
+// NewIPv6AddrFromEUI64()).  IfAddrs that are not IPv6, have a prefix longer
+// than a /64, or whose interface does not have a 48-bit or 64-bit hardware
+// address are skipped.  Interfaces with several addresses in the same network
+// return duplicate results, which can be removed with UniqueIfAddrsBy().
+func EUI64IfAddrs(in IfAddrs) IfAddrs {
+	out := make(IfAddrs, 0, len(in))
+	for _, ifAddr := range in {
+		ipv6, ok := ifAddr.SockAddr.(IPv6Addr)
+		if !ok {
+			continue
+		}
+
+		slaac, err := NewIPv6AddrFromEUI64(ipv6, ifAddr.HardwareAddr)
+		if err != nil {
+			continue
+		}
+
+		out = append(out, IfAddr{
+			SockAddr:  slaac,
+			Interface: ifAddr.Interface,
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,161 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"net"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestNewIPv6AddrFromEUI64(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		mac    net.HardwareAddr
+		want   string
+		fail   bool
+	}{
+		{
+			name:   "48-bit mac",
+			prefix: "2001:db8::/64",
+			mac:    net.HardwareAddr{0x00, 0x25, 0x96, 0x12, 0x34, 0x56},
+			want:   "2001:db8::225:96ff:fe12:3456/64",
+		},
+		{
+			name:   "locally administered mac",
+			prefix: "fe80::/64",
+			mac:    net.HardwareAddr{0x62, 0x3e, 0x5f, 0x48, 0x75, 0xff},
+			want:   "fe80::603e:5fff:fe48:75ff/64",
+		},
+		{
+			name:   "host bits of prefix replaced",
+			prefix: "2406:7400:63:ef5:1415:8bc3:fa5e:2578/64",
+			mac:    net.HardwareAddr{0x60, 0x3e, 0x5f, 0x48, 0x75, 0xff},
+			want:   "2406:7400:63:ef5:623e:5fff:fe48:75ff/64",
+		},
+		{
+			name:   "64-bit eui",
+			prefix: "2001:db8:1::/48",
+			mac:    net.HardwareAddr{0x02, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77},
+			want:   "2001:db8:1:0:11:2233:4455:6677/48",
+		},
+		{
+			name:   "zone preserved",
+			prefix: "fe80::%eth0/64",
+			mac:    net.HardwareAddr{0x00, 0x25, 0x96, 0x12, 0x34, 0x56},
+			want:   "fe80::225:96ff:fe12:3456%eth0/64",
+		},
+		{
+			name:   "prefix too long",
+			prefix: "2001:db8::/96",
+			mac:    net.HardwareAddr{0x00, 0x25, 0x96, 0x12, 0x34, 0x56},
+			fail:   true,
+		},
+		{
+			name:   "infiniband hardware address",
+			prefix: "2001:db8::/64",
+			mac:    make(net.HardwareAddr, 20),
+			fail:   true,
+		},
+		{
+			name:   "no hardware address",
+			prefix: "2001:db8::/64",
+			fail:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := sockaddr.NewIPv6AddrFromEUI64(sockaddr.MustIPv6Addr(test.prefix), test.mac)
+			switch {
+			case test.fail && err == nil:
+				t.Fatalf("expected an error, got %s", got)
+			case test.fail:
+				return
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.String() != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+			// Only identifiers built from a 48-bit MAC carry the ff:fe marker.
+			if got.IsEUI64() != (len(test.mac) == 6) {
+				t.Errorf("IsEUI64() = %t for %s", got.IsEUI64(), got)
+			}
+		})
+	}
+}
+
+func TestIPv6Addr_EUI64HardwareAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		mac  string
+		ok   bool
+	}{
+		{
+			addr: "fe80::225:96ff:fe12:3456",
+			mac:  "00:25:96:12:34:56",
+			ok:   true,
+		},
+		{
+			addr: "2406:7400:63:ef5:603e:5fff:fe48:75ff/64",
+			mac:  "62:3e:5f:48:75:ff",
+			ok:   true,
+		},
+		{
+			addr: "2406:7400:63:ef5:1415:8bc3:fa5e:2578/64",
+		},
+		{
+			addr: "::1",
+		},
+		{
+			addr: "::ffff:254.18.52.86",
+		},
+		{
+			addr: "ff02::225:96ff:fe12:3456",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.addr, func(t *testing.T) {
+			ipv6 := sockaddr.MustIPv6Addr(test.addr)
+			if ipv6.IsEUI64() != test.ok {
+				t.Errorf("IsEUI64() = %t, want %t", ipv6.IsEUI64(), test.ok)
+			}
+
+			mac, ok := ipv6.EUI64HardwareAddr()
+			if ok != test.ok || mac.String() != test.mac {
+				t.Errorf("EUI64HardwareAddr() = (%q, %t), want (%q, %t)", mac, ok, test.mac, test.ok)
+			}
+		})
+	}
+}
+
+func TestEUI64IfAddrs(t *testing.T) {
+	mac := net.HardwareAddr{0x60, 0x3e, 0x5f, 0x48, 0x75, 0xff}
+	in := sockaddr.IfAddrs{
+		{SockAddr: sockaddr.MustIPv6Addr("fe80::1/64"), Interface: net.Interface{Name: "en0", HardwareAddr: mac}},
+		{SockAddr: sockaddr.MustIPv4Addr("192.168.0.102/24"), Interface: net.Interface{Name: "en0", HardwareAddr: mac}},
+		{SockAddr: sockaddr.MustIPv6Addr("2001:db8::1/128"), Interface: net.Interface{Name: "en0", HardwareAddr: mac}},
+		{SockAddr: sockaddr.MustIPv6Addr("::1/128"), Interface: net.Interface{Name: "lo0"}},
+		{SockAddr: sockaddr.MustIPv6Addr("2001:db8:1::5/64"), Interface: net.Interface{Name: "en0", HardwareAddr: mac}},
+	}
+
+	out := sockaddr.EUI64IfAddrs(in)
+	want := []string{"fe80::623e:5fff:fe48:75ff/64", "2001:db8:1:0:623e:5fff:fe48:75ff/64"}
+	if len(out) != len(want) {
+		t.Fatalf("got %d results, want %d: %v", len(out), len(want), out)
+	}
+	for i, ifAddr := range out {
+		if ifAddr.SockAddr.String() != want[i] {
+			t.Errorf("result %d: got %s, want %s", i, ifAddr.SockAddr, want[i])
+		}
+		if ifAddr.Name != "en0" {
+			t.Errorf("result %d: interface %q not preserved", i, ifAddr.Name)
+		}
+	}
+}
@@ -3,7 +3,10 @@
 
 package sockaddr
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 // ifAddrAttrMap is a map of the IfAddr type-specific attributes.
 var ifAddrAttrMap map[AttrName]func(IfAddr) string
@@ -22,7 +25,13 @@ func init() {
 // $ sockaddr eval -r '{{GetPrivateInterfaces | attr "address"}}'
 // ```
 func GetPrivateIP() (string, error) {
-	privateIfs, err := GetPrivateInterfaces()
+	return GetPrivateIPFrom(OSInterfaceProvider{})
+}
+
+// GetPrivateIPFrom is GetPrivateIP() using the interfaces and default route
+// supplied by p.
+func GetPrivateIPFrom(p InterfaceProvider) (string, error) {
+	privateIfs, err := GetPrivateInterfacesFrom(p)
 	if err != nil {
 		return "", err
 	}
@@ -44,7 +53,12 @@ func GetPrivateIP() (string, error) {
 // $ sockaddr eval -r '{{GetAllInterfaces | include "RFC" "6890" | join "address" " "}}'
 // ```
 func GetPrivateIPs() (string, error) {
-	ifAddrs, err := GetAllInterfaces()
+	return GetPrivateIPsFrom(OSInterfaceProvider{})
+}
+
+// GetPrivateIPsFrom is GetPrivateIPs() using the interfaces supplied by p.
+func GetPrivateIPsFrom(p InterfaceProvider) (string, error) {
+	ifAddrs, err := GetAllInterfacesFrom(p)
 	if err != nil {
 		return "", err
 	} else if len(ifAddrs) < 1 {
@@ -91,7 +105,13 @@ func GetPrivateIPs() (string, error) {
 // $ sockaddr eval -r '{{GetPublicInterfaces | attr "address"}}'
 // ```
 func GetPublicIP() (string, error) {
-	publicIfs, err := GetPublicInterfaces()
+	return GetPublicIPFrom(OSInterfaceProvider{})
+}
+
+// GetPublicIPFrom is GetPublicIP() using the interfaces and default route
+// supplied by p.
+func GetPublicIPFrom(p InterfaceProvider) (string, error) {
+	publicIfs, err := GetPublicInterfacesFrom(p)
 	if err != nil {
 		return "", err
 	} else if len(publicIfs) < 1 {
@@ -113,7 +133,12 @@ func GetPublicIP() (string, error) {
 // $ sockaddr eval -r '{{GetAllInterfaces | exclude "RFC" "6890" | join "address" " "}}'
 // ```
 func GetPublicIPs() (string, error) {
-	ifAddrs, err := GetAllInterfaces()
+	return GetPublicIPsFrom(OSInterfaceProvider{})
+}
+
+// GetPublicIPsFrom is GetPublicIPs() using the interfaces supplied by p.
+func GetPublicIPsFrom(p InterfaceProvider) (string, error) {
+	ifAddrs, err := GetAllInterfacesFrom(p)
 	if err != nil {
 		return "", err
 	} else if len(ifAddrs) < 1 {
@@ -152,7 +177,12 @@ func GetPublicIPs() (string, error) {
 // $ sockaddr eval -r '{{GetAllInterfaces | include "name" <<ARG>> | sort "type,size" | include "flag" "forwardable" | attr "address" }}'
 // ```
 func GetInterfaceIP(namedIfRE string) (string, error) {
-	ifAddrs, err := GetAllInterfaces()
+	return GetInterfaceIPFrom(OSInterfaceProvider{}, namedIfRE)
+}
+
+// GetInterfaceIPFrom is GetInterfaceIP() using the interfaces supplied by p.
+func GetInterfaceIPFrom(p InterfaceProvider, namedIfRE string) (string, error) {
+	ifAddrs, err := GetAllInterfacesFrom(p)
 	if err != nil {
 		return "", err
 	}
@@ -167,7 +197,7 @@ func GetInterfaceIP(namedIfRE string) (string, error) {
 		return "", err
 	}
 
-	ifAddrs, err = SortIfBy("+type,+size", ifAddrs)
+	ifAddrs, err = SortIfByFrom(p, "+type,+size", ifAddrs)
 	if err != nil {
 		return "", err
 	}
@@ -193,7 +223,13 @@ func GetInterfaceIP(namedIfRE string) (string, error) {
 // $ sockaddr eval -r '{{GetAllInterfaces | include "name" <<ARG>> | sort "type,size" | join "address" " "}}'
 // ```
 func GetInterfaceIPs(namedIfRE string) (string, error) {
-	ifAddrs, err := GetAllInterfaces()
+	return GetInterfaceIPsFrom(OSInterfaceProvider{}, namedIfRE)
+}
+
+// GetInterfaceIPsFrom is GetInterfaceIPs() using the interfaces supplied by
+// p.
+func GetInterfaceIPsFrom(p InterfaceProvider, namedIfRE string) (string, error) {
+	ifAddrs, err := GetAllInterfacesFrom(p)
 	if err != nil {
 		return "", err
 	}
@@ -203,7 +239,7 @@ func GetInterfaceIPs(namedIfRE string) (string, error) {
 		return "", err
 	}
 
-	ifAddrs, err = SortIfBy("+type,+size", ifAddrs)
+	ifAddrs, err = SortIfByFrom(p, "+type,+size", ifAddrs)
 	if err != nil {
 		return "", err
 	}
@@ -242,16 +278,65 @@ func IfAddrAttr(ifAddr IfAddr, attrName AttrName) string {
 func ifAddrAttrInit() {
 	// Sorted for human readability
 	ifAddrAttrs = []AttrName{
+		"address_flags",
+		"carrier",
+		"duplex",
 		"flags",
+		"hardware_addr",
+		"index",
+		"kind",
+		"mtu",
 		"name",
+		"operstate",
+		"scope",
+		"speed",
 	}
 
 	ifAddrAttrMap = map[AttrName]func(ifAddr IfAddr) string{
+		"address_flags": func(ifAddr IfAddr) string {
+			if ifAddr.AddrFlags == 0 {
+				return ""
+			}
+			return ifAddr.AddrFlags.String()
+		},
+		"carrier": func(ifAddr IfAddr) string {
+			if ifAddr.Carrier == LinkCarrierUnknown {
+				return ""
+			}
+			return ifAddr.Carrier.String()
+		},
+		"duplex": func(ifAddr IfAddr) string {
+			return ifAddr.Duplex
+		},
 		"flags": func(ifAddr IfAddr) string {
 			return ifAddr.Flags.String()
 		},
+		"hardware_addr": func(ifAddr IfAddr) string {
+			return ifAddr.HardwareAddr.String()
+		},
+		"index": func(ifAddr IfAddr) string {
+			return strconv.Itoa(ifAddr.Index)
+		},
+		"kind": func(ifAddr IfAddr) string {
+			return string(ifAddr.Kind)
+		},
+		"mtu": func(ifAddr IfAddr) string {
+			return strconv.Itoa(ifAddr.MTU)
+		},
 		"name": func(ifAddr IfAddr) string {
 			return ifAddr.Name
 		},
+		"operstate": func(ifAddr IfAddr) string {
+			return string(ifAddr.OperState)
+		},
+		"scope": func(ifAddr IfAddr) string {
+			return ifAddr.AddrScope.String()
+		},
+		"speed": func(ifAddr IfAddr) string {
+			if ifAddr.Speed == 0 {
+				return ""
+			}
+			return strconv.Itoa(ifAddr.Speed)
+		},
 	}
 }
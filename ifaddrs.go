@@ -4,12 +4,15 @@
 package sockaddr
 
 import (
+	"cmp"
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"math/big"
+	"iter"
 	"net"
+	"net/netip"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,6 +34,30 @@ type IfAddrs []IfAddr
 
 func (ifs IfAddrs) Len() int { return len(ifs) }
 
+// NetipAddrs returns the netip.Addr of every IP address in ifs.  Non-IP
+// IfAddrs are skipped.
+func (ifs IfAddrs) NetipAddrs() []netip.Addr {
+	addrs := make([]netip.Addr, 0, len(ifs))
+	for _, ifAddr := range ifs {
+		if ip := ToIPAddr(ifAddr.SockAddr); ip != nil {
+			addrs = append(addrs, (*ip).NetipAddr())
+		}
+	}
+	return addrs
+}
+
+// NetipPrefixes returns the netip.Prefix of every IP address in ifs.
+// Non-IP IfAddrs are skipped.
+func (ifs IfAddrs) NetipPrefixes() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(ifs))
+	for _, ifAddr := range ifs {
+		if ip := ToIPAddr(ifAddr.SockAddr); ip != nil {
+			prefixes = append(prefixes, (*ip).NetipPrefix())
+		}
+	}
+	return prefixes
+}
+
 // CmpIfFunc is the function signature that must be met to be used in the
 // OrderedIfAddrBy multiIfAddrSorter
 type CmpIfAddrFunc func(p1, p2 *IfAddr) int
@@ -118,28 +145,44 @@ func AscIfAddress(p1Ptr, p2Ptr *IfAddr) int {
 // optimizing today.  The common case is this gets called once or twice.
 // Patches welcome.
 func AscIfDefault(p1Ptr, p2Ptr *IfAddr) int {
-	ri, err := NewRouteInfo()
-	if err != nil {
-		return sortDeferDecision
-	}
+	return ascIfDefaultFrom(OSInterfaceProvider{})(p1Ptr, p2Ptr)
+}
 
-	defaultIfName, err := ri.GetDefaultInterfaceName()
+// ascIfDefaultFrom returns a sorting function that sorts IfAddrs on an
+// interface with a default route of their address family first, as reported
+// by p.  If p can not determine its default interfaces the sort decision is
+// deferred, like AscIfDefault().
+func ascIfDefaultFrom(p InterfaceProvider) CmpIfAddrFunc {
+	defaultIfs, err := getDefaultIfNamesFrom(p)
 	if err != nil {
-		return sortDeferDecision
+		return func(*IfAddr, *IfAddr) int { return sortDeferDecision }
 	}
 
-	switch {
-	case p1Ptr.Name == defaultIfName && p2Ptr.Name == defaultIfName:
-		return sortDeferDecision
-	case p1Ptr.Name == defaultIfName:
-		return sortReceiverBeforeArg
-	case p2Ptr.Name == defaultIfName:
-		return sortArgBeforeReceiver
-	default:
-		return sortDeferDecision
+	return func(p1Ptr, p2Ptr *IfAddr) int {
+		p1Default, p2Default := defaultIfs.isDefault(*p1Ptr), defaultIfs.isDefault(*p2Ptr)
+		switch {
+		case p1Default && p2Default:
+			return sortDeferDecision
+		case p1Default:
+			return sortReceiverBeforeArg
+		case p2Default:
+			return sortArgBeforeReceiver
+		default:
+			return sortDeferDecision
+		}
 	}
 }
 
+// AscIfIndex is a sorting function to sort IfAddrs by their interface index.
+func AscIfIndex(p1Ptr, p2Ptr *IfAddr) int {
+	return cmp.Compare(p1Ptr.Index, p2Ptr.Index)
+}
+
+// AscIfMTU is a sorting function to sort IfAddrs by their interface MTU.
+func AscIfMTU(p1Ptr, p2Ptr *IfAddr) int {
+	return cmp.Compare(p1Ptr.MTU, p2Ptr.MTU)
+}
+
 // AscIfName is a sorting function to sort IfAddrs by their interface names.
 func AscIfName(p1Ptr, p2Ptr *IfAddr) int {
 	return strings.Compare(p1Ptr.Name, p2Ptr.Name)
@@ -165,6 +208,12 @@ func AscIfPrivate(p1Ptr, p2Ptr *IfAddr) int {
 	return AscPrivate(&p1Ptr.SockAddr, &p2Ptr.SockAddr)
 }
 
+// AscIfSpeed is a sorting function to sort IfAddrs by the speed of their
+// interface's link.  Links of unknown speed sort as the slowest.
+func AscIfSpeed(p1Ptr, p2Ptr *IfAddr) int {
+	return cmp.Compare(p1Ptr.Speed, p2Ptr.Speed)
+}
+
 // AscIfType is a sorting function to sort IfAddrs by their respective address
 // type.  Non-equal types are deferred in the sort.
 func AscIfType(p1Ptr, p2Ptr *IfAddr) int {
@@ -181,6 +230,16 @@ func DescIfDefault(p1Ptr, p2Ptr *IfAddr) int {
 	return -1 * AscIfDefault(p1Ptr, p2Ptr)
 }
 
+// DescIfIndex is identical to AscIfIndex but reverse ordered.
+func DescIfIndex(p1Ptr, p2Ptr *IfAddr) int {
+	return -1 * cmp.Compare(p1Ptr.Index, p2Ptr.Index)
+}
+
+// DescIfMTU is identical to AscIfMTU but reverse ordered.
+func DescIfMTU(p1Ptr, p2Ptr *IfAddr) int {
+	return -1 * cmp.Compare(p1Ptr.MTU, p2Ptr.MTU)
+}
+
 // DescIfName is identical to AscIfName but reverse ordered.
 func DescIfName(p1Ptr, p2Ptr *IfAddr) int {
 	return -1 * strings.Compare(p1Ptr.Name, p2Ptr.Name)
@@ -201,6 +260,12 @@ func DescIfPrivate(p1Ptr, p2Ptr *IfAddr) int {
 	return -1 * AscPrivate(&p1Ptr.SockAddr, &p2Ptr.SockAddr)
 }
 
+// DescIfSpeed is identical to AscIfSpeed but reverse ordered, which puts the
+// fastest links first.
+func DescIfSpeed(p1Ptr, p2Ptr *IfAddr) int {
+	return -1 * cmp.Compare(p1Ptr.Speed, p2Ptr.Speed)
+}
+
 // DescIfType is identical to AscIfType but reverse ordered.
 func DescIfType(p1Ptr, p2Ptr *IfAddr) int {
 	return -1 * AscType(&p1Ptr.SockAddr, &p2Ptr.SockAddr)
@@ -243,20 +308,69 @@ func IfAttrs(selectorName string, ifAddrs IfAddrs) (string, error) {
 
 // GetAllInterfaces iterates over all available network interfaces and finds all
 // available IP addresses on each interface and converts them to
-// sockaddr.IPAddrs, and returning the result as an array of IfAddr.
+// sockaddr.IPAddrs, and returning the result as an array of IfAddr.  IPv6
+// link-local addresses have their Zone set to the name of their interface.  On
+// Linux the AddrFlags and AddrScope of each address and the Kind and link
+// state (OperState, Carrier, Speed and Duplex) of each interface are set as
+// well.
 func GetAllInterfaces() (IfAddrs, error) {
-	ifs, err := net.Interfaces()
+	return GetAllInterfacesFrom(OSInterfaceProvider{})
+}
+
+// GetAllInterfacesFrom is GetAllInterfaces() using the interfaces and
+// addresses supplied by p.  If p is an AddrInfoProvider, the flags and scope of
+// each address are set from it, and if p is a LinkInfoProvider, the kind and
+// link state of each interface.
+func GetAllInterfacesFrom(p InterfaceProvider) (IfAddrs, error) {
+	ifs, err := p.Interfaces()
 	if err != nil {
 		return nil, err
 	}
 
+	// The AddrInfo of all interfaces is read at once when p supports it,
+	// rather than once per interface.
+	var allAddrInfo map[int]map[netip.Addr]AddrInfo
+	aaip, hasAllAddrInfo := p.(allAddrInfoProvider)
+	if hasAllAddrInfo {
+		if allAddrInfo, err = aaip.allAddrInfo(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Likewise for the LinkInfo.
+	var allLinkInfo map[int]LinkInfo
+	alip, hasAllLinkInfo := p.(allLinkInfoProvider)
+	if hasAllLinkInfo {
+		if allLinkInfo, err = alip.allLinkInfo(ifs); err != nil {
+			return nil, err
+		}
+	}
+
 	ifAddrs := make(IfAddrs, 0, len(ifs))
 	for _, intf := range ifs {
-		addrs, err := intf.Addrs()
+		addrs, err := p.Addrs(intf)
 		if err != nil {
 			return nil, err
 		}
 
+		var addrInfo map[netip.Addr]AddrInfo
+		if hasAllAddrInfo {
+			addrInfo = allAddrInfo[intf.Index]
+		} else if aip, ok := p.(AddrInfoProvider); ok {
+			if addrInfo, err = aip.AddrInfo(intf); err != nil {
+				return nil, err
+			}
+		}
+
+		var linkInfo LinkInfo
+		if hasAllLinkInfo {
+			linkInfo = allLinkInfo[intf.Index]
+		} else if lip, ok := p.(LinkInfoProvider); ok {
+			if linkInfo, err = lip.LinkInfo(intf); err != nil {
+				return nil, err
+			}
+		}
+
 		for _, addr := range addrs {
 			var ipAddr IPAddr
 			ipAddr, err = NewIPAddr(addr.String())
@@ -264,9 +378,24 @@ func GetAllInterfaces() (IfAddrs, error) {
 				return IfAddrs{}, fmt.Errorf("unable to create an IP address from %q", addr.String())
 			}
 
+			// Link-local addresses are only usable with a zone, so scope
+			// them to the interface they were found on.
+			if ipv6, ok := ipAddr.(IPv6Addr); ok && ipv6.NetIP().IsLinkLocalUnicast() {
+				ipv6.Zone = intf.Name
+				ipAddr = ipv6
+			}
+
+			info := addrInfo[ipAddr.NetipAddr().WithZone("")]
 			ifAddr := IfAddr{
 				SockAddr:  ipAddr,
 				Interface: intf,
+				AddrFlags: info.Flags,
+				AddrScope: info.Scope,
+				Kind:      linkInfo.Kind,
+				OperState: linkInfo.OperState,
+				Carrier:   linkInfo.Carrier,
+				Speed:     linkInfo.Speed,
+				Duplex:    linkInfo.Duplex,
 			}
 			ifAddrs = append(ifAddrs, ifAddr)
 		}
@@ -276,33 +405,189 @@ func GetAllInterfaces() (IfAddrs, error) {
 }
 
 // GetDefaultInterfaces returns IfAddrs of the addresses attached to the default
-// route.
+// route.  An address is only attached to the default route when the default
+// route of its address family uses its interface, so on a dual-stack host
+// whose IPv4 and IPv6 default routes use different interfaces, the IPv4
+// addresses of one and the IPv6 addresses of the other are returned.
 func GetDefaultInterfaces() (IfAddrs, error) {
-	ri, err := NewRouteInfo()
+	return GetDefaultInterfacesFrom(OSInterfaceProvider{})
+}
+
+// GetDefaultInterfacesFrom is GetDefaultInterfaces() using the interfaces and
+// default routes supplied by p.
+func GetDefaultInterfacesFrom(p InterfaceProvider) (IfAddrs, error) {
+	defaultIfs, err := getDefaultIfNamesFrom(p)
+	if err != nil {
+		return nil, err
+	}
+
+	ifAddrs, err := GetAllInterfacesFrom(p)
 	if err != nil {
 		return nil, err
 	}
 
-	defaultIfName, err := ri.GetDefaultInterfaceName()
+	var defaultIfAddrs IfAddrs
+	for _, ifAddr := range ifAddrs {
+		if defaultIfs.isDefault(ifAddr) {
+			defaultIfAddrs = append(defaultIfAddrs, ifAddr)
+		}
+	}
+
+	return defaultIfAddrs, nil
+}
+
+// GetDefaultIPv4Interfaces returns IfAddrs of the IPv4 addresses on the
+// interfaces with an IPv4 default route, ordered by the metric of the default
+// routes.  An empty IfAddrs is returned if there is no IPv4 default route.
+func GetDefaultIPv4Interfaces() (IfAddrs, error) {
+	return GetDefaultIPv4InterfacesFrom(OSInterfaceProvider{})
+}
+
+// GetDefaultIPv4InterfacesFrom is GetDefaultIPv4Interfaces() using the
+// interfaces and default routes supplied by p.
+func GetDefaultIPv4InterfacesFrom(p InterfaceProvider) (IfAddrs, error) {
+	return getDefaultFamilyInterfacesFrom(p, TypeIPv4)
+}
+
+// GetDefaultIPv6Interfaces returns IfAddrs of the IPv6 addresses on the
+// interfaces with an IPv6 default route, ordered by the metric of the default
+// routes.  An empty IfAddrs is returned if there is no IPv6 default route.
+func GetDefaultIPv6Interfaces() (IfAddrs, error) {
+	return GetDefaultIPv6InterfacesFrom(OSInterfaceProvider{})
+}
+
+// GetDefaultIPv6InterfacesFrom is GetDefaultIPv6Interfaces() using the
+// interfaces and default routes supplied by p.
+func GetDefaultIPv6InterfacesFrom(p InterfaceProvider) (IfAddrs, error) {
+	return getDefaultFamilyInterfacesFrom(p, TypeIPv6)
+}
+
+// getDefaultFamilyInterfacesFrom returns the addresses of family on the
+// interfaces with a default route of family, ordered by the metric of the
+// default routes.
+func getDefaultFamilyInterfacesFrom(p InterfaceProvider, family SockAddrType) (IfAddrs, error) {
+	ifNames, err := GetDefaultInterfaceNamesFrom(p, family)
 	if err != nil {
 		return nil, err
 	}
 
-	var defaultIfs, ifAddrs IfAddrs
-	ifAddrs, err = GetAllInterfaces()
+	ifAddrs, err := GetAllInterfacesFrom(p)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, ifAddr := range ifAddrs {
-		if ifAddr.Name == defaultIfName {
-			defaultIfs = append(defaultIfs, ifAddr)
+	defaultIfAddrs := IfAddrs{}
+	for _, ifName := range ifNames {
+		for _, ifAddr := range ifAddrs {
+			if ifAddr.Name == ifName && ifAddr.SockAddr.Type() == family {
+				defaultIfAddrs = append(defaultIfAddrs, ifAddr)
+			}
 		}
 	}
 
+	return defaultIfAddrs, nil
+}
+
+// GetDefaultInterfaceNames returns the names of the interfaces with a default
+// route of family (TypeIPv4, TypeIPv6 or TypeIP for both), ordered by the
+// metric of their default routes, lowest first.  Hosts with equal-cost
+// multipath or several metric-ranked default routes return every interface.
+// If the routing table is not available on the platform, the single interface
+// reported by GetDefaultInterfaceName() is returned for every family.
+func GetDefaultInterfaceNames(family SockAddrType) ([]string, error) {
+	return GetDefaultInterfaceNamesFrom(OSInterfaceProvider{}, family)
+}
+
+// GetDefaultInterfaceNamesFrom is GetDefaultInterfaceNames() using the routing
+// table supplied by p if it is a RouteProvider, and the default interface of p
+// otherwise.
+func GetDefaultInterfaceNamesFrom(p InterfaceProvider, family SockAddrType) ([]string, error) {
+	routes, routesErr := providerRoutes(p)
+	return defaultInterfaceNames(routes, routesErr, family, p.DefaultInterfaceName)
+}
+
+// providerRoutes returns the routing table of p, or ErrNoRoute if p is not a
+// RouteProvider.
+func providerRoutes(p InterfaceProvider) ([]Route, error) {
+	rp, ok := p.(RouteProvider)
+	if !ok {
+		return nil, ErrNoRoute
+	}
+
+	return rp.Routes()
+}
+
+// defaultIfNames holds the names of the interfaces with an IPv4 and an IPv6
+// default route.
+type defaultIfNames struct {
+	ipv4 []string
+	ipv6 []string
+}
+
+// getDefaultIfNamesFrom returns the interfaces with a default route of each
+// address family as reported by p, or an error if there is no default route.
+func getDefaultIfNamesFrom(p InterfaceProvider) (defaultIfNames, error) {
+	routes, routesErr := providerRoutes(p)
+
+	var defaultIfs defaultIfNames
+	var err error
+	if defaultIfs.ipv4, err = defaultInterfaceNames(routes, routesErr, TypeIPv4, p.DefaultInterfaceName); err != nil {
+		return defaultIfNames{}, err
+	}
+	if defaultIfs.ipv6, err = defaultInterfaceNames(routes, routesErr, TypeIPv6, p.DefaultInterfaceName); err != nil {
+		return defaultIfNames{}, err
+	}
+
+	if len(defaultIfs.ipv4) == 0 && len(defaultIfs.ipv6) == 0 {
+		return defaultIfNames{}, errors.New("no default interface found")
+	}
 	return defaultIfs, nil
 }
 
+// isDefault returns true if the default route of ifAddr's address family uses
+// ifAddr's interface.  IfAddrs that are not IP addresses are attached to the
+// default route of either family.
+func (d defaultIfNames) isDefault(ifAddr IfAddr) bool {
+	switch ifAddr.SockAddr.Type() {
+	case TypeIPv4:
+		return slices.Contains(d.ipv4, ifAddr.Name)
+	case TypeIPv6:
+		return slices.Contains(d.ipv6, ifAddr.Name)
+	default:
+		return slices.Contains(d.ipv4, ifAddr.Name) || slices.Contains(d.ipv6, ifAddr.Name)
+	}
+}
+
+// GetInterfacesForDestination returns an IfAddrs with the address the host
+// would use as the source address to reach dst, which is parsed with
+// NewIPAddr().  Unlike the address on the default route, this is correct on
+// multi-homed hosts where dst is reached through a secondary network.  An
+// empty IfAddrs is returned if the outgoing interface has no address of dst's
+// family.  This function is the `eval` equivalent of:
+//
+// ```
+// $ sockaddr eval -r '{{GetInterfacesForDestination "10.1.2.3"}}'
+// ```
+func GetInterfacesForDestination(dst string) (IfAddrs, error) {
+	return GetInterfacesForDestinationFrom(OSInterfaceProvider{}, dst)
+}
+
+// GetInterfacesForDestinationFrom is GetInterfacesForDestination() using the
+// routing table and interfaces supplied by p.
+func GetInterfacesForDestinationFrom(p RouteProvider, dst string) (IfAddrs, error) {
+	dstAddr, err := NewIPAddr(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ifAddrs, err := lookupRouteSource(p, dstAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return ifAddrs, nil
+}
+
 // GetPrivateInterfaces returns an IfAddrs that are part of RFC 6890 and have a
 // default route.  If the system can't determine its IP address or find an RFC
 // 6890 IP address, an empty IfAddrs will be returned instead.  This function is
@@ -312,7 +597,13 @@ func GetDefaultInterfaces() (IfAddrs, error) {
 // $ sockaddr eval -r '{{GetAllInterfaces | include "type" "ip" | include "flags" "forwardable" | include "flags" "up" | sort "default,type,size" | include "RFC" "6890" }}'
 // ```
 func GetPrivateInterfaces() (IfAddrs, error) {
-	privateIfs, err := GetAllInterfaces()
+	return GetPrivateInterfacesFrom(OSInterfaceProvider{})
+}
+
+// GetPrivateInterfacesFrom is GetPrivateInterfaces() using the interfaces and
+// default route supplied by p.
+func GetPrivateInterfacesFrom(p InterfaceProvider) (IfAddrs, error) {
+	privateIfs, err := GetAllInterfacesFrom(p)
 	if err != nil {
 		return IfAddrs{}, err
 	}
@@ -339,7 +630,7 @@ func GetPrivateInterfaces() (IfAddrs, error) {
 		return IfAddrs{}, nil
 	}
 
-	OrderedIfAddrBy(AscIfDefault, AscIfType, AscIfNetworkSize).Sort(privateIfs)
+	OrderedIfAddrBy(ascIfDefaultFrom(p), AscIfType, AscIfNetworkSize).Sort(privateIfs)
 
 	privateIfs, _, err = IfByRFC("6890", privateIfs)
 	if err != nil {
@@ -360,7 +651,13 @@ func GetPrivateInterfaces() (IfAddrs, error) {
 // $ sockaddr eval -r '{{GetAllInterfaces | include "type" "ip" | include "flags" "forwardable" | include "flags" "up" | sort "default,type,size" | exclude "RFC" "6890" }}'
 // ```
 func GetPublicInterfaces() (IfAddrs, error) {
-	publicIfs, err := GetAllInterfaces()
+	return GetPublicInterfacesFrom(OSInterfaceProvider{})
+}
+
+// GetPublicInterfacesFrom is GetPublicInterfaces() using the interfaces and
+// default route supplied by p.
+func GetPublicInterfacesFrom(p InterfaceProvider) (IfAddrs, error) {
+	publicIfs, err := GetAllInterfacesFrom(p)
 	if err != nil {
 		return IfAddrs{}, err
 	}
@@ -387,7 +684,7 @@ func GetPublicInterfaces() (IfAddrs, error) {
 		return IfAddrs{}, nil
 	}
 
-	OrderedIfAddrBy(AscIfDefault, AscIfType, AscIfNetworkSize).Sort(publicIfs)
+	OrderedIfAddrBy(ascIfDefaultFrom(p), AscIfType, AscIfNetworkSize).Sort(publicIfs)
 
 	_, publicIfs, err = IfByRFC("6890", publicIfs)
 	if err != nil {
@@ -420,6 +717,55 @@ func IfByAddress(inputRe string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 	return matchedAddrs, excludedAddrs, nil
 }
 
+// IfByCarrier returns a list of IfAddrs on interfaces whose carrier is
+// inputCarrier ("up" or "down"), and the remaining IfAddrs.  IfAddrs whose
+// carrier was not reported by the platform never match.
+func IfByCarrier(inputCarrier string, ifAddrs IfAddrs) (matched, remainder IfAddrs, err error) {
+	carrier, err := parseLinkCarrier(inputCarrier)
+	if err != nil {
+		return nil, nil, err
+	}
+	if carrier == LinkCarrierUnknown {
+		return nil, nil, fmt.Errorf("invalid carrier %q, expected \"up\" or \"down\"", inputCarrier)
+	}
+
+	matchedAddrs := make(IfAddrs, 0, len(ifAddrs))
+	excludedAddrs := make(IfAddrs, 0, len(ifAddrs))
+	for _, ifAddr := range ifAddrs {
+		if ifAddr.Carrier == carrier {
+			matchedAddrs = append(matchedAddrs, ifAddr)
+		} else {
+			excludedAddrs = append(excludedAddrs, ifAddr)
+		}
+	}
+
+	return matchedAddrs, excludedAddrs, nil
+}
+
+// IfByKind returns a list of IfAddrs on interfaces of one of the "|" separated
+// kinds in inputKinds (e.g. "physical|bond"), and the remaining IfAddrs.
+// IfAddrs whose kind was not reported by the platform never match.
+func IfByKind(inputKinds string, ifAddrs IfAddrs) (matched, remainder IfAddrs, err error) {
+	kinds := strings.Split(strings.ToLower(inputKinds), "|")
+	for _, kind := range kinds {
+		if kind == "" {
+			return nil, nil, fmt.Errorf("empty interface kind in %+q", inputKinds)
+		}
+	}
+
+	matchedAddrs := make(IfAddrs, 0, len(ifAddrs))
+	excludedAddrs := make(IfAddrs, 0, len(ifAddrs))
+	for _, ifAddr := range ifAddrs {
+		if slices.Contains(kinds, string(ifAddr.Kind)) {
+			matchedAddrs = append(matchedAddrs, ifAddr)
+		} else {
+			excludedAddrs = append(excludedAddrs, ifAddr)
+		}
+	}
+
+	return matchedAddrs, excludedAddrs, nil
+}
+
 // IfByName returns a list of matched and non-matched IfAddrs, or an error if
 // the regexp fails to compile.
 func IfByName(inputRe string, ifAddrs IfAddrs) (matched, remainder IfAddrs, err error) {
@@ -441,6 +787,31 @@ func IfByName(inputRe string, ifAddrs IfAddrs) (matched, remainder IfAddrs, err
 	return matchedAddrs, excludedAddrs, nil
 }
 
+// IfByOperState returns a list of IfAddrs on interfaces in one of the "|"
+// separated operational states in inputStates (e.g. "up|unknown"), and the
+// remaining IfAddrs.  IfAddrs whose state was not reported by the platform
+// never match.
+func IfByOperState(inputStates string, ifAddrs IfAddrs) (matched, remainder IfAddrs, err error) {
+	states := strings.Split(strings.ToLower(inputStates), "|")
+	for _, state := range states {
+		if !slices.Contains(operStates, OperState(state)) {
+			return nil, nil, fmt.Errorf("invalid operstate %q in %+q", state, inputStates)
+		}
+	}
+
+	matchedAddrs := make(IfAddrs, 0, len(ifAddrs))
+	excludedAddrs := make(IfAddrs, 0, len(ifAddrs))
+	for _, ifAddr := range ifAddrs {
+		if slices.Contains(states, string(ifAddr.OperState)) {
+			matchedAddrs = append(matchedAddrs, ifAddr)
+		} else {
+			excludedAddrs = append(excludedAddrs, ifAddr)
+		}
+	}
+
+	return matchedAddrs, excludedAddrs, nil
+}
+
 // IfByPort returns a list of matched and non-matched IfAddrs, or an error if
 // the regexp fails to compile.
 func IfByPort(inputRe string, ifAddrs IfAddrs) (matchedIfs, excludedIfs IfAddrs, err error) {
@@ -480,22 +851,15 @@ func IfByRFC(selectorParam string, ifAddrs IfAddrs) (matched, remainder IfAddrs,
 	matchedIfAddrs := make(IfAddrs, 0, len(ifAddrs))
 	remainingIfAddrs := make(IfAddrs, 0, len(ifAddrs))
 
-	rfcNetMap := KnownRFCs()
-	rfcNets, ok := rfcNetMap[uint(inputRFC)]
+	rfcTable, ok := rfcTables()[uint(inputRFC)]
 	if !ok {
 		return nil, nil, fmt.Errorf("unsupported RFC %d", inputRFC)
 	}
 
 	for _, ifAddr := range ifAddrs {
-		var contained bool
-		for _, rfcNet := range rfcNets {
-			if rfcNet.Contains(ifAddr.SockAddr) {
-				matchedIfAddrs = append(matchedIfAddrs, ifAddr)
-				contained = true
-				break
-			}
-		}
-		if !contained {
+		if _, _, contained := rfcTable.Lookup(ifAddr.SockAddr); contained {
+			matchedIfAddrs = append(matchedIfAddrs, ifAddr)
+		} else {
 			remainingIfAddrs = append(remainingIfAddrs, ifAddr)
 		}
 	}
@@ -570,7 +934,7 @@ func IfByType(inputTypes string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 	ifTypes := strings.Split(strings.ToLower(inputTypes), "|")
 	for _, ifType := range ifTypes {
 		switch ifType {
-		case "ip", "ipv4", "ipv6", "unix":
+		case "ip", "ipv4", "ipv6", "unix", "vsock":
 			// Valid types
 		default:
 			return nil, nil, fmt.Errorf("unsupported type %q %q", ifType, inputTypes)
@@ -589,6 +953,8 @@ func IfByType(inputTypes string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 				matched = true
 			case ifType == "unix" && ifAddr.Type()&TypeUnix != 0:
 				matched = true
+			case ifType == "vsock" && ifAddr.Type()&TypeVSock != 0:
+				matched = true
 			}
 
 			if matched {
@@ -609,7 +975,9 @@ func IfByType(inputTypes string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 //
 // will include any IfAddrs that have both the "up" and "broadcast" flags set.
 // Any addresses on those interfaces that don't match will be omitted from the
-// results.
+// results.  The names of the AddrFlags (e.g. "permanent", "tentative" or
+// "deprecated") match the flags of the address itself rather than of its
+// interface.
 func IfByFlag(inputFlags string, ifAddrs IfAddrs) (matched, remainder IfAddrs, err error) {
 	matchedAddrs := make(IfAddrs, 0, len(ifAddrs))
 	excludedAddrs := make(IfAddrs, 0, len(ifAddrs))
@@ -623,7 +991,8 @@ func IfByFlag(inputFlags string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 		wantMulticast,
 		wantUnspecified bool
 	var ifFlags net.Flags
-	var checkFlags, checkAttrs bool
+	var addrFlags AddrFlags
+	var checkFlags, checkAddrFlags, checkAttrs bool
 	for flagName := range strings.SplitSeq(strings.ToLower(inputFlags), "|") {
 		switch flagName {
 		case "broadcast":
@@ -667,7 +1036,12 @@ func IfByFlag(inputFlags string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 			checkFlags = true
 			ifFlags = ifFlags | net.FlagUp
 		default:
-			return nil, nil, fmt.Errorf("unknown interface flag: %+q", flagName)
+			addrFlag, found := addrFlagByName(flagName)
+			if !found {
+				return nil, nil, fmt.Errorf("unknown interface flag: %+q", flagName)
+			}
+			checkAddrFlags = true
+			addrFlags |= addrFlag
 		}
 	}
 
@@ -676,6 +1050,9 @@ func IfByFlag(inputFlags string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 		if checkFlags && ifAddr.Flags&ifFlags == ifFlags {
 			matched = true
 		}
+		if checkAddrFlags && ifAddr.AddrFlags&addrFlags == addrFlags {
+			matched = true
+		}
 		if checkAttrs {
 			if ip := ToIPAddr(ifAddr.SockAddr); ip != nil {
 				netIP := (*ip).NetIP()
@@ -709,17 +1086,27 @@ func IfByFlag(inputFlags string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 }
 
 // IfByNetwork returns an IfAddrs that are equal to or included within the
-// network passed in by selector.
+// network passed in by selector.  The selector may also be an IPRange in the
+// `start-end` form (e.g. `10.0.0.5-10.0.1.200`).
 func IfByNetwork(selectorParam string, inputIfAddrs IfAddrs) (IfAddrs, IfAddrs, error) {
 	var includedIfs, excludedIfs IfAddrs
 	for netStr := range strings.SplitSeq(selectorParam, "|") {
-		netAddr, err := NewIPAddr(netStr)
-		if err != nil {
+		var contains func(SockAddr) bool
+		switch netAddr, err := NewIPAddr(netStr); {
+		case err == nil:
+			contains = netAddr.Contains
+		case strings.Contains(netStr, "-"):
+			ipRange, err := NewIPRange(netStr)
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to create an IP range from %+q: %v", netStr, err)
+			}
+			contains = ipRange.Contains
+		default:
 			return nil, nil, fmt.Errorf("unable to create an IP address from %+q: %v", netStr, err)
 		}
 
 		for _, ifAddr := range inputIfAddrs {
-			if netAddr.Contains(ifAddr.SockAddr) {
+			if contains(ifAddr.SockAddr) {
 				includedIfs = append(includedIfs, ifAddr)
 			} else {
 				excludedIfs = append(excludedIfs, ifAddr)
@@ -771,12 +1158,7 @@ func IfAddrMath(operation, value string, inputIfAddr IfAddr) (IfAddr, error) {
 			}
 
 			ipv6 := *ToIPv6Addr(inputIfAddr.SockAddr)
-			ipv6BigIntA := new(big.Int)
-			ipv6BigIntA.Set(ipv6.Address)
-			ipv6BigIntB := big.NewInt(i)
-
-			ipv6Addr := ipv6BigIntA.Add(ipv6BigIntA, ipv6BigIntB)
-			ipv6Addr.And(ipv6Addr, ipv6HostMask)
+			ipv6Addr := Uint128(ipv6.Address).addInt64(i)
 
 			return IfAddr{
 				SockAddr: IPv6Addr{
@@ -837,35 +1219,24 @@ func IfAddrMath(operation, value string, inputIfAddr IfAddr) (IfAddr, error) {
 			}
 
 			ipv6 := *ToIPv6Addr(inputIfAddr.SockAddr)
-			ipv6BigInt := new(big.Int)
-			ipv6BigInt.Set(ipv6.NetworkAddress())
+			ipv6Addr := Uint128(ipv6.NetworkAddress())
 
-			mask := new(big.Int)
-			mask.Set(ipv6.Mask)
+			mask := Uint128(ipv6.Mask)
 			if i > 0 {
-				wrappedMask := new(big.Int)
-				wrappedMask.SetInt64(i)
-
-				wrappedMask.AndNot(wrappedMask, mask)
-				ipv6BigInt.Add(ipv6BigInt, wrappedMask)
+				wrappedMask := Uint128{Lo: uint64(i)}.AndNot(mask)
+				ipv6Addr = ipv6Addr.Add(wrappedMask)
 			} else {
 				// Mask off any bits that exceed the network size.  Subtract the
 				// wrappedMask from the last usable - 1
-				wrappedMask := new(big.Int)
-				wrappedMask.SetInt64(-1 * i)
-				wrappedMask.Sub(wrappedMask, big.NewInt(1))
+				wrappedMask := Uint128{Lo: uint64(-i)}.Sub(Uint128{Lo: 1}).AndNot(mask)
 
-				wrappedMask.AndNot(wrappedMask, mask)
-
-				lastUsable := new(big.Int)
-				lastUsable.Set(ipv6.LastUsable().(IPv6Addr).Address)
-
-				ipv6BigInt = lastUsable.Sub(lastUsable, wrappedMask)
+				lastUsable := Uint128(ipv6.LastUsable().(IPv6Addr).Address)
+				ipv6Addr = lastUsable.Sub(wrappedMask)
 			}
 
 			return IfAddr{
 				SockAddr: IPv6Addr{
-					Address: IPv6Address(ipv6BigInt),
+					Address: IPv6Address(ipv6Addr),
 					Mask:    ipv6.Mask,
 				},
 				Interface: inputIfAddr.Interface,
@@ -918,25 +1289,18 @@ func IfAddrMath(operation, value string, inputIfAddr IfAddr) (IfAddr, error) {
 
 			ipv6 := *ToIPv6Addr(inputIfAddr.SockAddr)
 
-			ipv6Mask := net.CIDRMask(int(i), 128)
-			ipv6MaskBigInt := new(big.Int)
-			ipv6MaskBigInt.SetBytes(ipv6Mask)
-
-			maskedIpv6 := ipv6.NetIP().Mask(ipv6Mask)
-			maskedIpv6BigInt := new(big.Int)
-			maskedIpv6BigInt.SetBytes(maskedIpv6)
+			ipv6Mask := mask128(int(i))
+			maskedIpv6 := Uint128(ipv6.Address).And(ipv6Mask)
 
-			maskedIpv6MaskBigInt := new(big.Int)
-			maskedIpv6MaskBigInt.Set(ipv6.Mask)
-
-			if ipv6MaskBigInt.Cmp(maskedIpv6MaskBigInt) == -1 {
-				maskedIpv6MaskBigInt = ipv6MaskBigInt
+			maskedIpv6Mask := Uint128(ipv6.Mask)
+			if ipv6Mask.Cmp(maskedIpv6Mask) == -1 {
+				maskedIpv6Mask = ipv6Mask
 			}
 
 			return IfAddr{
 				SockAddr: IPv6Addr{
-					Address: IPv6Address(maskedIpv6BigInt),
-					Mask:    IPv6Mask(maskedIpv6MaskBigInt),
+					Address: IPv6Address(maskedIpv6),
+					Mask:    IPv6Mask(maskedIpv6Mask),
 				},
 				Interface: inputIfAddr.Interface,
 			}, nil
@@ -970,12 +1334,18 @@ func IncludeIfs(selectorName, selectorParam string, inputIfAddrs IfAddrs) (IfAdd
 	switch strings.ToLower(selectorName) {
 	case "address":
 		includedIfs, _, err = IfByAddress(selectorParam, inputIfAddrs)
+	case "carrier":
+		includedIfs, _, err = IfByCarrier(selectorParam, inputIfAddrs)
 	case "flag", "flags":
 		includedIfs, _, err = IfByFlag(selectorParam, inputIfAddrs)
+	case "kind":
+		includedIfs, _, err = IfByKind(selectorParam, inputIfAddrs)
 	case "name":
 		includedIfs, _, err = IfByName(selectorParam, inputIfAddrs)
 	case "network":
 		includedIfs, _, err = IfByNetwork(selectorParam, inputIfAddrs)
+	case "operstate":
+		includedIfs, _, err = IfByOperState(selectorParam, inputIfAddrs)
 	case "port":
 		includedIfs, _, err = IfByPort(selectorParam, inputIfAddrs)
 	case "rfc", "rfcs":
@@ -1003,12 +1373,18 @@ func ExcludeIfs(selectorName, selectorParam string, inputIfAddrs IfAddrs) (IfAdd
 	switch strings.ToLower(selectorName) {
 	case "address":
 		_, excludedIfs, err = IfByAddress(selectorParam, inputIfAddrs)
+	case "carrier":
+		_, excludedIfs, err = IfByCarrier(selectorParam, inputIfAddrs)
 	case "flag", "flags":
 		_, excludedIfs, err = IfByFlag(selectorParam, inputIfAddrs)
+	case "kind":
+		_, excludedIfs, err = IfByKind(selectorParam, inputIfAddrs)
 	case "name":
 		_, excludedIfs, err = IfByName(selectorParam, inputIfAddrs)
 	case "network":
 		_, excludedIfs, err = IfByNetwork(selectorParam, inputIfAddrs)
+	case "operstate":
+		_, excludedIfs, err = IfByOperState(selectorParam, inputIfAddrs)
 	case "port":
 		_, excludedIfs, err = IfByPort(selectorParam, inputIfAddrs)
 	case "rfc", "rfcs":
@@ -1031,6 +1407,12 @@ func ExcludeIfs(selectorName, selectorParam string, inputIfAddrs IfAddrs) (IfAdd
 // SortIfBy returns an IfAddrs sorted based on the passed in selector.  Multiple
 // sort clauses can be passed in as a comma delimited list without whitespace.
 func SortIfBy(selectorParam string, inputIfAddrs IfAddrs) (IfAddrs, error) {
+	return SortIfByFrom(OSInterfaceProvider{}, selectorParam, inputIfAddrs)
+}
+
+// SortIfByFrom is SortIfBy() using the default route supplied by p for the
+// "default" selector.
+func SortIfByFrom(p InterfaceProvider, selectorParam string, inputIfAddrs IfAddrs) (IfAddrs, error) {
 	sortedIfs := append(IfAddrs(nil), inputIfAddrs...)
 
 	clauses := strings.Split(selectorParam, ",")
@@ -1047,9 +1429,24 @@ func SortIfBy(selectorParam string, inputIfAddrs IfAddrs) (IfAddrs, error) {
 		case "-address":
 			sortFuncs[i] = DescIfAddress
 		case "+default", "default":
-			sortFuncs[i] = AscIfDefault
+			sortFuncs[i] = ascIfDefaultFrom(p)
 		case "-default":
-			sortFuncs[i] = DescIfDefault
+			ascIfDefault := ascIfDefaultFrom(p)
+			sortFuncs[i] = func(p1Ptr, p2Ptr *IfAddr) int {
+				return -1 * ascIfDefault(p1Ptr, p2Ptr)
+			}
+		case "+index", "index":
+			// The "index" selector returns an array of IfAddrs
+			// ordered by the interface index.
+			sortFuncs[i] = AscIfIndex
+		case "-index":
+			sortFuncs[i] = DescIfIndex
+		case "+mtu", "mtu":
+			// The "mtu" selector returns an array of IfAddrs
+			// ordered by the interface MTU, smallest first.
+			sortFuncs[i] = AscIfMTU
+		case "-mtu":
+			sortFuncs[i] = DescIfMTU
 		case "+name", "name":
 			// The "name" selector returns an array of IfAddrs
 			// ordered by the interface name.
@@ -1080,6 +1477,13 @@ func SortIfBy(selectorParam string, inputIfAddrs IfAddrs) (IfAddrs, error) {
 			sortFuncs[i] = AscIfNetworkSize
 		case "-size":
 			sortFuncs[i] = DescIfNetworkSize
+		case "+speed", "speed":
+			// The "speed" selector returns an array of IfAddrs
+			// ordered by the speed of the interface's link, slowest
+			// and unknown first.
+			sortFuncs[i] = AscIfSpeed
+		case "-speed":
+			sortFuncs[i] = DescIfSpeed
 		case "+type", "type":
 			// The "type" selector returns an array of IfAddrs
 			// ordered by the type of the IfAddr.  The sort order is
@@ -1173,6 +1577,62 @@ func OffsetIfAddrs(off int, in IfAddrs) (IfAddrs, error) {
 	return in[off:], nil
 }
 
+// maxIfAddrsExpansion is the maximum number of IfAddrs that HostIfAddrs() and
+// SubnetIfAddrs() will return.
+const maxIfAddrsExpansion = 65536
+
+// HostIfAddrs returns an IfAddr for every host address in the network of each
+// IP IfAddr (see IPAddr.Hosts()).  Non-IP IfAddrs are skipped.  An error is
+// returned if the result would exceed 65536 addresses.
+func HostIfAddrs(in IfAddrs) (IfAddrs, error) {
+	return expandIfAddrs(in, func(ip IPAddr) (iter.Seq[IPAddr], error) {
+		return ip.Hosts(), nil
+	})
+}
+
+// SubnetIfAddrs returns an IfAddr for every subnet with a prefix length of
+// prefixLen in the network of each IP IfAddr (see IPAddr.Subnets()).  Non-IP
+// IfAddrs are skipped.  An error is returned if prefixLen is not valid for an
+// input or if the result would exceed 65536 networks.
+func SubnetIfAddrs(prefixLen uint, in IfAddrs) (IfAddrs, error) {
+	return expandIfAddrs(in, func(ip IPAddr) (iter.Seq[IPAddr], error) {
+		if int(prefixLen) < ip.Maskbits() || int(prefixLen) > len(*ip.NetIP())*8 {
+			return nil, fmt.Errorf("invalid prefix length %d for %s", prefixLen, ip)
+		}
+		return ip.Subnets(int(prefixLen)), nil
+	})
+}
+
+// expandIfAddrs replaces every IP IfAddr with the IPAddrs returned by fn,
+// preserving the Interface of the input.
+func expandIfAddrs(in IfAddrs, fn func(IPAddr) (iter.Seq[IPAddr], error)) (IfAddrs, error) {
+	out := make(IfAddrs, 0, len(in))
+	for _, ifAddr := range in {
+		ip, ok := ifAddr.SockAddr.(IPAddr)
+		if !ok {
+			continue
+		}
+
+		seq, err := fn(ip)
+		if err != nil {
+			return nil, err
+		}
+
+		for child := range seq {
+			if len(out) == maxIfAddrsExpansion {
+				return nil, fmt.Errorf("too many results: limit is %d", maxIfAddrsExpansion)
+			}
+
+			out = append(out, IfAddr{
+				SockAddr:  child,
+				Interface: ifAddr.Interface,
+			})
+		}
+	}
+
+	return out, nil
+}
+
 func (ifAddr IfAddr) String() string {
 	return fmt.Sprintf("%s %v", ifAddr.SockAddr, ifAddr.Interface)
 }
@@ -1227,6 +1687,101 @@ func parseIfNameFromIPCmd(routeOut string) [][]string {
 	return parsedLines
 }
 
+// Route flags from the Linux kernel's include/uapi/linux/route.h and
+// include/uapi/linux/ipv6_route.h used by /proc/net/route and
+// /proc/net/ipv6_route.
+const (
+	rtfUp     = 0x0001
+	rtfReject = 0x0200
+)
+
+// parseDefaultIfNameFromProcNetRoute parses the default interface from Linux's
+// /proc/net/route.  When there are several default routes the one with the
+// lowest metric wins, matching the kernel's route selection.
+func parseDefaultIfNameFromProcNetRoute(routeOut string) (string, error) {
+	var ifName string
+	var bestMetric uint64
+	for i, line := range strings.Split(routeOut, "\n") {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask MTU Window IRTT
+		fields := strings.Fields(line)
+		if i == 0 || len(fields) < 8 {
+			continue
+		}
+
+		dest, err := strconv.ParseUint(fields[1], 16, 32)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse destination %+q: %w", fields[1], err)
+		}
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse flags %+q: %w", fields[3], err)
+		}
+		metric, err := strconv.ParseUint(fields[6], 10, 32)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse metric %+q: %w", fields[6], err)
+		}
+		mask, err := strconv.ParseUint(fields[7], 16, 32)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse mask %+q: %w", fields[7], err)
+		}
+
+		if dest != 0 || mask != 0 || flags&rtfUp == 0 || flags&rtfReject != 0 {
+			continue
+		}
+		if ifName == "" || metric < bestMetric {
+			ifName, bestMetric = fields[0], metric
+		}
+	}
+
+	if ifName == "" {
+		return "", errors.New("no default interface found")
+	}
+	return ifName, nil
+}
+
+// parseDefaultIfNameFromProcNetIPv6Route parses the default interface from
+// Linux's /proc/net/ipv6_route.  When there are several default routes the one
+// with the lowest metric wins, matching the kernel's route selection.
+func parseDefaultIfNameFromProcNetIPv6Route(routeOut string) (string, error) {
+	var ifName string
+	var bestMetric uint64
+	for _, line := range strings.Split(routeOut, "\n") {
+		// Destination DestPrefixLen Source SourcePrefixLen NextHop Metric
+		// RefCnt Use Flags Iface
+		fields := strings.Fields(line)
+		if len(fields) < 10 {
+			continue
+		}
+
+		prefixLen, err := strconv.ParseUint(fields[1], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse prefix length %+q: %w", fields[1], err)
+		}
+		metric, err := strconv.ParseUint(fields[5], 16, 32)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse metric %+q: %w", fields[5], err)
+		}
+		flags, err := strconv.ParseUint(fields[8], 16, 32)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse flags %+q: %w", fields[8], err)
+		}
+
+		// The kernel lists unreachable default routes on the loopback
+		// interface with the reject flag.
+		if prefixLen != 0 || strings.Trim(fields[0], "0") != "" || flags&rtfUp == 0 || flags&rtfReject != 0 {
+			continue
+		}
+		if ifName == "" || metric < bestMetric {
+			ifName, bestMetric = fields[9], metric
+		}
+	}
+
+	if ifName == "" {
+		return "", errors.New("no default interface found")
+	}
+	return ifName, nil
+}
+
 // parseDefaultIfNameWindows parses the default interface from `netstat -rn` and
 // `ipconfig` on Windows.
 //
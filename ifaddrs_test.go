@@ -368,6 +368,28 @@ func TestFilterIfByFlags(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "permanent",
+			selector: "permanent",
+			ifAddrs: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					Interface: net.Interface{},
+					SockAddr:  sockaddr.MustIPv6Addr("2001:db8::1/64"),
+					AddrFlags: sockaddr.AddrFlagPermanent | sockaddr.AddrFlagNoPrefixRoute,
+				},
+			},
+		},
+		{
+			name:     "temporary deprecated",
+			selector: "temporary|deprecated",
+			ifAddrs: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					Interface: net.Interface{},
+					SockAddr:  sockaddr.MustIPv6Addr("2001:db8::2/64"),
+					AddrFlags: sockaddr.AddrFlagTemporary | sockaddr.AddrFlagDeprecated,
+				},
+			},
+		},
 		{
 			name:     "invalid",
 			selector: "foo",
@@ -492,6 +514,44 @@ func TestIfByNetwork(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "range",
+			input: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.0.0.5"),
+				},
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.0.1.0/25"),
+				},
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.0.1.0/24"),
+				},
+			},
+			selector: "10.0.0.5-10.0.1.200",
+			matched: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.0.0.5"),
+				},
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.0.1.0/25"),
+				},
+			},
+			excluded: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.0.1.0/24"),
+				},
+			},
+		},
+		{
+			name: "invalid range",
+			input: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("1.2.3.4"),
+				},
+			},
+			selector: "10.0.1.200-10.0.0.5",
+			fail:     true,
+		},
 		{
 			name: "invalid selector",
 			input: sockaddr.IfAddrs{
@@ -705,7 +765,7 @@ func TestGetDefaultInterface(t *testing.T) {
 }
 
 func TestIfAddrAttrs(t *testing.T) {
-	const expectedNumAttrs = 2
+	const expectedNumAttrs = 12
 	attrs := sockaddr.IfAddrAttrs()
 	if len(attrs) != expectedNumAttrs {
 		t.Fatalf("wrong number of attrs")
@@ -727,6 +787,124 @@ func TestIfAddrAttrs(t *testing.T) {
 			attr:     "name",
 			expected: "abc0",
 		},
+		{
+			name: "address_flags",
+			ifAddr: sockaddr.IfAddr{
+				AddrFlags: sockaddr.AddrFlagTemporary | sockaddr.AddrFlagDeprecated,
+			},
+			attr:     "address_flags",
+			expected: "temporary|deprecated",
+		},
+		{
+			name: "scope",
+			ifAddr: sockaddr.IfAddr{
+				AddrScope: sockaddr.AddrScopeLink,
+			},
+			attr:     "scope",
+			expected: "link",
+		},
+		{
+			name: "mtu",
+			ifAddr: sockaddr.IfAddr{
+				Interface: net.Interface{
+					MTU: 9001,
+				},
+			},
+			attr:     "mtu",
+			expected: "9001",
+		},
+		{
+			name: "index",
+			ifAddr: sockaddr.IfAddr{
+				Interface: net.Interface{
+					Index: 4,
+				},
+			},
+			attr:     "index",
+			expected: "4",
+		},
+		{
+			name: "hardware_addr",
+			ifAddr: sockaddr.IfAddr{
+				Interface: net.Interface{
+					HardwareAddr: net.HardwareAddr{0x02, 0x42, 0xac, 0x11, 0x00, 0x02},
+				},
+			},
+			attr:     "hardware_addr",
+			expected: "02:42:ac:11:00:02",
+		},
+		{
+			name: "kind",
+			ifAddr: sockaddr.IfAddr{
+				Kind: sockaddr.InterfaceKindVeth,
+			},
+			attr:     "kind",
+			expected: "veth",
+		},
+		{
+			name: "operstate",
+			ifAddr: sockaddr.IfAddr{
+				OperState: sockaddr.OperStateLowerLayerDown,
+			},
+			attr:     "operstate",
+			expected: "lowerlayerdown",
+		},
+		{
+			name: "carrier",
+			ifAddr: sockaddr.IfAddr{
+				Carrier: sockaddr.LinkCarrierDown,
+			},
+			attr:     "carrier",
+			expected: "down",
+		},
+		{
+			name: "speed",
+			ifAddr: sockaddr.IfAddr{
+				Speed: 25000,
+			},
+			attr:     "speed",
+			expected: "25000",
+		},
+		{
+			name: "duplex",
+			ifAddr: sockaddr.IfAddr{
+				Duplex: "full",
+			},
+			attr:     "duplex",
+			expected: "full",
+		},
+		{
+			name: "unreported speed",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.0.0.5/24"),
+			},
+			attr:     "speed",
+			expected: "",
+		},
+		{
+			name: "unreported carrier",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.0.0.5/24"),
+			},
+			attr:     "carrier",
+			expected: "",
+		},
+		{
+			name: "hardware_addr of a virtual interface",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("127.0.0.1/8"),
+			},
+			attr:     "hardware_addr",
+			expected: "",
+		},
+		{
+			name: "unreported address_flags",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.0.0.5/24"),
+			},
+			attr:     "address_flags",
+			expected: "",
+		},
 	}
 
 	for i, test := range tests {
@@ -921,6 +1099,106 @@ func TestIncludeExcludeIfs(t *testing.T) {
 			includeNum:   2,
 			includeParam: `loopback`,
 		},
+		{
+			name: "kind",
+			ifAddrs: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.0.0.5/24"),
+					Kind:     sockaddr.InterfaceKindPhysical,
+				},
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("172.17.0.1/16"),
+					Kind:     sockaddr.InterfaceKindBridge,
+				},
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv6Addr("fe80::1/64"),
+					Kind:     sockaddr.InterfaceKindVeth,
+				},
+			},
+			excludeName:  "kind",
+			excludeNum:   1,
+			excludeParam: `bridge|veth`,
+			includeName:  "kind",
+			includeNum:   1,
+			includeParam: `physical`,
+		},
+		{
+			name:         "kind invalid",
+			fail:         true,
+			excludeName:  "kind",
+			excludeNum:   0,
+			excludeParam: `veth|`,
+			includeName:  "kind",
+			includeNum:   0,
+			includeParam: ``,
+		},
+		{
+			name: "operstate",
+			ifAddrs: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					SockAddr:  sockaddr.MustIPv4Addr("10.0.0.5/24"),
+					OperState: sockaddr.OperStateUp,
+					Carrier:   sockaddr.LinkCarrierUp,
+				},
+				sockaddr.IfAddr{
+					SockAddr:  sockaddr.MustIPv4Addr("10.1.0.5/24"),
+					OperState: sockaddr.OperStateDown,
+					Carrier:   sockaddr.LinkCarrierDown,
+				},
+				sockaddr.IfAddr{
+					SockAddr:  sockaddr.MustIPv4Addr("10.8.0.1/24"),
+					OperState: sockaddr.OperStateUnknown,
+				},
+			},
+			excludeName:  "operstate",
+			excludeNum:   1,
+			excludeParam: `up|unknown`,
+			includeName:  "operstate",
+			includeNum:   1,
+			includeParam: `up`,
+		},
+		{
+			name:         "operstate invalid",
+			fail:         true,
+			excludeName:  "operstate",
+			excludeNum:   0,
+			excludeParam: `up|`,
+			includeName:  "operstate",
+			includeNum:   0,
+			includeParam: `running`,
+		},
+		{
+			name: "carrier",
+			ifAddrs: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.0.0.5/24"),
+					Carrier:  sockaddr.LinkCarrierUp,
+				},
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.1.0.5/24"),
+					Carrier:  sockaddr.LinkCarrierDown,
+				},
+				sockaddr.IfAddr{
+					SockAddr: sockaddr.MustIPv4Addr("10.8.0.1/24"),
+				},
+			},
+			excludeName:  "carrier",
+			excludeNum:   2,
+			excludeParam: `down`,
+			includeName:  "carrier",
+			includeNum:   1,
+			includeParam: `up`,
+		},
+		{
+			name:         "carrier invalid",
+			fail:         true,
+			excludeName:  "carrier",
+			excludeNum:   0,
+			excludeParam: `unknown`,
+			includeName:  "carrier",
+			includeNum:   0,
+			includeParam: `1`,
+		},
 		{
 			name:         "flag invalid",
 			fail:         true,
@@ -1341,7 +1619,7 @@ func TestNewIPAddr(t *testing.T) {
 }
 
 func TestIPAttrs(t *testing.T) {
-	const expectedIPAttrs = 11
+	const expectedIPAttrs = 12
 	ipAttrs := sockaddr.IPAttrs()
 	if len(ipAttrs) != expectedIPAttrs {
 		t.Fatalf("wrong number of args")
@@ -1968,6 +2246,76 @@ func TestSortIfBy(t *testing.T) {
 				sockaddr.IfAddr{SockAddr: sockaddr.MustIPv4Addr("1.2.3.4:80")},
 			},
 		},
+		{
+			name:    "sort mtu",
+			sortStr: "mtu,index",
+			in: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0", Index: 2, MTU: 9001}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "tun0", Index: 4, MTU: 1400}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth1", Index: 3, MTU: 1500}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "tun1", Index: 5, MTU: 1400}},
+			},
+			out: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "tun0", Index: 4, MTU: 1400}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "tun1", Index: 5, MTU: 1400}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth1", Index: 3, MTU: 1500}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0", Index: 2, MTU: 9001}},
+			},
+		},
+		{
+			name:    "sort -mtu",
+			sortStr: "-mtu",
+			in: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "tun0", Index: 4, MTU: 1400}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0", Index: 2, MTU: 9001}},
+			},
+			out: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0", Index: 2, MTU: 9001}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "tun0", Index: 4, MTU: 1400}},
+			},
+		},
+		{
+			name:    "sort -index",
+			sortStr: "-index",
+			in: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "lo", Index: 1}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0", Index: 2}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth1", Index: 3}},
+			},
+			out: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth1", Index: 3}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0", Index: 2}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "lo", Index: 1}},
+			},
+		},
+		{
+			name:    "sort -speed",
+			sortStr: "-speed,name",
+			in: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "wg0"}},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0"}, Speed: 1000},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth2"}, Speed: 25000},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth1"}, Speed: 1000},
+			},
+			out: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth2"}, Speed: 25000},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0"}, Speed: 1000},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth1"}, Speed: 1000},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "wg0"}},
+			},
+		},
+		{
+			name:    "sort speed",
+			sortStr: "speed",
+			in: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0"}, Speed: 10000},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth1"}, Speed: 100},
+			},
+			out: sockaddr.IfAddrs{
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth1"}, Speed: 100},
+				sockaddr.IfAddr{Interface: net.Interface{Name: "eth0"}, Speed: 10000},
+			},
+		},
 		{
 			name:    "sort invalid",
 			sortStr: "ENOENT",
@@ -12,6 +12,20 @@ import (
 type IfAddr struct {
 	SockAddr
 	net.Interface
+
+	// AddrFlags and AddrScope are the flags and scope of the address, if
+	// reported by the platform (see AddrInfoProvider).
+	AddrFlags AddrFlags
+	AddrScope AddrScope
+
+	// Kind is the kind of the interface and OperState, Carrier, Speed and
+	// Duplex the state of its link, if reported by the platform (see
+	// LinkInfoProvider).
+	Kind      InterfaceKind
+	OperState OperState
+	Carrier   LinkCarrier
+	Speed     int
+	Duplex    string
 }
 
 // Attr returns the named attribute as a string
@@ -21,6 +35,14 @@ func (ifAddr IfAddr) Attr(attrName AttrName) (string, error) {
 		return val, nil
 	}
 
+	// The flags and scope of an address, the kind and link state of an
+	// interface and the hardware address of virtual interfaces may
+	// legitimately be empty.
+	switch attrName {
+	case "address_flags", "carrier", "duplex", "hardware_addr", "kind", "operstate", "scope", "speed":
+		return "", nil
+	}
+
 	return Attr(ifAddr.SockAddr, attrName)
 }
 
@@ -42,10 +64,10 @@ func Attr(sa SockAddr, attrName AttrName) (string, error) {
 				return attrVal, nil
 			}
 		case TypeIPv6:
+			// Some IPv6 attributes, such as zone, may legitimately be empty.
 			ipv6 := *ToIPv6Addr(sa)
-			attrVal := IPv6AddrAttr(ipv6, attrName)
-			if attrVal != "" {
-				return attrVal, nil
+			if _, found := ipv6AddrAttrMap[attrName]; found {
+				return IPv6AddrAttr(ipv6, attrName), nil
 			}
 		}
 
@@ -55,6 +77,13 @@ func Attr(sa SockAddr, attrName AttrName) (string, error) {
 		if attrVal != "" {
 			return attrVal, nil
 		}
+
+	case sockType == TypeVSock:
+		vs := *ToVSock(sa)
+		attrVal := VSockAttr(vs, attrName)
+		if attrVal != "" {
+			return attrVal, nil
+		}
 	}
 
 	// Non type-specific attributes
@@ -5,8 +5,9 @@ package sockaddr
 
 import (
 	"fmt"
-	"math/big"
+	"iter"
 	"net"
+	"net/netip"
 	"strings"
 )
 
@@ -28,14 +29,20 @@ type IPAddr interface {
 	CmpPort(SockAddr) int
 	FirstUsable() IPAddr
 	Host() IPAddr
+	Hosts() iter.Seq[IPAddr]
 	IPPort() IPPort
 	LastUsable() IPAddr
 	Maskbits() int
 	NetIP() *net.IP
 	NetIPMask() *net.IPMask
 	NetIPNet() *net.IPNet
+	NetipAddr() netip.Addr
+	NetipAddrPort() netip.AddrPort
+	NetipPrefix() netip.Prefix
 	Network() IPAddr
 	Octets() []int
+	ReverseName() string
+	Subnets(newPrefixLen int) iter.Seq[IPAddr]
 }
 
 // IPPort is the type for an IP port number for the TCP and UDP IP transports.
@@ -69,6 +76,33 @@ func NewIPAddr(addr string) (IPAddr, error) {
 	return nil, fmt.Errorf("invalid IPAddr %v", addr)
 }
 
+// NewIPAddrFromNetipAddr creates a new host IPAddr (i.e. a /32 or /128) from a
+// netip.Addr.  IPv4-mapped IPv6 addresses (e.g. `::ffff:192.0.2.1`) are
+// returned as an IPv6Addr so that the conversion round-trips through
+// NetipAddr().
+func NewIPAddrFromNetipAddr(addr netip.Addr) (IPAddr, error) {
+	return newIPAddrFromNetip(addr, addr.BitLen(), 0)
+}
+
+// NewIPAddrFromNetipAddrPort creates a new host IPAddr (i.e. a /32 or /128)
+// with its port set from a netip.AddrPort.
+func NewIPAddrFromNetipAddrPort(addrPort netip.AddrPort) (IPAddr, error) {
+	addr := addrPort.Addr()
+	return newIPAddrFromNetip(addr, addr.BitLen(), addrPort.Port())
+}
+
+// NewIPAddrFromNetipPrefix creates a new IPAddr from a netip.Prefix.  Like
+// NewIPAddr(), the host bits of the prefix's address are preserved (e.g.
+// `192.168.1.10/24` remains `192.168.1.10/24`); use Network() to obtain the
+// network address.
+func NewIPAddrFromNetipPrefix(prefix netip.Prefix) (IPAddr, error) {
+	if !prefix.IsValid() {
+		return nil, fmt.Errorf("invalid netip.Prefix %v", prefix)
+	}
+
+	return newIPAddrFromNetip(prefix.Addr(), prefix.Bits(), 0)
+}
+
 // IPAddrAttr returns a string representation of an attribute for the given
 // IPAddr.
 func IPAddrAttr(ip IPAddr, selector AttrName) string {
@@ -95,6 +129,21 @@ func MustIPAddr(addr string) IPAddr {
 	return ip
 }
 
+// newIPAddrFromNetip is the common constructor for the NewIPAddrFromNetip*()
+// family of functions.
+func newIPAddrFromNetip(addr netip.Addr, maskBits int, port uint16) (IPAddr, error) {
+	switch {
+	case !addr.IsValid():
+		return nil, fmt.Errorf("invalid netip.Addr %v", addr)
+	case maskBits < 0 || maskBits > addr.BitLen():
+		return nil, fmt.Errorf("invalid mask size %d for %v", maskBits, addr)
+	case addr.Is4():
+		return newIPv4AddrFromNetip(addr, maskBits, IPPort(port)), nil
+	default:
+		return newIPv6AddrFromNetip(addr, maskBits, IPPort(port)), nil
+	}
+}
+
 // ipAddrInit is called once at init()
 func ipAddrInit() {
 	// Sorted for human readability
@@ -110,6 +159,7 @@ func ipAddrInit() {
 		"first_usable",
 		"last_usable",
 		"octets",
+		"reverse_dns",
 	}
 
 	ipAddrAttrMap = map[AttrName]func(ip IPAddr) string{
@@ -143,10 +193,8 @@ func ipAddrInit() {
 				}
 				return ipv4Mask.String()
 			case IPv6Addr:
-				ipv6Mask := new(big.Int)
-				ipv6Mask.Set(v.Mask)
 				ipv6MaskAddr := IPv6Addr{
-					Address: IPv6Address(ipv6Mask),
+					Address: IPv6Address(v.Mask),
 					Mask:    ipv6HostMask,
 				}
 				return ipv6MaskAddr.String()
@@ -168,5 +216,8 @@ func ipAddrInit() {
 		"port": func(ip IPAddr) string {
 			return fmt.Sprintf("%d", ip.IPPort())
 		},
+		"reverse_dns": func(ip IPAddr) string {
+			return ip.Host().ReverseName()
+		},
 	}
 }
@@ -5,6 +5,8 @@ package sockaddr_test
 
 import (
 	"fmt"
+	"net/netip"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/go-sockaddr"
@@ -223,3 +225,314 @@ func TestSockAddr_IPAddr_CmpPort(t *testing.T) {
 		})
 	}
 }
+
+func TestSockAddr_IPAddr_Netip(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       sockaddr.IPAddr
+		addr     string
+		addrPort string
+		prefix   string
+	}{
+		{
+			name:     "ipv4 host",
+			ip:       sockaddr.MustIPv4Addr("192.0.2.1"),
+			addr:     "192.0.2.1",
+			addrPort: "192.0.2.1:0",
+			prefix:   "192.0.2.1/32",
+		},
+		{
+			name:     "ipv4 host and port",
+			ip:       sockaddr.MustIPv4Addr("192.0.2.1:8500"),
+			addr:     "192.0.2.1",
+			addrPort: "192.0.2.1:8500",
+			prefix:   "192.0.2.1/32",
+		},
+		{
+			name:     "ipv4 cidr",
+			ip:       sockaddr.MustIPv4Addr("192.168.10.24/24"),
+			addr:     "192.168.10.24",
+			addrPort: "192.168.10.24:0",
+			prefix:   "192.168.10.24/24",
+		},
+		{
+			name:     "ipv4 default route",
+			ip:       sockaddr.MustIPv4Addr("0.0.0.0/0"),
+			addr:     "0.0.0.0",
+			addrPort: "0.0.0.0:0",
+			prefix:   "0.0.0.0/0",
+		},
+		{
+			name:     "ipv6 host",
+			ip:       sockaddr.MustIPv6Addr("2001:db8::1"),
+			addr:     "2001:db8::1",
+			addrPort: "[2001:db8::1]:0",
+			prefix:   "2001:db8::1/128",
+		},
+		{
+			name:     "ipv6 host and port",
+			ip:       sockaddr.MustIPv6Addr("[2001:db8::1]:443"),
+			addr:     "2001:db8::1",
+			addrPort: "[2001:db8::1]:443",
+			prefix:   "2001:db8::1/128",
+		},
+		{
+			name:     "ipv6 cidr",
+			ip:       sockaddr.MustIPv6Addr("2001:db8::7/64"),
+			addr:     "2001:db8::7",
+			addrPort: "[2001:db8::7]:0",
+			prefix:   "2001:db8::7/64",
+		},
+		{
+			name:     "ipv4-mapped ipv6",
+			ip:       sockaddr.MustIPv6Addr("::ffff:192.0.2.1"),
+			addr:     "::ffff:192.0.2.1",
+			addrPort: "[::ffff:192.0.2.1]:0",
+			prefix:   "::ffff:192.0.2.1/128",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.ip.NetipAddr().String(); got != test.addr {
+				t.Errorf("NetipAddr(): got %q; want %q", got, test.addr)
+			}
+			if got := test.ip.NetipAddrPort().String(); got != test.addrPort {
+				t.Errorf("NetipAddrPort(): got %q; want %q", got, test.addrPort)
+			}
+			if got := test.ip.NetipPrefix().String(); got != test.prefix {
+				t.Errorf("NetipPrefix(): got %q; want %q", got, test.prefix)
+			}
+
+			// Hosts round-trip with their port via netip.AddrPort and
+			// networks round-trip with their mask via netip.Prefix.
+			if test.ip.Maskbits() == len(*test.ip.NetIP())*8 {
+				ip, err := sockaddr.NewIPAddrFromNetipAddrPort(test.ip.NetipAddrPort())
+				if err != nil {
+					t.Fatalf("NewIPAddrFromNetipAddrPort(): %v", err)
+				}
+				if !ip.Equal(test.ip) {
+					t.Errorf("AddrPort round-trip: got %v; want %v", ip, test.ip)
+				}
+			}
+
+			ip, err := sockaddr.NewIPAddrFromNetipPrefix(test.ip.NetipPrefix())
+			if err != nil {
+				t.Fatalf("NewIPAddrFromNetipPrefix(): %v", err)
+			}
+			if ip.Type() != test.ip.Type() || ip.Maskbits() != test.ip.Maskbits() || ip.CmpAddress(test.ip) != 0 {
+				t.Errorf("Prefix round-trip: got %v; want %v", ip, test.ip)
+			}
+
+			ip, err = sockaddr.NewIPAddrFromNetipAddr(test.ip.NetipAddr())
+			if err != nil {
+				t.Fatalf("NewIPAddrFromNetipAddr(): %v", err)
+			}
+			if test.ip.IPPort() == 0 && !ip.Equal(test.ip.Host()) {
+				t.Errorf("Addr round-trip: got %v; want %v", ip, test.ip.Host())
+			}
+		})
+	}
+}
+
+func TestSockAddr_IPAddr_NetipInvalid(t *testing.T) {
+	if _, err := sockaddr.NewIPAddrFromNetipAddr(netip.Addr{}); err == nil {
+		t.Errorf("expected an error for the zero netip.Addr")
+	}
+	if _, err := sockaddr.NewIPAddrFromNetipPrefix(netip.Prefix{}); err == nil {
+		t.Errorf("expected an error for the zero netip.Prefix")
+	}
+
+	noncanonical := sockaddr.IPv4Addr{
+		Address: sockaddr.IPv4Address(0xc0000201),
+		Mask:    sockaddr.IPv4Mask(0xff00ff00),
+	}
+	if noncanonical.NetipPrefix().IsValid() {
+		t.Errorf("expected an invalid netip.Prefix for a non-canonical mask")
+	}
+}
+
+func TestSockAddr_SockAddrs_Netip(t *testing.T) {
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+	sas, err := sockaddr.NewSockAddrsFromNetipPrefixes(prefixes)
+	if err != nil {
+		t.Fatalf("unable to convert prefixes: %v", err)
+	}
+	sas = append(sas, sockaddr.MustUnixSock("/tmp/foo"))
+
+	if got := sas.NetipPrefixes(); len(got) != len(prefixes) || got[0] != prefixes[0] || got[1] != prefixes[1] {
+		t.Errorf("NetipPrefixes(): got %v; want %v", got, prefixes)
+	}
+	if got := sas.NetipAddrs(); len(got) != len(prefixes) || got[0] != prefixes[0].Addr() || got[1] != prefixes[1].Addr() {
+		t.Errorf("NetipAddrs(): got %v; want %v", got, prefixes)
+	}
+
+	ifAddrs := sockaddr.IfAddrs{
+		{SockAddr: sas[0]},
+		{SockAddr: sas[1]},
+		{SockAddr: sas[2]},
+	}
+	if got := ifAddrs.NetipPrefixes(); len(got) != len(prefixes) || got[1] != prefixes[1] {
+		t.Errorf("IfAddrs.NetipPrefixes(): got %v; want %v", got, prefixes)
+	}
+	if got := ifAddrs.NetipAddrs(); len(got) != len(prefixes) || got[0] != prefixes[0].Addr() {
+		t.Errorf("IfAddrs.NetipAddrs(): got %v; want %v", got, prefixes)
+	}
+}
+
+func TestSockAddr_IPAddr_Subnets(t *testing.T) {
+	tests := []struct {
+		name      string
+		ip        sockaddr.IPAddr
+		prefixLen int
+		limit     int
+		subnets   []string
+	}{
+		{
+			name:      "ipv4 /24 to /26",
+			ip:        sockaddr.MustIPv4Addr("192.168.0.77/24"),
+			prefixLen: 26,
+			subnets:   []string{"192.168.0.0/26", "192.168.0.64/26", "192.168.0.128/26", "192.168.0.192/26"},
+		},
+		{
+			name:      "ipv4 same prefix",
+			ip:        sockaddr.MustIPv4Addr("10.0.0.0/8"),
+			prefixLen: 8,
+			subnets:   []string{"10.0.0.0/8"},
+		},
+		{
+			name:      "ipv4 last network",
+			ip:        sockaddr.MustIPv4Addr("255.255.255.0/24"),
+			prefixLen: 25,
+			subnets:   []string{"255.255.255.0/25", "255.255.255.128/25"},
+		},
+		{
+			name:      "ipv4 default route",
+			ip:        sockaddr.MustIPv4Addr("0.0.0.0/0"),
+			prefixLen: 32,
+			limit:     2,
+			subnets:   []string{"0.0.0.0", "0.0.0.1"},
+		},
+		{
+			name:      "ipv4 shorter prefix",
+			ip:        sockaddr.MustIPv4Addr("10.0.0.0/8"),
+			prefixLen: 7,
+		},
+		{
+			name:      "ipv4 prefix too long",
+			ip:        sockaddr.MustIPv4Addr("10.0.0.0/8"),
+			prefixLen: 33,
+		},
+		{
+			name:      "ipv6 /32 to /34",
+			ip:        sockaddr.MustIPv6Addr("2001:db8::/32"),
+			prefixLen: 34,
+			subnets:   []string{"2001:db8::/34", "2001:db8:4000::/34", "2001:db8:8000::/34", "2001:db8:c000::/34"},
+		},
+		{
+			name:      "ipv6 last network",
+			ip:        sockaddr.MustIPv6Addr("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/126"),
+			prefixLen: 127,
+			subnets:   []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/127", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127"},
+		},
+		{
+			name:      "ipv6 huge",
+			ip:        sockaddr.MustIPv6Addr("::/0"),
+			prefixLen: 128,
+			limit:     3,
+			subnets:   []string{"::", "::1", "::2"},
+		},
+		{
+			name:      "ipv6 zone",
+			ip:        sockaddr.MustIPv6Addr("fe80::%eth0/63"),
+			prefixLen: 64,
+			subnets:   []string{"fe80::%eth0/64", "fe80:0:0:1::%eth0/64"},
+		},
+		{
+			name:      "ipv6 prefix too long",
+			ip:        sockaddr.MustIPv6Addr("2001:db8::/32"),
+			prefixLen: 129,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var subnets []string
+			for subnet := range test.ip.Subnets(test.prefixLen) {
+				subnets = append(subnets, subnet.String())
+				if len(subnets) == test.limit {
+					break
+				}
+			}
+
+			if !reflect.DeepEqual(subnets, test.subnets) {
+				t.Errorf("expected %v, received %v", test.subnets, subnets)
+			}
+		})
+	}
+}
+
+func TestSockAddr_IPAddr_Hosts(t *testing.T) {
+	tests := []struct {
+		name  string
+		ip    sockaddr.IPAddr
+		limit int
+		hosts []string
+	}{
+		{
+			name:  "ipv4 /29",
+			ip:    sockaddr.MustIPv4Addr("192.0.2.3/29"),
+			hosts: []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4", "192.0.2.5", "192.0.2.6"},
+		},
+		{
+			name:  "ipv4 /31",
+			ip:    sockaddr.MustIPv4Addr("192.0.2.0/31"),
+			hosts: []string{"192.0.2.0", "192.0.2.1"},
+		},
+		{
+			name:  "ipv4 /32",
+			ip:    sockaddr.MustIPv4Addr("192.0.2.1:80"),
+			hosts: []string{"192.0.2.1"},
+		},
+		{
+			name:  "ipv4 top of range",
+			ip:    sockaddr.MustIPv4Addr("255.255.255.252/30"),
+			hosts: []string{"255.255.255.253", "255.255.255.254"},
+		},
+		{
+			name:  "ipv6 /126",
+			ip:    sockaddr.MustIPv6Addr("2001:db8::/126"),
+			hosts: []string{"2001:db8::", "2001:db8::1", "2001:db8::2", "2001:db8::3"},
+		},
+		{
+			name:  "ipv6 top of range",
+			ip:    sockaddr.MustIPv6Addr("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127"),
+			hosts: []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		},
+		{
+			name:  "ipv6 huge",
+			ip:    sockaddr.MustIPv6Addr("fe80::%eth0/64"),
+			limit: 2,
+			hosts: []string{"fe80::%eth0", "fe80::1%eth0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var hosts []string
+			for host := range test.ip.Hosts() {
+				hosts = append(hosts, host.String())
+				if len(hosts) == test.limit {
+					break
+				}
+			}
+
+			if !reflect.DeepEqual(hosts, test.hosts) {
+				t.Errorf("expected %v, received %v", test.hosts, hosts)
+			}
+		})
+	}
+}
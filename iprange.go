@@ -0,0 +1,177 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// IPRange is an inclusive range of IPv4 or IPv6 addresses, such as
+// `10.0.0.5-10.0.1.200`.  Unlike an IPAddr, the bounds of an IPRange do not
+// need to fall on a CIDR boundary.  The zero value is the IPv4 range
+// containing only `0.0.0.0`.
+type IPRange struct {
+	r    ipRange
+	ipv6 bool
+	zone string
+}
+
+// NewIPRange parses a string of the form `start-end` into an IPRange, where
+// start and end are addresses of the same family (e.g. `10.0.0.5-10.0.1.200`
+// or `2001:db8::1-2001:db8::ff`).  Either bound may also be a network, in
+// which case the range begins at the network address of start and ends at the
+// last address of end's network.
+func NewIPRange(rangeStr string) (IPRange, error) {
+	startStr, endStr, found := cutIPRange(rangeStr)
+	if !found {
+		return IPRange{}, fmt.Errorf("invalid IPRange %+q: missing '-' between the start and end addresses", rangeStr)
+	}
+
+	start, err := NewIPAddr(strings.TrimSpace(startStr))
+	if err != nil {
+		return IPRange{}, fmt.Errorf("invalid IPRange %+q: %v", rangeStr, err)
+	}
+
+	end, err := NewIPAddr(strings.TrimSpace(endStr))
+	if err != nil {
+		return IPRange{}, fmt.Errorf("invalid IPRange %+q: %v", rangeStr, err)
+	}
+
+	ipRange, err := NewIPRangeFromIPAddrs(start, end)
+	if err != nil {
+		return IPRange{}, fmt.Errorf("invalid IPRange %+q: %v", rangeStr, err)
+	}
+
+	return ipRange, nil
+}
+
+// cutIPRange splits rangeStr around the "-" between its start and end
+// addresses.  IPv6 zones may contain a "-" themselves (e.g. "fe80::1%br-lan"),
+// so the last "-" followed by a parseable address is used.  If there is none,
+// rangeStr is split on its first "-" so the error describes the addresses.
+func cutIPRange(rangeStr string) (startStr, endStr string, found bool) {
+	for i := strings.LastIndexByte(rangeStr, '-'); i >= 0; i = strings.LastIndexByte(rangeStr[:i], '-') {
+		if _, err := NewIPAddr(strings.TrimSpace(rangeStr[i+1:])); err == nil {
+			return rangeStr[:i], rangeStr[i+1:], true
+		}
+	}
+
+	return strings.Cut(rangeStr, "-")
+}
+
+// NewIPRangeFromIPAddrs creates an IPRange beginning at the network address of
+// start and ending at the last address of end's network.  start and end must
+// be of the same address family, and IPv6 addresses with zones must share the
+// same zone.  An error is returned if end comes before start.
+func NewIPRangeFromIPAddrs(start, end IPAddr) (IPRange, error) {
+	var r IPRange
+	switch s := start.(type) {
+	case IPv4Addr:
+		e, ok := end.(IPv4Addr)
+		if !ok {
+			return IPRange{}, fmt.Errorf("address family mismatch between %s and %s", start, end)
+		}
+		r.r = ipRange{start: ipv4Range(s).start, end: ipv4Range(e).end}
+	case IPv6Addr:
+		e, ok := end.(IPv6Addr)
+		if !ok {
+			return IPRange{}, fmt.Errorf("address family mismatch between %s and %s", start, end)
+		}
+		if s.Zone != "" && e.Zone != "" && s.Zone != e.Zone {
+			return IPRange{}, fmt.Errorf("zone mismatch between %s and %s", start, end)
+		}
+		r.r = ipRange{start: ipv6Range(s).start, end: ipv6Range(e).end}
+		r.ipv6 = true
+		r.zone = s.Zone
+		if r.zone == "" {
+			r.zone = e.Zone
+		}
+	default:
+		return IPRange{}, fmt.Errorf("unsupported IPAddr type %T", start)
+	}
+
+	if r.r.start.Cmp(r.r.end) > 0 {
+		return IPRange{}, fmt.Errorf("start address %s is after end address %s", start, end)
+	}
+
+	return r, nil
+}
+
+// MustIPRange is a helper method that must return an IPRange or panic on
+// invalid input.
+func MustIPRange(rangeStr string) IPRange {
+	r, err := NewIPRange(rangeStr)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to create an IPRange from %+q: %v", rangeStr, err))
+	}
+	return r
+}
+
+// Contains returns true if sa is an IPAddr of the same address family whose
+// network lies entirely within the range.  IPv6 zones are only compared when
+// both the range and sa have a zone.
+func (r IPRange) Contains(sa SockAddr) bool {
+	var other ipRange
+	switch v := sa.(type) {
+	case IPv4Addr:
+		if r.ipv6 {
+			return false
+		}
+		other = ipv4Range(v)
+	case IPv6Addr:
+		if !r.ipv6 || (r.zone != "" && v.Zone != "" && r.zone != v.Zone) {
+			return false
+		}
+		other = ipv6Range(v)
+	default:
+		return false
+	}
+
+	return r.r.start.Cmp(other.start) <= 0 && other.end.Cmp(r.r.end) <= 0
+}
+
+// End returns the last address in the range as a host IPAddr.
+func (r IPRange) End() IPAddr {
+	return newIPAddrFromRange(r.r.end, r.width(), r.width(), r.zone)
+}
+
+// Equal returns true if both ranges contain the same addresses in the same
+// address family and zone.
+func (r IPRange) Equal(other IPRange) bool {
+	return r == other
+}
+
+// Prefixes returns the minimal list of CIDR networks that covers exactly the
+// addresses in the range, in ascending order (e.g. `10.0.0.5-10.0.0.10` is
+// `10.0.0.5/32`, `10.0.0.6/31`, `10.0.0.8/31` and `10.0.0.10/32`).
+func (r IPRange) Prefixes() IPAddrs {
+	return r.r.appendPrefixes(nil, r.width(), r.zone)
+}
+
+// Size returns the number of addresses in the range.  A *big.Int is returned
+// because the full IPv6 address space does not fit in 128 bits.
+func (r IPRange) Size() *big.Int {
+	size := r.r.end.Sub(r.r.start).BigInt()
+	return size.Add(size, big.NewInt(1))
+}
+
+// Start returns the first address in the range as a host IPAddr.
+func (r IPRange) Start() IPAddr {
+	return newIPAddrFromRange(r.r.start, r.width(), r.width(), r.zone)
+}
+
+// String returns the range in the `start-end` form accepted by NewIPRange.
+func (r IPRange) String() string {
+	return r.Start().String() + "-" + r.End().String()
+}
+
+// width returns the number of bits in the range's address family.
+func (r IPRange) width() int {
+	if r.ipv6 {
+		return IPv6len * 8
+	}
+	return IPv4len * 8
+}
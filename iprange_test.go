@@ -0,0 +1,181 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"reflect"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestIPRange_New(t *testing.T) {
+	tests := []struct {
+		input    string
+		str      string
+		size     string
+		prefixes []string
+		pass     bool
+	}{
+		{
+			input:    "10.0.0.5-10.0.1.200",
+			str:      "10.0.0.5-10.0.1.200",
+			size:     "452",
+			prefixes: []string{"10.0.0.5", "10.0.0.6/31", "10.0.0.8/29", "10.0.0.16/28", "10.0.0.32/27", "10.0.0.64/26", "10.0.0.128/25", "10.0.1.0/25", "10.0.1.128/26", "10.0.1.192/29", "10.0.1.200"},
+			pass:     true,
+		},
+		{
+			input:    "192.0.2.0 - 192.0.2.255",
+			str:      "192.0.2.0-192.0.2.255",
+			size:     "256",
+			prefixes: []string{"192.0.2.0/24"},
+			pass:     true,
+		},
+		{
+			input:    "10.0.0.0/24-10.0.3.0/24",
+			str:      "10.0.0.0-10.0.3.255",
+			size:     "1024",
+			prefixes: []string{"10.0.0.0/22"},
+			pass:     true,
+		},
+		{
+			input:    "192.0.2.7-192.0.2.7",
+			str:      "192.0.2.7-192.0.2.7",
+			size:     "1",
+			prefixes: []string{"192.0.2.7"},
+			pass:     true,
+		},
+		{
+			input:    "0.0.0.0-255.255.255.255",
+			str:      "0.0.0.0-255.255.255.255",
+			size:     "4294967296",
+			prefixes: []string{"0.0.0.0/0"},
+			pass:     true,
+		},
+		{
+			input:    "2001:db8::1-2001:db8::4",
+			str:      "2001:db8::1-2001:db8::4",
+			size:     "4",
+			prefixes: []string{"2001:db8::1", "2001:db8::2/127", "2001:db8::4"},
+			pass:     true,
+		},
+		{
+			input:    "::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+			str:      "::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+			size:     "340282366920938463463374607431768211456",
+			prefixes: []string{"::/0"},
+			pass:     true,
+		},
+		{
+			input:    "fe80::1%eth0-fe80::2",
+			str:      "fe80::1%eth0-fe80::2%eth0",
+			size:     "2",
+			prefixes: []string{"fe80::1%eth0", "fe80::2%eth0"},
+			pass:     true,
+		},
+		{
+			input:    "fe80::1%br-lan-fe80::9%br-lan",
+			str:      "fe80::1%br-lan-fe80::9%br-lan",
+			size:     "9",
+			prefixes: []string{"fe80::1%br-lan", "fe80::2%br-lan/127", "fe80::4%br-lan/126", "fe80::8%br-lan/127"},
+			pass:     true,
+		},
+		{
+			input:    "fe80::1%br-lan-fe80::2",
+			str:      "fe80::1%br-lan-fe80::2%br-lan",
+			size:     "2",
+			prefixes: []string{"fe80::1%br-lan", "fe80::2%br-lan"},
+			pass:     true,
+		},
+		{input: "10.0.0.5", pass: false},
+		{input: "fe80::1%br-lan-fe80::9%br-wan", pass: false},
+		{input: "10.0.1.200-10.0.0.5", pass: false},
+		{input: "10.0.0.1-2001:db8::1", pass: false},
+		{input: "fe80::1%eth0-fe80::2%eth1", pass: false},
+		{input: "10.0.0.1-", pass: false},
+		{input: "foo-bar", pass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			r, err := sockaddr.NewIPRange(test.input)
+			if !test.pass {
+				if err == nil {
+					t.Fatalf("expected %+q to fail, received %v", test.input, r)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to create an IPRange from %+q: %v", test.input, err)
+			}
+
+			if s := r.String(); s != test.str {
+				t.Errorf("expected String() %+q, received %+q", test.str, s)
+			}
+
+			if size := r.Size().String(); size != test.size {
+				t.Errorf("expected Size() %s, received %s", test.size, size)
+			}
+
+			var prefixes []string
+			for _, prefix := range r.Prefixes() {
+				prefixes = append(prefixes, prefix.String())
+			}
+			if !reflect.DeepEqual(prefixes, test.prefixes) {
+				t.Errorf("expected Prefixes() %v, received %v", test.prefixes, prefixes)
+			}
+
+			if r2 := sockaddr.MustIPRange(r.String()); !r2.Equal(r) {
+				t.Errorf("expected %s to round-trip, received %s", r, r2)
+			}
+		})
+	}
+}
+
+func TestIPRange_Contains(t *testing.T) {
+	tests := []struct {
+		ipRange  string
+		input    sockaddr.SockAddr
+		contains bool
+	}{
+		{"10.0.0.5-10.0.1.200", sockaddr.MustIPv4Addr("10.0.0.5"), true},
+		{"10.0.0.5-10.0.1.200", sockaddr.MustIPv4Addr("10.0.1.200:80"), true},
+		{"10.0.0.5-10.0.1.200", sockaddr.MustIPv4Addr("10.0.0.4"), false},
+		{"10.0.0.5-10.0.1.200", sockaddr.MustIPv4Addr("10.0.1.201"), false},
+		{"10.0.0.5-10.0.1.200", sockaddr.MustIPv4Addr("10.0.0.128/25"), true},
+		{"10.0.0.5-10.0.1.200", sockaddr.MustIPv4Addr("10.0.0.0/25"), false},
+		{"10.0.0.5-10.0.1.200", sockaddr.MustIPv6Addr("::ffff:10.0.0.6"), false},
+		{"10.0.0.5-10.0.1.200", sockaddr.MustUnixSock("/tmp/foo"), false},
+		{"2001:db8::1-2001:db8::4", sockaddr.MustIPv6Addr("2001:db8::2/127"), true},
+		{"2001:db8::1-2001:db8::4", sockaddr.MustIPv6Addr("2001:db8::5"), false},
+		{"2001:db8::1-2001:db8::4", sockaddr.MustIPv4Addr("0.0.0.2"), false},
+		{"fe80::1%eth0-fe80::4", sockaddr.MustIPv6Addr("fe80::2%eth0"), true},
+		{"fe80::1%eth0-fe80::4", sockaddr.MustIPv6Addr("fe80::2"), true},
+		{"fe80::1%eth0-fe80::4", sockaddr.MustIPv6Addr("fe80::2%eth1"), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ipRange+" "+test.input.String(), func(t *testing.T) {
+			r := sockaddr.MustIPRange(test.ipRange)
+			if c := r.Contains(test.input); c != test.contains {
+				t.Errorf("expected Contains(%s) %t, received %t", test.input, test.contains, c)
+			}
+		})
+	}
+}
+
+func TestIPRange_FromIPAddrs(t *testing.T) {
+	r, err := sockaddr.NewIPRangeFromIPAddrs(sockaddr.MustIPv4Addr("192.0.2.10"), sockaddr.MustIPv4Addr("192.0.2.20"))
+	if err != nil {
+		t.Fatalf("unable to create an IPRange: %v", err)
+	}
+
+	if !r.Start().Equal(sockaddr.MustIPv4Addr("192.0.2.10")) || !r.End().Equal(sockaddr.MustIPv4Addr("192.0.2.20")) {
+		t.Errorf("expected 192.0.2.10-192.0.2.20, received %s", r)
+	}
+
+	if _, err := sockaddr.NewIPRangeFromIPAddrs(sockaddr.MustIPv4Addr("192.0.2.10"), sockaddr.MustIPv6Addr("::1")); err == nil {
+		t.Errorf("expected an address family mismatch to fail")
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"sort"
+)
+
+// IPSet is an immutable set of IPv4 and IPv6 addresses.  Sets are built from
+// SockAddrs or IPRanges and combined with Union(), Intersect() and
+// Difference(), each of which returns a new IPSet.  IPv4 and IPv6 addresses
+// are tracked separately, so an IPv4-mapped IPv6 address is not a member of
+// the IPv4 set.  IPv6 zones are ignored.  The zero value is the empty set.
+type IPSet struct {
+	// ipv4 and ipv6 are sorted lists of disjoint, non-adjacent ranges.
+	ipv4 []ipRange
+	ipv6 []ipRange
+}
+
+// NewIPSet creates an IPSet containing the networks of every IPAddr in sas.
+// SockAddrs that are not IP addresses are ignored, which allows an entry of
+// KnownRFCs() to be used directly (e.g. `NewIPSet(KnownRFCs()[1918])`).
+func NewIPSet(sas SockAddrs) IPSet {
+	var ipv4, ipv6 []ipRange
+	for _, sa := range sas {
+		switch v := sa.(type) {
+		case IPv4Addr:
+			ipv4 = append(ipv4, ipv4Range(v))
+		case IPv6Addr:
+			ipv6 = append(ipv6, ipv6Range(v))
+		}
+	}
+
+	return IPSet{
+		ipv4: mergeIPRanges(ipv4),
+		ipv6: mergeIPRanges(ipv6),
+	}
+}
+
+// NewIPSetFromIPRanges creates an IPSet containing every address in ranges.
+func NewIPSetFromIPRanges(ranges []IPRange) IPSet {
+	var ipv4, ipv6 []ipRange
+	for _, r := range ranges {
+		if r.ipv6 {
+			ipv6 = append(ipv6, r.r)
+		} else {
+			ipv4 = append(ipv4, r.r)
+		}
+	}
+
+	return IPSet{
+		ipv4: mergeIPRanges(ipv4),
+		ipv6: mergeIPRanges(ipv6),
+	}
+}
+
+// Contains returns true if every address in the network of sa is a member of
+// the set.  SockAddrs that are not IP addresses are never contained.
+func (s IPSet) Contains(sa SockAddr) bool {
+	var ranges []ipRange
+	var r ipRange
+	switch v := sa.(type) {
+	case IPv4Addr:
+		ranges, r = s.ipv4, ipv4Range(v)
+	case IPv6Addr:
+		ranges, r = s.ipv6, ipv6Range(v)
+	default:
+		return false
+	}
+
+	// Ranges are disjoint and non-adjacent, so r must fit within a single
+	// range.
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].end.Cmp(r.start) >= 0
+	})
+	return i < len(ranges) && ranges[i].start.Cmp(r.start) <= 0 && r.end.Cmp(ranges[i].end) <= 0
+}
+
+// Difference returns a new IPSet containing the addresses of s that are not
+// in other.
+func (s IPSet) Difference(other IPSet) IPSet {
+	return IPSet{
+		ipv4: differenceIPRanges(s.ipv4, other.ipv4),
+		ipv6: differenceIPRanges(s.ipv6, other.ipv6),
+	}
+}
+
+// Equal returns true if both sets contain the same addresses.
+func (s IPSet) Equal(other IPSet) bool {
+	return equalIPRanges(s.ipv4, other.ipv4) && equalIPRanges(s.ipv6, other.ipv6)
+}
+
+// Intersect returns a new IPSet containing the addresses that are in both s
+// and other.
+func (s IPSet) Intersect(other IPSet) IPSet {
+	return IPSet{
+		ipv4: intersectIPRanges(s.ipv4, other.ipv4),
+		ipv6: intersectIPRanges(s.ipv6, other.ipv6),
+	}
+}
+
+// IsEmpty returns true if the set contains no addresses.
+func (s IPSet) IsEmpty() bool {
+	return len(s.ipv4) == 0 && len(s.ipv6) == 0
+}
+
+// Overlaps returns true if at least one address is a member of both s and
+// other.
+func (s IPSet) Overlaps(other IPSet) bool {
+	return overlapIPRanges(s.ipv4, other.ipv4) || overlapIPRanges(s.ipv6, other.ipv6)
+}
+
+// Prefixes returns the minimal list of CIDR networks covering the set, with
+// IPv4 networks before IPv6 networks and in ascending order within each
+// family.
+func (s IPSet) Prefixes() IPAddrs {
+	var out IPAddrs
+	for _, r := range s.ipv4 {
+		out = r.appendPrefixes(out, IPv4len*8, "")
+	}
+	for _, r := range s.ipv6 {
+		out = r.appendPrefixes(out, IPv6len*8, "")
+	}
+	return out
+}
+
+// Ranges returns the set as a list of disjoint, non-adjacent IPRanges, with
+// IPv4 ranges before IPv6 ranges and in ascending order within each family.
+func (s IPSet) Ranges() []IPRange {
+	out := make([]IPRange, 0, len(s.ipv4)+len(s.ipv6))
+	for _, r := range s.ipv4 {
+		out = append(out, IPRange{r: r})
+	}
+	for _, r := range s.ipv6 {
+		out = append(out, IPRange{r: r, ipv6: true})
+	}
+	return out
+}
+
+// Union returns a new IPSet containing the addresses that are in either s or
+// other.
+func (s IPSet) Union(other IPSet) IPSet {
+	return IPSet{
+		ipv4: mergeIPRanges(append(append([]ipRange(nil), s.ipv4...), other.ipv4...)),
+		ipv6: mergeIPRanges(append(append([]ipRange(nil), s.ipv6...), other.ipv6...)),
+	}
+}
+
+// differenceIPRanges returns the addresses in a that are not in b.  Both
+// inputs must be sorted lists of disjoint ranges.
+func differenceIPRanges(a, b []ipRange) []ipRange {
+	var out []ipRange
+	j := 0
+	for _, r := range a {
+		// Skip the ranges of b that end before r begins.
+		for j < len(b) && b[j].end.Cmp(r.start) < 0 {
+			j++
+		}
+
+		start := r.start
+		k := j
+		for ; k < len(b) && b[k].start.Cmp(r.end) <= 0; k++ {
+			if b[k].start.Cmp(start) > 0 {
+				out = append(out, ipRange{start: start, end: b[k].start.Sub(Uint128{Lo: 1})})
+			}
+			if b[k].end.Cmp(r.end) >= 0 {
+				break
+			}
+			start = b[k].end.Add(Uint128{Lo: 1})
+		}
+
+		// r extends past the last range of b that overlaps it.
+		if k == len(b) || b[k].start.Cmp(r.end) > 0 {
+			out = append(out, ipRange{start: start, end: r.end})
+		}
+	}
+	return out
+}
+
+// equalIPRanges returns true if a and b contain the same ranges.
+func equalIPRanges(a, b []ipRange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// intersectIPRanges returns the addresses that are in both a and b.  Both
+// inputs must be sorted lists of disjoint ranges.
+func intersectIPRanges(a, b []ipRange) []ipRange {
+	var out []ipRange
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].start, a[i].end
+		if b[j].start.Cmp(start) > 0 {
+			start = b[j].start
+		}
+		if b[j].end.Cmp(end) < 0 {
+			end = b[j].end
+		}
+		if start.Cmp(end) <= 0 {
+			out = append(out, ipRange{start: start, end: end})
+		}
+
+		// Advance whichever range ends first.
+		if a[i].end.Cmp(b[j].end) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// overlapIPRanges returns true if a and b share at least one address.  Both
+// inputs must be sorted lists of disjoint ranges.
+func overlapIPRanges(a, b []ipRange) bool {
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i].end.Cmp(b[j].start) < 0:
+			i++
+		case b[j].end.Cmp(a[i].start) < 0:
+			j++
+		default:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func mustIPSet(t *testing.T, addrs ...string) sockaddr.IPSet {
+	t.Helper()
+
+	var sas sockaddr.SockAddrs
+	var ranges []sockaddr.IPRange
+	for _, addr := range addrs {
+		if ipAddr, err := sockaddr.NewIPAddr(addr); err == nil {
+			sas = append(sas, ipAddr)
+			continue
+		}
+		ranges = append(ranges, sockaddr.MustIPRange(addr))
+	}
+	return sockaddr.NewIPSet(sas).Union(sockaddr.NewIPSetFromIPRanges(ranges))
+}
+
+func ipSetPrefixes(s sockaddr.IPSet) []string {
+	out := []string{}
+	for _, ip := range s.Prefixes() {
+		out = append(out, ip.String())
+	}
+	return out
+}
+
+func TestIPSet_Ops(t *testing.T) {
+	tests := []struct {
+		name       string
+		a          []string
+		b          []string
+		union      []string
+		intersect  []string
+		difference []string
+		overlaps   bool
+	}{
+		{
+			name:       "empty",
+			union:      []string{},
+			intersect:  []string{},
+			difference: []string{},
+		},
+		{
+			name:       "private minus vpc",
+			a:          []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
+			b:          []string{"10.0.0.0/9", "172.16.0.0/12"},
+			union:      []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
+			intersect:  []string{"10.0.0.0/9", "172.16.0.0/12"},
+			difference: []string{"10.128.0.0/9", "192.168.0.0/16"},
+			overlaps:   true,
+		},
+		{
+			name:       "hole in the middle",
+			a:          []string{"192.0.2.0/24"},
+			b:          []string{"192.0.2.64/26"},
+			union:      []string{"192.0.2.0/24"},
+			intersect:  []string{"192.0.2.64/26"},
+			difference: []string{"192.0.2.0/26", "192.0.2.128/25"},
+			overlaps:   true,
+		},
+		{
+			name:       "disjoint adjacent",
+			a:          []string{"10.0.0.0/25"},
+			b:          []string{"10.0.0.128/25"},
+			union:      []string{"10.0.0.0/24"},
+			intersect:  []string{},
+			difference: []string{"10.0.0.0/25"},
+		},
+		{
+			name:       "ranges",
+			a:          []string{"10.0.0.5-10.0.0.10"},
+			b:          []string{"10.0.0.8-10.0.0.20"},
+			union:      []string{"10.0.0.5", "10.0.0.6/31", "10.0.0.8/29", "10.0.0.16/30", "10.0.0.20"},
+			intersect:  []string{"10.0.0.8/31", "10.0.0.10"},
+			difference: []string{"10.0.0.5", "10.0.0.6/31"},
+			overlaps:   true,
+		},
+		{
+			name:       "families are separate",
+			a:          []string{"0.0.0.0/0", "2001:db8::/32"},
+			b:          []string{"::/96", "2001:db8:8000::/33"},
+			union:      []string{"0.0.0.0/0", "::/96", "2001:db8::/32"},
+			intersect:  []string{"2001:db8:8000::/33"},
+			difference: []string{"0.0.0.0/0", "2001:db8::/33"},
+			overlaps:   true,
+		},
+		{
+			name:       "edges of the address space",
+			a:          []string{"::/127", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127"},
+			b:          []string{"::1-ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe"},
+			union:      []string{"::/0"},
+			intersect:  []string{"::1", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe"},
+			difference: []string{"::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+			overlaps:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := mustIPSet(t, test.a...), mustIPSet(t, test.b...)
+
+			if out := ipSetPrefixes(a.Union(b)); !reflect.DeepEqual(out, test.union) {
+				t.Errorf("expected Union() %v, received %v", test.union, out)
+			}
+			if out := ipSetPrefixes(a.Intersect(b)); !reflect.DeepEqual(out, test.intersect) {
+				t.Errorf("expected Intersect() %v, received %v", test.intersect, out)
+			}
+			if out := ipSetPrefixes(a.Difference(b)); !reflect.DeepEqual(out, test.difference) {
+				t.Errorf("expected Difference() %v, received %v", test.difference, out)
+			}
+			if o := a.Overlaps(b); o != test.overlaps {
+				t.Errorf("expected Overlaps() %t, received %t", test.overlaps, o)
+			}
+			if !a.Union(b).Equal(b.Union(a)) || !a.Intersect(b).Equal(b.Intersect(a)) {
+				t.Errorf("expected Union() and Intersect() to be commutative")
+			}
+		})
+	}
+}
+
+// TestIPSet_Brute checks the set operations on random sets within a /24
+// against a brute force enumeration of every address.
+func TestIPSet_Brute(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randomSet := func() (sockaddr.IPSet, [256]bool) {
+		var sas sockaddr.SockAddrs
+		var members [256]bool
+		for i := r.Intn(6); i >= 0; i-- {
+			ip := sockaddr.MustIPv4Addr(fmt.Sprintf("192.0.2.%d/%d", r.Intn(256), 24+r.Intn(9)))
+			sas = append(sas, ip)
+			for host := range ip.Network().Subnets(32) {
+				members[host.(sockaddr.IPv4Addr).Octets()[3]] = true
+			}
+		}
+		return sockaddr.NewIPSet(sas), members
+	}
+
+	for i := 0; i < 200; i++ {
+		a, aMembers := randomSet()
+		b, bMembers := randomSet()
+
+		var union, intersect, difference [256]bool
+		overlaps := false
+		for j := range aMembers {
+			union[j] = aMembers[j] || bMembers[j]
+			intersect[j] = aMembers[j] && bMembers[j]
+			difference[j] = aMembers[j] && !bMembers[j]
+			overlaps = overlaps || intersect[j]
+		}
+
+		for _, test := range []struct {
+			name     string
+			set      sockaddr.IPSet
+			expected [256]bool
+		}{
+			{"union", a.Union(b), union},
+			{"intersect", a.Intersect(b), intersect},
+			{"difference", a.Difference(b), difference},
+		} {
+			for j := 0; j < 256; j++ {
+				host := sockaddr.MustIPv4Addr(fmt.Sprintf("192.0.2.%d", j))
+				if c := test.set.Contains(host); c != test.expected[j] {
+					t.Fatalf("%s of %v and %v: expected Contains(%s) %t", test.name, ipSetPrefixes(a), ipSetPrefixes(b), host, test.expected[j])
+				}
+			}
+		}
+
+		if o := a.Overlaps(b); o != overlaps {
+			t.Fatalf("expected Overlaps() of %v and %v to be %t", ipSetPrefixes(a), ipSetPrefixes(b), overlaps)
+		}
+	}
+}
+
+func TestIPSet_Contains(t *testing.T) {
+	s := sockaddr.NewIPSet(sockaddr.KnownRFCs()[1918])
+
+	tests := []struct {
+		input    sockaddr.SockAddr
+		contains bool
+	}{
+		{sockaddr.MustIPv4Addr("10.1.2.3"), true},
+		{sockaddr.MustIPv4Addr("192.168.0.0/16"), true},
+		{sockaddr.MustIPv4Addr("192.168.0.0/15"), false},
+		{sockaddr.MustIPv4Addr("8.8.8.8"), false},
+		{sockaddr.MustIPv6Addr("::ffff:10.1.2.3"), false},
+		{sockaddr.MustUnixSock("/tmp/foo"), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input.String(), func(t *testing.T) {
+			if c := s.Contains(test.input); c != test.contains {
+				t.Errorf("expected Contains() %t, received %t", test.contains, c)
+			}
+		})
+	}
+
+	var empty sockaddr.IPSet
+	if !empty.IsEmpty() || empty.Contains(sockaddr.MustIPv4Addr("10.0.0.1")) {
+		t.Errorf("expected the zero IPSet to be empty")
+	}
+
+	ranges := s.Ranges()
+	if len(ranges) != 3 || ranges[0].String() != "10.0.0.0-10.255.255.255" {
+		t.Errorf("expected three ranges beginning with 10.0.0.0-10.255.255.255, received %v", ranges)
+	}
+}
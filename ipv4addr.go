@@ -6,7 +6,9 @@ package sockaddr
 import (
 	"encoding/binary"
 	"fmt"
+	"iter"
 	"net"
+	"net/netip"
 	"regexp"
 	"strconv"
 	"strings"
@@ -351,6 +353,25 @@ func (ipv4 IPv4Addr) Host() IPAddr {
 	}
 }
 
+// Hosts returns an iterator over the /32 host addresses between FirstUsable()
+// and LastUsable(), inclusive.  The iterator is lazy and does not allocate the
+// list of hosts.
+func (ipv4 IPv4Addr) Hosts() iter.Seq[IPAddr] {
+	return func(yield func(IPAddr) bool) {
+		first := uint64(ipv4.FirstUsable().(IPv4Addr).Address)
+		last := uint64(ipv4.LastUsable().(IPv4Addr).Address)
+		for addr := first; addr <= last; addr++ {
+			host := IPv4Addr{
+				Address: IPv4Address(addr),
+				Mask:    IPv4HostMask,
+			}
+			if !yield(host) {
+				return
+			}
+		}
+	}
+}
+
 // IPPort returns the Port number attached to the IPv4Addr
 func (ipv4 IPv4Addr) IPPort() IPPort {
 	return ipv4.Port
@@ -436,6 +457,30 @@ func (ipv4 IPv4Addr) NetIPNet() *net.IPNet {
 	return ipv4net
 }
 
+// NetipAddr returns the address as a netip.Addr.
+func (ipv4 IPv4Addr) NetipAddr() netip.Addr {
+	var x [IPv4len]byte
+	binary.BigEndian.PutUint32(x[:], uint32(ipv4.Address))
+	return netip.AddrFrom4(x)
+}
+
+// NetipAddrPort returns the address and port as a netip.AddrPort.
+func (ipv4 IPv4Addr) NetipAddrPort() netip.AddrPort {
+	return netip.AddrPortFrom(ipv4.NetipAddr(), uint16(ipv4.Port))
+}
+
+// NetipPrefix returns the address and mask as a netip.Prefix.  The host bits
+// of the address are preserved (see netip.Prefix.Masked()).  An invalid
+// netip.Prefix is returned if the mask is not a canonical (contiguous) mask.
+func (ipv4 IPv4Addr) NetipPrefix() netip.Prefix {
+	maskOnes, maskBits := ipv4.NetIPMask().Size()
+	if maskBits == 0 {
+		return netip.Prefix{}
+	}
+
+	return netip.PrefixFrom(ipv4.NetipAddr(), maskOnes)
+}
+
 // Network returns the network prefix or network address for a given network.
 func (ipv4 IPv4Addr) Network() IPAddr {
 	return IPv4Addr{
@@ -460,6 +505,30 @@ func (ipv4 IPv4Addr) Octets() []int {
 	}
 }
 
+// ReverseName returns the in-addr.arpa. domain name of the receiver.  A host
+// address returns its PTR name (e.g. "2.1.168.192.in-addr.arpa.").  A network
+// on an octet boundary returns the name of its reverse zone (e.g.
+// "1.168.192.in-addr.arpa." for 192.168.1.0/24).  Other networks return the
+// RFC 2317 classless delegation name, which prefixes the zone of the
+// enclosing octet boundary with the first value of the partial octet and the
+// prefix length (e.g. "64/26.2.0.192.in-addr.arpa." for 192.0.2.64/26).
+func (ipv4 IPv4Addr) ReverseName() string {
+	maskBits := ipv4.Maskbits()
+	address := uint32(ipv4.NetworkAddress())
+
+	labels := make([]string, 0, IPv4len+2)
+	if maskBits%8 != 0 {
+		partial := address >> uint(IPv4len*8-(maskBits/8+1)*8) & 0xff
+		labels = append(labels, fmt.Sprintf("%d/%d", partial, maskBits))
+	}
+	for i := maskBits/8 - 1; i >= 0; i-- {
+		labels = append(labels, strconv.FormatUint(uint64(address>>uint(IPv4len*8-(i+1)*8)&0xff), 10))
+	}
+	labels = append(labels, "in-addr.arpa.")
+
+	return strings.Join(labels, ".")
+}
+
 // String returns a string representation of the IPv4Addr
 func (ipv4 IPv4Addr) String() string {
 	if ipv4.Port != 0 {
@@ -473,6 +542,33 @@ func (ipv4 IPv4Addr) String() string {
 	return fmt.Sprintf("%s/%d", ipv4.NetIP().String(), ipv4.Maskbits())
 }
 
+// Subnets returns an iterator over the networks with a prefix length of
+// newPrefixLen contained within the receiver's network, in ascending order.
+// For example, the Subnets(26) of "192.168.0.0/24" are "192.168.0.0/26",
+// "192.168.0.64/26", "192.168.0.128/26", and "192.168.0.192/26".  No networks
+// are returned if newPrefixLen is shorter than the receiver's prefix length or
+// longer than 32.
+func (ipv4 IPv4Addr) Subnets(newPrefixLen int) iter.Seq[IPAddr] {
+	return func(yield func(IPAddr) bool) {
+		if newPrefixLen < ipv4.Maskbits() || newPrefixLen > IPv4len*8 {
+			return
+		}
+
+		mask := IPv4Mask(uint64(IPv4HostMask) << uint(IPv4len*8-newPrefixLen))
+		step := uint64(1) << uint(IPv4len*8-newPrefixLen)
+		last := uint64(ipv4.BroadcastAddress())
+		for addr := uint64(ipv4.NetworkAddress()); addr <= last; addr += step {
+			subnet := IPv4Addr{
+				Address: IPv4Address(addr),
+				Mask:    mask,
+			}
+			if !yield(subnet) {
+				return
+			}
+		}
+	}
+}
+
 // Type is used as a type switch and returns TypeIPv4
 func (IPv4Addr) Type() SockAddrType {
 	return TypeIPv4
@@ -515,3 +611,14 @@ func ipv4AddrInit() {
 		},
 	}
 }
+
+// newIPv4AddrFromNetip creates an IPv4Addr from a 4 byte netip.Addr.  The
+// caller is responsible for validating addr and maskBits.
+func newIPv4AddrFromNetip(addr netip.Addr, maskBits int, port IPPort) IPv4Addr {
+	ipv4 := addr.As4()
+	return IPv4Addr{
+		Address: IPv4Address(binary.BigEndian.Uint32(ipv4[:])),
+		Mask:    IPv4Mask(binary.BigEndian.Uint32(net.CIDRMask(maskBits, IPv4len*8))),
+		Port:    port,
+	}
+}
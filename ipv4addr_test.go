@@ -985,6 +985,63 @@ func TestIPv4CmpRFC(t *testing.T) {
 	}
 }
 
+func TestIPv4Addr_ReverseName(t *testing.T) {
+	tests := []struct {
+		name string
+		ipv4 sockaddr.IPv4Addr
+		want string
+	}{
+		{
+			name: "host",
+			ipv4: sockaddr.MustIPv4Addr("192.168.1.2"),
+			want: "2.1.168.192.in-addr.arpa.",
+		},
+		{
+			name: "host with port",
+			ipv4: sockaddr.MustIPv4Addr("192.168.1.2:53"),
+			want: "2.1.168.192.in-addr.arpa.",
+		},
+		{
+			name: "/24",
+			ipv4: sockaddr.MustIPv4Addr("192.168.1.0/24"),
+			want: "1.168.192.in-addr.arpa.",
+		},
+		{
+			name: "/16 with host bits",
+			ipv4: sockaddr.MustIPv4Addr("172.16.5.4/16"),
+			want: "16.172.in-addr.arpa.",
+		},
+		{
+			name: "/26",
+			ipv4: sockaddr.MustIPv4Addr("192.0.2.64/26"),
+			want: "64/26.2.0.192.in-addr.arpa.",
+		},
+		{
+			name: "/12",
+			ipv4: sockaddr.MustIPv4Addr("172.16.0.0/12"),
+			want: "16/12.172.in-addr.arpa.",
+		},
+		{
+			name: "/4",
+			ipv4: sockaddr.MustIPv4Addr("224.0.0.0/4"),
+			want: "224/4.in-addr.arpa.",
+		},
+		{
+			name: "/0",
+			ipv4: sockaddr.MustIPv4Addr("0.0.0.0/0"),
+			want: "in-addr.arpa.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.ipv4.ReverseName(); got != test.want {
+				t.Errorf("ReverseName() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
 func TestIPv4Attrs(t *testing.T) {
 	const expectedNumAttrs = 3
 	attrs := sockaddr.IPv4Attrs()
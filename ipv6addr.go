@@ -4,53 +4,59 @@
 package sockaddr
 
 import (
-	"bytes"
-	"encoding/binary"
 	"fmt"
+	"iter"
 	"math/big"
 	"net"
+	"net/netip"
+	"strconv"
+	"strings"
 )
 
 type (
 	// IPv6Address is a named type representing an IPv6 address.
-	IPv6Address *big.Int
+	IPv6Address Uint128
 
 	// IPv6Network is a named type representing an IPv6 network.
-	IPv6Network *big.Int
+	IPv6Network Uint128
 
 	// IPv6Mask is a named type representing an IPv6 network mask.
-	IPv6Mask *big.Int
+	IPv6Mask Uint128
 )
 
 // IPv6HostPrefix is a constant represents a /128 IPv6 Prefix.
 const IPv6HostPrefix = IPPrefixLen(128)
 
-// ipv6HostMask is an unexported big.Int representing a /128 IPv6 address.
+// ipv6HostMask is an unexported IPv6Mask representing a /128 IPv6 address.
 // This value must be a constant and always set to all ones.
-var ipv6HostMask IPv6Mask
+var ipv6HostMask = IPv6Mask(maxUint128)
 
 // ipv6AddrAttrMap is a map of the IPv6Addr type-specific attributes.
 var ipv6AddrAttrMap map[AttrName]func(IPv6Addr) string
 var ipv6AddrAttrs []AttrName
 
 func init() {
-	biMask := new(big.Int)
-	biMask.SetBytes([]byte{
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-	},
-	)
-	ipv6HostMask = IPv6Mask(biMask)
-
 	ipv6AddrInit()
 }
 
+// BigInt returns the IPv6Address as a newly allocated big.Int.
+func (a IPv6Address) BigInt() *big.Int { return Uint128(a).BigInt() }
+
+// Uint128 returns the IPv6Address as a Uint128.
+func (a IPv6Address) Uint128() Uint128 { return Uint128(a) }
+
+// BigInt returns the IPv6Network as a newly allocated big.Int.
+func (n IPv6Network) BigInt() *big.Int { return Uint128(n).BigInt() }
+
+// Uint128 returns the IPv6Network as a Uint128.
+func (n IPv6Network) Uint128() Uint128 { return Uint128(n) }
+
+// BigInt returns the IPv6Mask as a newly allocated big.Int.
+func (m IPv6Mask) BigInt() *big.Int { return Uint128(m).BigInt() }
+
+// Uint128 returns the IPv6Mask as a Uint128.
+func (m IPv6Mask) Uint128() Uint128 { return Uint128(m) }
+
 // IPv6Addr implements a convenience wrapper around the union of Go's
 // built-in net.IP and net.IPNet types.  In UNIX-speak, IPv6Addr implements
 // `sockaddr` when the the address family is set to AF_INET6
@@ -60,13 +66,20 @@ type IPv6Addr struct {
 	Address IPv6Address
 	Mask    IPv6Mask
 	Port    IPPort
+
+	// Zone is the IPv6 zone (scope) identifier of the address, e.g. the
+	// interface name of a link-local address.  Zone is empty when no zone was
+	// specified.
+	Zone string
 }
 
 // NewIPv6Addr creates an IPv6Addr from a string.  String can be in the form of
 // an an IPv6:port (e.g. `[2001:4860:0:2001::68]:80`, in which case the mask is
 // assumed to be a /128), an IPv6 address (e.g. `2001:4860:0:2001::68`, also
 // with a `/128` mask), an IPv6 CIDR (e.g. `2001:4860:0:2001::68/64`, which has
-// its IP port initialized to zero).  ipv6Str can not be a hostname.
+// its IP port initialized to zero).  Each form may include a zone identifier
+// (e.g. `fe80::1%eth0`, `[fe80::1%eth0]:80`, or `fe80::1%eth0/64`).  ipv6Str
+// can not be a hostname.
 //
 // NOTE: Many net.*() routines will initialize and return an IPv4 address.
 // Always test to make sure the address returned cannot be converted to a 4 byte
@@ -88,6 +101,11 @@ LOOP:
 		return IPv6Addr{}, fmt.Errorf("unable to resolve %+q as an IPv6 address, appears to be an IPv4 address", ipv6Str)
 	}
 
+	ipv6Str, zone, err := splitIPv6Zone(ipv6Str)
+	if err != nil {
+		return IPv6Addr{}, err
+	}
+
 	// Attempt to parse ipv6Str as a /128 host with a port number.
 	tcpAddr, err := net.ResolveTCPAddr("tcp6", ipv6Str)
 	if err == nil {
@@ -96,16 +114,11 @@ LOOP:
 			return IPv6Addr{}, fmt.Errorf("unable to resolve %+q as a 16byte IPv6 address", ipv6Str)
 		}
 
-		ipv6BigIntAddr := new(big.Int)
-		ipv6BigIntAddr.SetBytes(ipv6)
-
-		ipv6BigIntMask := new(big.Int)
-		ipv6BigIntMask.Set(ipv6HostMask)
-
 		ipv6Addr := IPv6Addr{
-			Address: IPv6Address(ipv6BigIntAddr),
-			Mask:    IPv6Mask(ipv6BigIntMask),
+			Address: IPv6Address(Uint128FromBytes([IPv6len]byte(ipv6))),
+			Mask:    ipv6HostMask,
 			Port:    IPPort(tcpAddr.Port),
+			Zone:    zone,
 		}
 
 		return ipv6Addr, nil
@@ -122,15 +135,10 @@ LOOP:
 			return IPv6Addr{}, fmt.Errorf("unable to string convert %+q to a 16byte IPv6 address", ipv6Str)
 		}
 
-		ipv6BigIntAddr := new(big.Int)
-		ipv6BigIntAddr.SetBytes(ipv6)
-
-		ipv6BigIntMask := new(big.Int)
-		ipv6BigIntMask.Set(ipv6HostMask)
-
 		return IPv6Addr{
-			Address: IPv6Address(ipv6BigIntAddr),
-			Mask:    IPv6Mask(ipv6BigIntMask),
+			Address: IPv6Address(Uint128FromBytes([IPv6len]byte(ipv6))),
+			Mask:    ipv6HostMask,
+			Zone:    zone,
 		}, nil
 	}
 
@@ -142,15 +150,11 @@ LOOP:
 			return IPv6Addr{}, fmt.Errorf("unable to convert %+q to a 16byte IPv6 address", ipv6Str)
 		}
 
-		ipv6BigIntAddr := new(big.Int)
-		ipv6BigIntAddr.SetBytes(ipv6)
-
-		ipv6BigIntMask := new(big.Int)
-		ipv6BigIntMask.SetBytes(network.Mask)
-
+		maskOnes, _ := network.Mask.Size()
 		ipv6Addr := IPv6Addr{
-			Address: IPv6Address(ipv6BigIntAddr),
-			Mask:    IPv6Mask(ipv6BigIntMask),
+			Address: IPv6Address(Uint128FromBytes([IPv6len]byte(ipv6))),
+			Mask:    IPv6Mask(mask128(maskOnes)),
+			Zone:    zone,
 		}
 		return ipv6Addr, nil
 	}
@@ -162,16 +166,36 @@ LOOP:
 // as a sequence of '0' and '1' characters.  This method is useful for
 // debugging or by operators who want to inspect an address.
 func (ipv6 IPv6Addr) AddressBinString() string {
-	bi := big.Int(*ipv6.Address)
-	return fmt.Sprintf("%0128s", bi.Text(2))
+	return fmt.Sprintf("%064b%064b", ipv6.Address.Hi, ipv6.Address.Lo)
+}
+
+// AddressCanonicalString returns the IPv6Addr's Address in the canonical
+// text format of RFC 5952: lowercase hex digits, leading zeros suppressed,
+// the longest run of two or more zero groups compressed to "::", and
+// IPv4-mapped addresses written with a dotted-quad suffix (e.g.
+// "::ffff:192.0.2.1").  The zone, mask and port are not included.
+func (ipv6 IPv6Addr) AddressCanonicalString() string {
+	return netip.AddrFrom16(Uint128(ipv6.Address).Bytes()).String()
+}
+
+// AddressExpandedString returns the IPv6Addr's Address as eight groups of
+// four hex digits without any compression (e.g.
+// "2001:0db8:0000:0000:0000:0000:0000:0001").  The zone, mask and port are not
+// included.
+func (ipv6 IPv6Addr) AddressExpandedString() string {
+	b := Uint128(ipv6.Address).Bytes()
+	groups := make([]string, 0, IPv6len/2)
+	for i := 0; i < IPv6len; i += 2 {
+		groups = append(groups, fmt.Sprintf("%02x%02x", b[i], b[i+1]))
+	}
+	return strings.Join(groups, ":")
 }
 
 // AddressHexString returns a string with the IPv6Addr address represented as
 // a sequence of hex characters.  This method is useful for debugging or by
 // operators who want to inspect an address.
 func (ipv6 IPv6Addr) AddressHexString() string {
-	bi := big.Int(*ipv6.Address)
-	return fmt.Sprintf("%032s", bi.Text(16))
+	return fmt.Sprintf("%016x%016x", ipv6.Address.Hi, ipv6.Address.Lo)
 }
 
 // CmpAddress follows the Cmp() standard protocol and returns:
@@ -180,18 +204,19 @@ func (ipv6 IPv6Addr) AddressHexString() string {
 //   - 0 if the SockAddr arg equal to the receiving IPv6Addr or the argument is of a
 //     different type.
 //   - 1 If the argument should sort first.
+//
+// Identical addresses in different zones are ordered by their Zone.
 func (ipv6 IPv6Addr) CmpAddress(sa SockAddr) int {
 	ipv6b, ok := sa.(IPv6Addr)
 	if !ok {
 		return sortDeferDecision
 	}
 
-	ipv6aBigInt := new(big.Int)
-	ipv6aBigInt.Set(ipv6.Address)
-	ipv6bBigInt := new(big.Int)
-	ipv6bBigInt.Set(ipv6b.Address)
+	if c := Uint128(ipv6.Address).Cmp(Uint128(ipv6b.Address)); c != 0 {
+		return c
+	}
 
-	return ipv6aBigInt.Cmp(ipv6bBigInt)
+	return strings.Compare(ipv6.Zone, ipv6b.Zone)
 }
 
 // CmpPort follows the Cmp() standard protocol and returns:
@@ -255,7 +280,8 @@ func (ipv6 IPv6Addr) CmpRFC(rfcNum uint, sa SockAddr) int {
 	}
 }
 
-// Contains returns true if the SockAddr is contained within the receiver.
+// Contains returns true if the SockAddr is contained within the receiver.  If
+// both the receiver and sa have a Zone, the zones must match.
 func (ipv6 IPv6Addr) Contains(sa SockAddr) bool {
 	ipv6b, ok := sa.(IPv6Addr)
 	if !ok {
@@ -268,48 +294,19 @@ func (ipv6 IPv6Addr) Contains(sa SockAddr) bool {
 // ContainsAddress returns true if the IPv6Address is contained within the
 // receiver.
 func (ipv6 IPv6Addr) ContainsAddress(x IPv6Address) bool {
-	xAddr := IPv6Addr{
-		Address: x,
-		Mask:    ipv6HostMask,
-	}
-
-	{
-		xIPv6 := xAddr.FirstUsable().(IPv6Addr)
-		yIPv6 := ipv6.FirstUsable().(IPv6Addr)
-		if xIPv6.CmpAddress(yIPv6) >= 1 {
-			return false
-		}
-	}
-
-	{
-		xIPv6 := xAddr.LastUsable().(IPv6Addr)
-		yIPv6 := ipv6.LastUsable().(IPv6Addr)
-		if xIPv6.CmpAddress(yIPv6) <= -1 {
-			return false
-		}
-	}
-	return true
+	return Uint128(ipv6.NetworkAddress()).Cmp(Uint128(x)) <= 0 &&
+		ipv6.lastAddress().Cmp(Uint128(x)) >= 0
 }
 
 // ContainsNetwork returns true if the network from IPv6Addr is contained within
-// the receiver.
+// the receiver.  If both x and y have a Zone, the zones must match.
 func (x IPv6Addr) ContainsNetwork(y IPv6Addr) bool {
-	{
-		xIPv6 := x.FirstUsable().(IPv6Addr)
-		yIPv6 := y.FirstUsable().(IPv6Addr)
-		if ret := xIPv6.CmpAddress(yIPv6); ret >= 1 {
-			return false
-		}
+	if x.Zone != "" && y.Zone != "" && x.Zone != y.Zone {
+		return false
 	}
 
-	{
-		xIPv6 := x.LastUsable().(IPv6Addr)
-		yIPv6 := y.LastUsable().(IPv6Addr)
-		if ret := xIPv6.CmpAddress(yIPv6); ret <= -1 {
-			return false
-		}
-	}
-	return true
+	return Uint128(x.NetworkAddress()).Cmp(Uint128(y.NetworkAddress())) <= 0 &&
+		x.lastAddress().Cmp(y.lastAddress()) >= 0
 }
 
 // DialPacketArgs returns the arguments required to be passed to
@@ -317,11 +314,10 @@ func (x IPv6Addr) ContainsNetwork(y IPv6Addr) bool {
 // DialPacketArgs() will fail.  See Host() to create an IPv6Addr with its
 // mask set to /128.
 func (ipv6 IPv6Addr) DialPacketArgs() (network, dialArgs string) {
-	ipv6Mask := big.Int(*ipv6.Mask)
-	if ipv6Mask.Cmp(ipv6HostMask) != 0 || ipv6.Port == 0 {
+	if ipv6.Mask != ipv6HostMask || ipv6.Port == 0 {
 		return "udp6", ""
 	}
-	return "udp6", fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+	return "udp6", fmt.Sprintf("[%s]:%d", ipv6.addressString(), ipv6.Port)
 }
 
 // DialStreamArgs returns the arguments required to be passed to
@@ -329,11 +325,10 @@ func (ipv6 IPv6Addr) DialPacketArgs() (network, dialArgs string) {
 // DialStreamArgs() will fail.  See Host() to create an IPv6Addr with its
 // mask set to /128.
 func (ipv6 IPv6Addr) DialStreamArgs() (network, dialArgs string) {
-	ipv6Mask := big.Int(*ipv6.Mask)
-	if ipv6Mask.Cmp(ipv6HostMask) != 0 || ipv6.Port == 0 {
+	if ipv6.Mask != ipv6HostMask || ipv6.Port == 0 {
 		return "tcp6", ""
 	}
-	return "tcp6", fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+	return "tcp6", fmt.Sprintf("[%s]:%d", ipv6.addressString(), ipv6.Port)
 }
 
 // Equal returns true if a SockAddr is equal to the receiving IPv4Addr.
@@ -343,11 +338,11 @@ func (ipv6a IPv6Addr) Equal(sa SockAddr) bool {
 		return false
 	}
 
-	if ipv6a.NetIP().String() != ipv6b.NetIP().String() {
+	if ipv6a.Address != ipv6b.Address {
 		return false
 	}
 
-	if ipv6a.NetIPNet().String() != ipv6b.NetIPNet().String() {
+	if ipv6a.Mask != ipv6b.Mask {
 		return false
 	}
 
@@ -355,6 +350,10 @@ func (ipv6a IPv6Addr) Equal(sa SockAddr) bool {
 		return false
 	}
 
+	if ipv6a.Zone != ipv6b.Zone {
+		return false
+	}
+
 	return true
 }
 
@@ -369,6 +368,7 @@ func (ipv6 IPv6Addr) FirstUsable() IPAddr {
 	return IPv6Addr{
 		Address: IPv6Address(ipv6.NetworkAddress()),
 		Mask:    ipv6HostMask,
+		Zone:    ipv6.Zone,
 	}
 }
 
@@ -381,6 +381,26 @@ func (ipv6 IPv6Addr) Host() IPAddr {
 		Address: ipv6.Address,
 		Mask:    ipv6HostMask,
 		Port:    ipv6.Port,
+		Zone:    ipv6.Zone,
+	}
+}
+
+// Hosts returns an iterator over the /128 host addresses between FirstUsable()
+// and LastUsable(), inclusive.  The iterator is lazy and is safe to use on
+// large networks as long as the caller stops iterating.
+func (ipv6 IPv6Addr) Hosts() iter.Seq[IPAddr] {
+	return func(yield func(IPAddr) bool) {
+		last := ipv6.lastAddress()
+		for addr := Uint128(ipv6.NetworkAddress()); ; addr = addr.Add(Uint128{Lo: 1}) {
+			host := IPv6Addr{
+				Address: IPv6Address(addr),
+				Mask:    ipv6HostMask,
+				Zone:    ipv6.Zone,
+			}
+			if !yield(host) || addr == last {
+				return
+			}
+		}
 	}
 }
 
@@ -391,22 +411,10 @@ func (ipv6 IPv6Addr) IPPort() IPPort {
 
 // LastUsable returns the last address in a given network.
 func (ipv6 IPv6Addr) LastUsable() IPAddr {
-	addr := new(big.Int)
-	addr.Set(ipv6.Address)
-
-	mask := new(big.Int)
-	mask.Set(ipv6.Mask)
-
-	negMask := new(big.Int)
-	negMask.Xor(ipv6HostMask, mask)
-
-	lastAddr := new(big.Int)
-	lastAddr.And(addr, mask)
-	lastAddr.Or(lastAddr, negMask)
-
 	return IPv6Addr{
-		Address: IPv6Address(lastAddr),
+		Address: IPv6Address(ipv6.lastAddress()),
 		Mask:    ipv6HostMask,
+		Zone:    ipv6.Zone,
 	}
 }
 
@@ -414,28 +422,31 @@ func (ipv6 IPv6Addr) LastUsable() IPAddr {
 // net.ListenUDP().  If the Mask of ipv6 is not a /128, ListenPacketArgs()
 // will fail.  See Host() to create an IPv6Addr with its mask set to /128.
 func (ipv6 IPv6Addr) ListenPacketArgs() (network, listenArgs string) {
-	ipv6Mask := big.Int(*ipv6.Mask)
-	if ipv6Mask.Cmp(ipv6HostMask) != 0 {
+	if ipv6.Mask != ipv6HostMask {
 		return "udp6", ""
 	}
-	return "udp6", fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+	return "udp6", fmt.Sprintf("[%s]:%d", ipv6.addressString(), ipv6.Port)
 }
 
 // ListenStreamArgs returns the arguments required to be passed to
 // net.ListenTCP().  If the Mask of ipv6 is not a /128, ListenStreamArgs()
 // will fail.  See Host() to create an IPv6Addr with its mask set to /128.
 func (ipv6 IPv6Addr) ListenStreamArgs() (network, listenArgs string) {
-	ipv6Mask := big.Int(*ipv6.Mask)
-	if ipv6Mask.Cmp(ipv6HostMask) != 0 {
+	if ipv6.Mask != ipv6HostMask {
 		return "tcp6", ""
 	}
-	return "tcp6", fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+	return "tcp6", fmt.Sprintf("[%s]:%d", ipv6.addressString(), ipv6.Port)
 }
 
 // Maskbits returns the number of network mask bits in a given IPv6Addr.  For
 // example, the Maskbits() of "2001:0db8::0003/64" would return 64.
 func (ipv6 IPv6Addr) Maskbits() int {
-	maskOnes, _ := ipv6.NetIPNet().Mask.Size()
+	// Non-canonical masks have no prefix length and return 0, the same as
+	// net.IPMask.Size().
+	maskOnes := Uint128(ipv6.Mask).Not().LeadingZeros()
+	if mask128(maskOnes) != Uint128(ipv6.Mask) {
+		return 0
+	}
 
 	return maskOnes
 }
@@ -452,14 +463,17 @@ func MustIPv6Addr(addr string) IPv6Addr {
 
 // NetIP returns the address as a net.IP.
 func (ipv6 IPv6Addr) NetIP() *net.IP {
-	return bigIntToNetIPv6(ipv6.Address)
+	b := Uint128(ipv6.Address).Bytes()
+	x := make(net.IP, IPv6len)
+	copy(x, b[:])
+	return &x
 }
 
 // NetIPMask create a new net.IPMask from the IPv6Addr.
 func (ipv6 IPv6Addr) NetIPMask() *net.IPMask {
+	b := Uint128(ipv6.Mask).Bytes()
 	ipv6Mask := make(net.IPMask, IPv6len)
-	m := big.Int(*ipv6.Mask)
-	copy(ipv6Mask, m.Bytes())
+	copy(ipv6Mask, b[:])
 	return &ipv6Mask
 }
 
@@ -472,50 +486,120 @@ func (ipv6 IPv6Addr) NetIPNet() *net.IPNet {
 	return ipv6net
 }
 
+// NetipAddr returns the address and zone as a netip.Addr.  IPv4-mapped
+// addresses (e.g. `::ffff:192.0.2.1`) are returned in their 16 byte form.
+func (ipv6 IPv6Addr) NetipAddr() netip.Addr {
+	return netip.AddrFrom16(Uint128(ipv6.Address).Bytes()).WithZone(ipv6.Zone)
+}
+
+// NetipAddrPort returns the address and port as a netip.AddrPort.
+func (ipv6 IPv6Addr) NetipAddrPort() netip.AddrPort {
+	return netip.AddrPortFrom(ipv6.NetipAddr(), uint16(ipv6.Port))
+}
+
+// NetipPrefix returns the address and mask as a netip.Prefix.  The host bits
+// of the address are preserved (see netip.Prefix.Masked()).  An invalid
+// netip.Prefix is returned if the mask is not a canonical (contiguous) mask.
+// netip.Prefix does not support zones and the Zone is dropped.
+func (ipv6 IPv6Addr) NetipPrefix() netip.Prefix {
+	maskOnes := ipv6.Maskbits()
+	if maskOnes == 0 && ipv6.Mask != (IPv6Mask{}) {
+		return netip.Prefix{}
+	}
+
+	return netip.PrefixFrom(ipv6.NetipAddr().WithZone(""), maskOnes)
+}
+
 // Network returns the network prefix or network address for a given network.
 func (ipv6 IPv6Addr) Network() IPAddr {
 	return IPv6Addr{
 		Address: IPv6Address(ipv6.NetworkAddress()),
 		Mask:    ipv6.Mask,
+		Zone:    ipv6.Zone,
 	}
 }
 
 // NetworkAddress returns an IPv6Network of the IPv6Addr's network address.
 func (ipv6 IPv6Addr) NetworkAddress() IPv6Network {
-	addr := new(big.Int)
-	addr.SetBytes((*ipv6.Address).Bytes())
-
-	mask := new(big.Int)
-	mask.SetBytes(*ipv6.NetIPMask())
-
-	netAddr := new(big.Int)
-	netAddr.And(addr, mask)
-
-	return IPv6Network(netAddr)
+	return IPv6Network(Uint128(ipv6.Address).And(Uint128(ipv6.Mask)))
 }
 
 // Octets returns a slice of the 16 octets in an IPv6Addr's Address.  The
 // order of the bytes is big endian.
 func (ipv6 IPv6Addr) Octets() []int {
 	x := make([]int, IPv6len)
-	for i, b := range *bigIntToNetIPv6(ipv6.Address) {
+	for i, b := range Uint128(ipv6.Address).Bytes() {
 		x[i] = int(b)
 	}
 
 	return x
 }
 
+// ReverseName returns the ip6.arpa. domain name of the receiver.  A host
+// address returns its PTR name, with one label per nibble.  A network on a
+// nibble boundary returns the name of its reverse zone (e.g.
+// "8.b.d.0.1.0.0.2.ip6.arpa." for 2001:db8::/32).  Other networks follow the
+// RFC 2317 convention used by IPv4Addr.ReverseName() and prefix the zone of
+// the enclosing nibble boundary with the first value of the partial nibble and
+// the prefix length (e.g. "4/34.8.b.d.0.1.0.0.2.ip6.arpa." for
+// 2001:db8:4000::/34).
+func (ipv6 IPv6Addr) ReverseName() string {
+	maskBits := ipv6.Maskbits()
+	address := Uint128(ipv6.NetworkAddress())
+
+	labels := make([]string, 0, IPv6len*2+2)
+	if maskBits%4 != 0 {
+		partial := address.Rsh(uint(IPv6len*8-(maskBits/4+1)*4)).Lo & 0xf
+		labels = append(labels, fmt.Sprintf("%x/%d", partial, maskBits))
+	}
+	for i := maskBits/4 - 1; i >= 0; i-- {
+		labels = append(labels, strconv.FormatUint(address.Rsh(uint(IPv6len*8-(i+1)*4)).Lo&0xf, 16))
+	}
+	labels = append(labels, "ip6.arpa.")
+
+	return strings.Join(labels, ".")
+}
+
 // String returns a string representation of the IPv6Addr
 func (ipv6 IPv6Addr) String() string {
 	if ipv6.Port != 0 {
-		return fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+		return fmt.Sprintf("[%s]:%d", ipv6.addressString(), ipv6.Port)
 	}
 
 	if ipv6.Maskbits() == 128 {
-		return ipv6.NetIP().String()
+		return ipv6.addressString()
 	}
 
-	return fmt.Sprintf("%s/%d", ipv6.NetIP().String(), ipv6.Maskbits())
+	return fmt.Sprintf("%s/%d", ipv6.addressString(), ipv6.Maskbits())
+}
+
+// Subnets returns an iterator over the networks with a prefix length of
+// newPrefixLen contained within the receiver's network, in ascending order.
+// For example, the Subnets(34) of "2001:db8::/32" are "2001:db8::/34",
+// "2001:db8:4000::/34", "2001:db8:8000::/34", and "2001:db8:c000::/34".  No
+// networks are returned if newPrefixLen is shorter than the receiver's prefix
+// length or longer than 128.  The iterator is lazy and is safe to use on large
+// networks as long as the caller stops iterating.
+func (ipv6 IPv6Addr) Subnets(newPrefixLen int) iter.Seq[IPAddr] {
+	return func(yield func(IPAddr) bool) {
+		if newPrefixLen < ipv6.Maskbits() || newPrefixLen > IPv6len*8 {
+			return
+		}
+
+		mask := mask128(newPrefixLen)
+		step := Uint128{Lo: 1}.Lsh(uint(IPv6len*8 - newPrefixLen))
+		last := ipv6.lastAddress().And(mask)
+		for addr := Uint128(ipv6.NetworkAddress()); ; addr = addr.Add(step) {
+			subnet := IPv6Addr{
+				Address: IPv6Address(addr),
+				Mask:    IPv6Mask(mask),
+				Zone:    ipv6.Zone,
+			}
+			if !yield(subnet) || addr == last {
+				return
+			}
+		}
+	}
 }
 
 // Type is used as a type switch and returns TypeIPv6
@@ -545,50 +629,103 @@ func ipv6AddrInit() {
 	ipv6AddrAttrs = []AttrName{
 		"size", // Same position as in IPv6 for output consistency
 		"uint128",
+		"zone",
+		"expanded",
+		"eui64",
+		"eui64_mac",
+		"embedded_ipv4",
+		"teredo_server",
+		"teredo_client",
 	}
 
 	ipv6AddrAttrMap = map[AttrName]func(ipv6 IPv6Addr) string{
+		"embedded_ipv4": func(ipv6 IPv6Addr) string {
+			if ipv4, ok := ipv6.EmbeddedIPv4Addr(); ok {
+				return ipv4.String()
+			}
+			return ""
+		},
+		"eui64": func(ipv6 IPv6Addr) string {
+			return fmt.Sprintf("%t", ipv6.IsEUI64())
+		},
+		"eui64_mac": func(ipv6 IPv6Addr) string {
+			mac, _ := ipv6.EUI64HardwareAddr()
+			return mac.String()
+		},
+		"expanded": func(ipv6 IPv6Addr) string {
+			return ipv6.AddressExpandedString()
+		},
 		"size": func(ipv6 IPv6Addr) string {
 			netSize := big.NewInt(1)
 			netSize = netSize.Lsh(netSize, uint(IPv6len*8-ipv6.Maskbits()))
 			return netSize.Text(10)
 		},
 		"uint128": func(ipv6 IPv6Addr) string {
-			b := big.Int(*ipv6.Address)
-			return b.Text(10)
+			return Uint128(ipv6.Address).String()
+		},
+		"teredo_client": func(ipv6 IPv6Addr) string {
+			if _, client, ok := ipv6.TeredoIPv4Addrs(); ok {
+				return client.String()
+			}
+			return ""
+		},
+		"teredo_server": func(ipv6 IPv6Addr) string {
+			if server, _, ok := ipv6.TeredoIPv4Addrs(); ok {
+				return server.String()
+			}
+			return ""
+		},
+		"zone": func(ipv6 IPv6Addr) string {
+			return ipv6.Zone
 		},
 	}
 }
 
-// bigIntToNetIPv6 is a helper function that correctly returns a net.IP with the
-// correctly padded values.
-func bigIntToNetIPv6(bi *big.Int) *net.IP {
-	x := make(net.IP, IPv6len)
-	ipv6Bytes := bi.Bytes()
+// newIPv6AddrFromNetip creates an IPv6Addr from a netip.Addr, including its
+// zone.  IPv4 addresses are stored in their IPv4-mapped form.  The caller is
+// responsible for validating addr and maskBits.
+func newIPv6AddrFromNetip(addr netip.Addr, maskBits int, port IPPort) IPv6Addr {
+	return IPv6Addr{
+		Address: IPv6Address(Uint128FromBytes(addr.As16())),
+		Mask:    IPv6Mask(mask128(maskBits)),
+		Port:    port,
+		Zone:    addr.Zone(),
+	}
+}
 
-	// It's possible for ipv6Bytes to be less than IPv6len bytes in size.  If
-	// they are different sizes we to pad the size of response.
-	if len(ipv6Bytes) < IPv6len {
-		buf := new(bytes.Buffer)
-		buf.Grow(IPv6len)
+// addressString returns the textual form of the receiver's address, including
+// its zone if one is set.
+func (ipv6 IPv6Addr) addressString() string {
+	if ipv6.Zone == "" {
+		return ipv6.NetIP().String()
+	}
 
-		for i := len(ipv6Bytes); i < IPv6len; i++ {
-			if err := binary.Write(buf, binary.BigEndian, byte(0)); err != nil {
-				panic(fmt.Sprintf("unable to pad byte %d of input %v: %v", i, bi, err))
-			}
-		}
+	return ipv6.NetIP().String() + "%" + ipv6.Zone
+}
 
-		for _, b := range ipv6Bytes {
-			if err := binary.Write(buf, binary.BigEndian, b); err != nil {
-				panic(fmt.Sprintf("unable to preserve endianness of input %v: %v", bi, err))
-			}
-		}
+// lastAddress returns the last address in the receiver's network.
+func (ipv6 IPv6Addr) lastAddress() Uint128 {
+	return Uint128(ipv6.NetworkAddress()).Or(Uint128(ipv6.Mask).Not())
+}
 
-		ipv6Bytes = buf.Bytes()
+// splitIPv6Zone removes the zone identifier from ipv6Str and returns the
+// remaining string and the zone.  The zone starts after a '%' and is
+// terminated by the end of the string, a ']', or a '/'.
+func splitIPv6Zone(ipv6Str string) (string, string, error) {
+	i := strings.IndexByte(ipv6Str, '%')
+	if i < 0 {
+		return ipv6Str, "", nil
 	}
-	i := copy(x, ipv6Bytes)
-	if i != IPv6len {
-		panic("IPv6 wrong size")
+
+	end := len(ipv6Str)
+	if j := strings.IndexAny(ipv6Str[i+1:], "]/"); j >= 0 {
+		end = i + 1 + j
 	}
-	return &x
+
+	zone := ipv6Str[i+1 : end]
+	if zone == "" || strings.ContainsAny(zone, "%[]") {
+		return "", "", fmt.Errorf("invalid IPv6 zone in %+q", ipv6Str)
+	}
+
+	return ipv6Str[:i] + ipv6Str[end:], zone, nil
 }
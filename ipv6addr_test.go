@@ -6,30 +6,15 @@ package sockaddr_test
 import (
 	"fmt"
 	"math/big"
+	"net/netip"
 	"strings"
 	"testing"
 
 	sockaddr "github.com/hashicorp/go-sockaddr"
 )
 
-// ipv6HostMask is an unexported big.Int representing a /128 IPv6 address
-var ipv6HostMask sockaddr.IPv6Mask
-
-func init() {
-	biMask := big.NewInt(0)
-	biMask = biMask.SetBytes([]byte{
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-	},
-	)
-	ipv6HostMask = sockaddr.IPv6Mask(biMask)
-}
+// ipv6HostMask is an unexported IPv6Mask representing a /128 IPv6 address
+var ipv6HostMask = sockaddr.IPv6Mask(sockaddr.Uint128{Hi: ^uint64(0), Lo: ^uint64(0)})
 
 func newIPv6BigInt(t *testing.T, ipv6Str string) *big.Int {
 	addr := big.NewInt(0)
@@ -43,15 +28,15 @@ func newIPv6BigInt(t *testing.T, ipv6Str string) *big.Int {
 }
 
 func newIPv6Address(t *testing.T, ipv6Str string) sockaddr.IPv6Address {
-	return sockaddr.IPv6Address(newIPv6BigInt(t, ipv6Str))
+	return sockaddr.IPv6Address(sockaddr.Uint128FromBigInt(newIPv6BigInt(t, ipv6Str)))
 }
 
 func newIPv6Mask(t *testing.T, ipv6Str string) sockaddr.IPv6Mask {
-	return sockaddr.IPv6Mask(newIPv6BigInt(t, ipv6Str))
+	return sockaddr.IPv6Mask(sockaddr.Uint128FromBigInt(newIPv6BigInt(t, ipv6Str)))
 }
 
 func newIPv6Network(t *testing.T, ipv6Str string) sockaddr.IPv6Network {
-	return sockaddr.IPv6Network(newIPv6BigInt(t, ipv6Str))
+	return sockaddr.IPv6Network(sockaddr.Uint128FromBigInt(newIPv6BigInt(t, ipv6Str)))
 }
 
 func TestSockAddr_IPv6Addr(t *testing.T) {
@@ -339,9 +324,7 @@ func TestSockAddr_IPv6Addr(t *testing.T) {
 				t.Errorf("[%d] Unable to type assert +%q's Host to IPv6Addr", idx, test.z00_input)
 			}
 
-			hAddressBigInt := big.Int(*h.Address)
-			hMaskBigInt := big.Int(*h.Mask)
-			if hAddressBigInt.Cmp(ipv6.Address) != 0 || hMaskBigInt.Cmp(ipv6HostMask) != 0 || h.Port != ipv6.Port {
+			if h.Address != ipv6.Address || h.Mask != ipv6HostMask || h.Port != ipv6.Port {
 				t.Errorf("[%d] Expected %+q's Host() to return identical IPv6Addr except mask, received %+q", idx, test.z00_input, h.String())
 			}
 
@@ -369,8 +352,8 @@ func TestSockAddr_IPv6Addr(t *testing.T) {
 				t.Errorf("[%d] Expected %+q's address to be %+q, received %+q", idx, test.z00_input, test.z04_NetIPStringOut, s)
 			}
 
-			if hAddressBigInt.Cmp(test.z05_addrInt) != 0 {
-				t.Errorf("[%d] Expected %+q's Address to return %+v, received %+v", idx, test.z00_input, test.z05_addrInt, hAddressBigInt)
+			if h.Address != test.z05_addrInt {
+				t.Errorf("[%d] Expected %+q's Address to return %+v, received %+v", idx, test.z00_input, test.z05_addrInt, h.Address)
 			}
 
 			n, ok := ipv6.Network().(sockaddr.IPv6Addr)
@@ -378,8 +361,7 @@ func TestSockAddr_IPv6Addr(t *testing.T) {
 				t.Errorf("[%d] Unable to type assert +%q's Network to IPv6Addr", idx, test.z00_input)
 			}
 
-			nAddressBigInt := big.Int(*n.Address)
-			if nAddressBigInt.Cmp(test.z06_netInt) != 0 {
+			if sockaddr.IPv6Network(n.Address) != test.z06_netInt {
 				t.Errorf("[%d] Expected %+q's Network to return %+v, received %+v", idx, test.z00_input, test.z06_netInt, n.Address)
 			}
 
@@ -395,14 +377,12 @@ func TestSockAddr_IPv6Addr(t *testing.T) {
 				t.Errorf("[%d] Expected %+q's network to be %+q, received %+q", idx, test.z00_input, test.z09_NetIPNetStringOut, n)
 			}
 
-			ipv6MaskBigInt := big.Int(*ipv6.Mask)
-			if ipv6MaskBigInt.Cmp(test.z10_maskInt) != 0 {
-				t.Errorf("[%d] Expected %+q's Mask to return %+v, received %+v", idx, test.z00_input, test.z10_maskInt, ipv6MaskBigInt)
+			if ipv6.Mask != test.z10_maskInt {
+				t.Errorf("[%d] Expected %+q's Mask to return %+v, received %+v", idx, test.z00_input, test.z10_maskInt, ipv6.Mask)
 			}
 
-			nMaskBigInt := big.Int(*n.Mask)
-			if nMaskBigInt.Cmp(test.z10_maskInt) != 0 {
-				t.Errorf("[%d] Expected %+q's Network's Mask to return %+v, received %+v", idx, test.z00_input, test.z10_maskInt, nMaskBigInt)
+			if n.Mask != test.z10_maskInt {
+				t.Errorf("[%d] Expected %+q's Network's Mask to return %+v, received %+v", idx, test.z00_input, test.z10_maskInt, n.Mask)
 			}
 
 			// Network()'s mask must match the IPv6Addr's Mask
@@ -719,10 +699,343 @@ func TestIPv6Addr_CmpRFC(t *testing.T) {
 	}
 }
 
+func TestIPv6Addr_ReverseName(t *testing.T) {
+	tests := []struct {
+		name string
+		ipv6 sockaddr.IPv6Addr
+		want string
+	}{
+		{
+			name: "host",
+			ipv6: sockaddr.MustIPv6Addr("2001:db8::567:89ab"),
+			want: "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+		},
+		{
+			name: "host with zone and port",
+			ipv6: sockaddr.MustIPv6Addr("[fe80::1%eth0]:53"),
+			want: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.e.f.ip6.arpa.",
+		},
+		{
+			name: "/48",
+			ipv6: sockaddr.MustIPv6Addr("2001:db8:abcd::/48"),
+			want: "d.c.b.a.8.b.d.0.1.0.0.2.ip6.arpa.",
+		},
+		{
+			name: "/34",
+			ipv6: sockaddr.MustIPv6Addr("2001:db8:4000::/34"),
+			want: "4/34.8.b.d.0.1.0.0.2.ip6.arpa.",
+		},
+		{
+			name: "/7",
+			ipv6: sockaddr.MustIPv6Addr("fc00::/7"),
+			want: "c/7.f.ip6.arpa.",
+		},
+		{
+			name: "/0",
+			ipv6: sockaddr.MustIPv6Addr("::/0"),
+			want: "ip6.arpa.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.ipv6.ReverseName(); got != test.want {
+				t.Errorf("ReverseName() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIPv6Addr_AddressStrings(t *testing.T) {
+	tests := []struct {
+		input     string
+		canonical string
+		expanded  string
+	}{
+		{
+			input:     "2001:DB8:0:0:0:0:0:1",
+			canonical: "2001:db8::1",
+			expanded:  "2001:0db8:0000:0000:0000:0000:0000:0001",
+		},
+		{
+			input:     "2001:db8:0:0:1:0:0:1",
+			canonical: "2001:db8::1:0:0:1",
+			expanded:  "2001:0db8:0000:0000:0001:0000:0000:0001",
+		},
+		{
+			input:     "2001:db8:0:1:1:1:1:1",
+			canonical: "2001:db8:0:1:1:1:1:1",
+			expanded:  "2001:0db8:0000:0001:0001:0001:0001:0001",
+		},
+		{
+			input:     "::ffff:192.0.2.1",
+			canonical: "::ffff:192.0.2.1",
+			expanded:  "0000:0000:0000:0000:0000:ffff:c000:0201",
+		},
+		{
+			input:     "[fe80::1%eth0]:80",
+			canonical: "fe80::1",
+			expanded:  "fe80:0000:0000:0000:0000:0000:0000:0001",
+		},
+		{
+			input:     "::",
+			canonical: "::",
+			expanded:  "0000:0000:0000:0000:0000:0000:0000:0000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ipv6 := sockaddr.MustIPv6Addr(test.input)
+			if got := ipv6.AddressCanonicalString(); got != test.canonical {
+				t.Errorf("AddressCanonicalString() = %q, want %q", got, test.canonical)
+			}
+			if got := ipv6.AddressExpandedString(); got != test.expanded {
+				t.Errorf("AddressExpandedString() = %q, want %q", got, test.expanded)
+			}
+		})
+	}
+}
+
 func TestIPv6Attrs(t *testing.T) {
-	const expectedNumAttrs = 2
+	const expectedNumAttrs = 9
 	attrs := sockaddr.IPv6Attrs()
 	if len(attrs) != expectedNumAttrs {
 		t.Fatalf("wrong number of IPv6Attrs: %d vs %d", len(attrs), expectedNumAttrs)
 	}
 }
+
+func TestSockAddr_IPv6Addr_Zone(t *testing.T) {
+	tests := []struct {
+		input      string
+		zone       string
+		str        string
+		network    string
+		streamArgs string
+		pass       bool
+	}{
+		{
+			input:      "fe80::1%eth0",
+			zone:       "eth0",
+			str:        "fe80::1%eth0",
+			network:    "fe80::1%eth0",
+			streamArgs: "",
+			pass:       true,
+		},
+		{
+			input:      "[fe80::1%eth0]:8600",
+			zone:       "eth0",
+			str:        "[fe80::1%eth0]:8600",
+			network:    "fe80::1%eth0",
+			streamArgs: "[fe80::1%eth0]:8600",
+			pass:       true,
+		},
+		{
+			input:      "[fe80::1%en0]",
+			zone:       "en0",
+			str:        "fe80::1%en0",
+			network:    "fe80::1%en0",
+			streamArgs: "",
+			pass:       true,
+		},
+		{
+			input:      "fe80::1%3/64",
+			zone:       "3",
+			str:        "fe80::1%3/64",
+			network:    "fe80::%3/64",
+			streamArgs: "",
+			pass:       true,
+		},
+		{
+			input:      "2001:db8::1",
+			zone:       "",
+			str:        "2001:db8::1",
+			network:    "2001:db8::1",
+			streamArgs: "",
+			pass:       true,
+		},
+		{
+			input: "fe80::1%",
+			pass:  false,
+		},
+		{
+			input: "[fe80::1%]:80",
+			pass:  false,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ipv6, err := sockaddr.NewIPv6Addr(test.input)
+			if !test.pass {
+				if err == nil {
+					t.Fatalf("[%d] Expected %+q to fail, received %v", i, test.input, ipv6)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("[%d] Unable to create an IPv6Addr from %+q: %v", i, test.input, err)
+			}
+
+			if ipv6.Zone != test.zone {
+				t.Errorf("[%d] Expected zone %+q, received %+q", i, test.zone, ipv6.Zone)
+			}
+
+			if s := ipv6.String(); s != test.str {
+				t.Errorf("[%d] Expected String() %+q, received %+q", i, test.str, s)
+			}
+
+			if s := ipv6.Network().String(); s != test.network {
+				t.Errorf("[%d] Expected Network() %+q, received %+q", i, test.network, s)
+			}
+
+			if h := ipv6.Host().(sockaddr.IPv6Addr); h.Zone != test.zone {
+				t.Errorf("[%d] Expected Host() zone %+q, received %+q", i, test.zone, h.Zone)
+			}
+
+			if _, args := ipv6.DialStreamArgs(); args != test.streamArgs {
+				t.Errorf("[%d] Expected DialStreamArgs() %+q, received %+q", i, test.streamArgs, args)
+			}
+
+			if attr, err := sockaddr.Attr(ipv6, "zone"); err != nil || attr != test.zone {
+				t.Errorf("[%d] Expected zone attribute %+q, received %+q (%v)", i, test.zone, attr, err)
+			}
+
+			if a := ipv6.NetipAddr(); a.Zone() != test.zone {
+				t.Errorf("[%d] Expected NetipAddr() zone %+q, received %+q", i, test.zone, a.Zone())
+			}
+
+			if p := ipv6.NetipPrefix(); !p.IsValid() {
+				t.Errorf("[%d] Expected NetipPrefix() to be valid", i)
+			}
+
+			ipAddr, err := sockaddr.NewIPAddrFromNetipAddrPort(ipv6.NetipAddrPort())
+			if err != nil {
+				t.Fatalf("[%d] NewIPAddrFromNetipAddrPort(): %v", i, err)
+			}
+			if !ipAddr.Equal(ipv6.Host()) {
+				t.Errorf("[%d] Expected AddrPort round-trip to return %v, received %v", i, ipv6.Host(), ipAddr)
+			}
+		})
+	}
+}
+
+func TestSockAddr_IPv6Addr_ZoneCmp(t *testing.T) {
+	eth0 := sockaddr.MustIPv6Addr("fe80::1%eth0")
+	eth1 := sockaddr.MustIPv6Addr("fe80::1%eth1")
+	noZone := sockaddr.MustIPv6Addr("fe80::1")
+	eth0Net := sockaddr.MustIPv6Addr("fe80::%eth0/64")
+	noZoneNet := sockaddr.MustIPv6Addr("fe80::/64")
+
+	if eth0.Equal(eth1) || eth0.Equal(noZone) {
+		t.Errorf("Expected addresses in different zones to not be equal")
+	}
+
+	if !eth0.Equal(sockaddr.MustIPv6Addr("fe80::1%eth0")) {
+		t.Errorf("Expected addresses in the same zone to be equal")
+	}
+
+	if c := eth0.CmpAddress(eth1); c != -1 {
+		t.Errorf("Expected eth0 to sort before eth1, received %d", c)
+	}
+
+	if c := eth1.CmpAddress(eth0); c != 1 {
+		t.Errorf("Expected eth1 to sort after eth0, received %d", c)
+	}
+
+	if c := noZone.CmpAddress(eth0); c != -1 {
+		t.Errorf("Expected an unzoned address to sort first, received %d", c)
+	}
+
+	if !eth0Net.Contains(eth0) || !eth0Net.Contains(noZone) || !noZoneNet.Contains(eth1) {
+		t.Errorf("Expected network to contain addresses with a matching or unset zone")
+	}
+
+	if eth0Net.Contains(eth1) {
+		t.Errorf("Expected network in zone eth0 to not contain an address in zone eth1")
+	}
+
+	ipAddr, err := sockaddr.NewIPAddrFromNetipAddr(netip.MustParseAddr("fe80::1%eth0"))
+	if err != nil {
+		t.Fatalf("NewIPAddrFromNetipAddr(): %v", err)
+	}
+	if !ipAddr.Equal(eth0) {
+		t.Errorf("Expected %v, received %v", eth0, ipAddr)
+	}
+}
+
+func BenchmarkIPv6Addr_Contains(b *testing.B) {
+	network := sockaddr.MustIPv6Addr("2001:db8::/32")
+	host := sockaddr.MustIPv6Addr("2001:db8:1:2::3")
+	b.ReportAllocs()
+	for b.Loop() {
+		if !network.Contains(host) {
+			b.Fatal("expected network to contain host")
+		}
+	}
+}
+
+func BenchmarkIPv6Addr_ContainsAddress(b *testing.B) {
+	network := sockaddr.MustIPv6Addr("2001:db8::/32")
+	host := sockaddr.MustIPv6Addr("2001:db8:1:2::3")
+	b.ReportAllocs()
+	for b.Loop() {
+		if !network.ContainsAddress(host.Address) {
+			b.Fatal("expected network to contain address")
+		}
+	}
+}
+
+func BenchmarkIPv6Addr_CmpAddress(b *testing.B) {
+	x := sockaddr.MustIPv6Addr("2001:db8::1")
+	y := sockaddr.MustIPv6Addr("2001:db8::2")
+	b.ReportAllocs()
+	for b.Loop() {
+		if x.CmpAddress(y) != -1 {
+			b.Fatal("expected x to sort before y")
+		}
+	}
+}
+
+func BenchmarkIPv6Addr_NetworkAddress(b *testing.B) {
+	ipv6 := sockaddr.MustIPv6Addr("2001:db8:1:2::3/64")
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = ipv6.NetworkAddress()
+	}
+}
+
+func BenchmarkIfAddrMath_IPv6(b *testing.B) {
+	ifAddr := sockaddr.IfAddr{SockAddr: sockaddr.MustIPv6Addr("2001:db8:1:2::3/64")}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := sockaddr.IfAddrMath("network", "+5", ifAddr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestIPv6Addr_HotPathAllocs(t *testing.T) {
+	network := sockaddr.MustIPv6Addr("2001:db8::/32")
+	host := sockaddr.MustIPv6Addr("2001:db8:1:2::3")
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Contains", func() { network.Contains(host) }},
+		{"ContainsAddress", func() { network.ContainsAddress(host.Address) }},
+		{"ContainsNetwork", func() { network.ContainsNetwork(host) }},
+		{"CmpAddress", func() { network.CmpAddress(host) }},
+		{"NetworkAddress", func() { _ = host.NetworkAddress() }},
+		{"Maskbits", func() { _ = network.Maskbits() }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if n := testing.AllocsPerRun(100, test.fn); n != 0 {
+				t.Errorf("Expected %s to not allocate, received %v allocs", test.name, n)
+			}
+		})
+	}
+}
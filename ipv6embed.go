@@ -0,0 +1,189 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+var (
+	// nat64WellKnownPrefix is the RFC 6052 Well-Known Prefix, 64:ff9b::/96.
+	nat64WellKnownPrefix = MustIPv6Addr("64:ff9b::/96")
+
+	// sixToFourPrefix is the RFC 3056 6to4 prefix, 2002::/16.
+	sixToFourPrefix = MustIPv6Addr("2002::/16")
+
+	// teredoPrefix is the RFC 4380 Teredo prefix, 2001::/32.
+	teredoPrefix = MustIPv6Addr("2001::/32")
+)
+
+// rfc6052SuffixByte is the "u" octet of an RFC 6052 address, bits 64 to 71,
+// which must be zero and never holds part of the IPv4 address.
+const rfc6052SuffixByte = 8
+
+// NewIPv6AddrFromNAT64 synthesizes the RFC 6052 IPv4-embedded IPv6 address of
+// ipv4 within the NAT64 prefix (e.g. `64:ff9b::/96` and `192.0.2.33` return
+// `64:ff9b::c000:221`).  The prefix length of prefix must be 32, 40, 48, 56,
+// 64 or 96 and bits 64 to 71 of prefix must be zero.  The result is a host
+// address.
+func NewIPv6AddrFromNAT64(prefix IPv6Addr, ipv4 IPv4Addr) (IPv6Addr, error) {
+	positions, err := rfc6052Positions(prefix)
+	if err != nil {
+		return IPv6Addr{}, err
+	}
+
+	b := Uint128(prefix.NetworkAddress()).Bytes()
+	var v4 [IPv4len]byte
+	binary.BigEndian.PutUint32(v4[:], uint32(ipv4.Address))
+	for i, pos := range positions {
+		b[pos] = v4[i]
+	}
+
+	return IPv6Addr{
+		Address: IPv6Address(Uint128FromBytes(b)),
+		Mask:    ipv6HostMask,
+	}, nil
+}
+
+// EmbeddedIPv4Addr returns the IPv4 address embedded in the receiver by one
+// of the well-known IPv4/IPv6 transition mechanisms: the RFC 6052 NAT64
+// Well-Known Prefix (`64:ff9b::/96`), 6to4, Teredo (the client's public
+// address) or ISATAP.  ok is false if the receiver does not use any of them.
+// Use NAT64IPv4Addr() for network-specific NAT64 prefixes.
+func (ipv6 IPv6Addr) EmbeddedIPv4Addr() (ipv4 IPv4Addr, ok bool) {
+	if nat64WellKnownPrefix.Contains(ipv6) {
+		ipv4, err := ipv6.NAT64IPv4Addr(nat64WellKnownPrefix)
+		return ipv4, err == nil
+	}
+
+	if ipv4, ok := ipv6.SixToFourIPv4Addr(); ok {
+		return ipv4, true
+	}
+
+	if _, client, ok := ipv6.TeredoIPv4Addrs(); ok {
+		client.Port = 0
+		return client, true
+	}
+
+	return ipv6.ISATAPIPv4Addr()
+}
+
+// ISATAPIPv4Addr returns the IPv4 address embedded in the interface
+// identifier of an RFC 5214 ISATAP address (e.g. `fe80::5efe:c000:201` and
+// `fe80::200:5efe:c000:201` return `192.0.2.1`).  ok is false if the
+// interface identifier of the receiver is not an ISATAP identifier.
+func (ipv6 IPv6Addr) ISATAPIPv4Addr() (ipv4 IPv4Addr, ok bool) {
+	b := Uint128(ipv6.Address).Bytes()
+
+	// The interface identifier is [000000ug]00000000:0101111011111110 followed
+	// by the IPv4 address, where u and g are the universal/local and
+	// individual/group bits.
+	if b[8]&^0x03 != 0 || b[9] != 0x00 || b[10] != 0x5e || b[11] != 0xfe {
+		return IPv4Addr{}, false
+	}
+
+	return IPv4Addr{
+		Address: IPv4Address(binary.BigEndian.Uint32(b[12:16])),
+		Mask:    IPv4HostMask,
+	}, true
+}
+
+// NAT64IPv4Addr returns the IPv4 address embedded in the receiver by the
+// RFC 6052 algorithm for the NAT64 prefix (e.g. `64:ff9b::c000:221` and
+// `64:ff9b::/96` return `192.0.2.33`).  An error is returned if prefix does
+// not contain the receiver, is not a valid RFC 6052 prefix, or if the "u"
+// octet (bits 64 to 71) of the receiver is not zero.
+func (ipv6 IPv6Addr) NAT64IPv4Addr(prefix IPv6Addr) (IPv4Addr, error) {
+	positions, err := rfc6052Positions(prefix)
+	if err != nil {
+		return IPv4Addr{}, err
+	}
+
+	if !prefix.ContainsAddress(ipv6.Address) {
+		return IPv4Addr{}, fmt.Errorf("%s is not within the NAT64 prefix %s", ipv6.addressString(), prefix)
+	}
+
+	b := Uint128(ipv6.Address).Bytes()
+	if b[rfc6052SuffixByte] != 0 {
+		return IPv4Addr{}, fmt.Errorf("%s is not an RFC 6052 address: bits 64 to 71 must be zero", ipv6.addressString())
+	}
+
+	var v4 [IPv4len]byte
+	for i, pos := range positions {
+		v4[i] = b[pos]
+	}
+
+	return IPv4Addr{
+		Address: IPv4Address(binary.BigEndian.Uint32(v4[:])),
+		Mask:    IPv4HostMask,
+	}, nil
+}
+
+// SixToFourIPv4Addr returns the IPv4 address of the RFC 3056 6to4 router
+// embedded in bits 16 to 47 of a `2002::/16` address (e.g. `2002:c000:201::1`
+// returns `192.0.2.1`).  ok is false if the receiver is not a 6to4 address.
+func (ipv6 IPv6Addr) SixToFourIPv4Addr() (ipv4 IPv4Addr, ok bool) {
+	if !sixToFourPrefix.ContainsAddress(ipv6.Address) {
+		return IPv4Addr{}, false
+	}
+
+	b := Uint128(ipv6.Address).Bytes()
+	return IPv4Addr{
+		Address: IPv4Address(binary.BigEndian.Uint32(b[2:6])),
+		Mask:    IPv4HostMask,
+	}, true
+}
+
+// TeredoIPv4Addrs returns the addresses embedded in an RFC 4380 Teredo
+// address: the IPv4 address of the Teredo server, and the public IPv4 address
+// and UDP port of the client, which are stored obfuscated (inverted) in the
+// lower bits.  For example, `2001:0:4136:e378:8000:63bf:3fff:fdd2` returns the
+// server `65.54.227.120` and the client `192.0.2.45:40000`.  ok is false if
+// the receiver is not a Teredo address.
+func (ipv6 IPv6Addr) TeredoIPv4Addrs() (server, client IPv4Addr, ok bool) {
+	if !teredoPrefix.ContainsAddress(ipv6.Address) {
+		return IPv4Addr{}, IPv4Addr{}, false
+	}
+
+	b := Uint128(ipv6.Address).Bytes()
+	server = IPv4Addr{
+		Address: IPv4Address(binary.BigEndian.Uint32(b[4:8])),
+		Mask:    IPv4HostMask,
+	}
+	client = IPv4Addr{
+		Address: IPv4Address(^binary.BigEndian.Uint32(b[12:16])),
+		Mask:    IPv4HostMask,
+		Port:    IPPort(^binary.BigEndian.Uint16(b[10:12])),
+	}
+	return server, client, true
+}
+
+// rfc6052Positions returns the byte offsets of the four octets of the IPv4
+// address in an RFC 6052 address using prefix.  The IPv4 address directly
+// follows the prefix, skipping the "u" octet.
+func rfc6052Positions(prefix IPv6Addr) ([IPv4len]int, error) {
+	var positions [IPv4len]int
+
+	maskBits := prefix.Maskbits()
+	switch maskBits {
+	case 32, 40, 48, 56, 64, 96:
+	default:
+		return positions, fmt.Errorf("invalid NAT64 prefix %s: prefix length must be 32, 40, 48, 56, 64 or 96", prefix)
+	}
+
+	if Uint128(prefix.NetworkAddress()).Bytes()[rfc6052SuffixByte] != 0 {
+		return positions, fmt.Errorf("invalid NAT64 prefix %s: bits 64 to 71 must be zero", prefix)
+	}
+
+	pos := maskBits / 8
+	for i := range positions {
+		if pos == rfc6052SuffixByte {
+			pos++
+		}
+		positions[i] = pos
+		pos++
+	}
+	return positions, nil
+}
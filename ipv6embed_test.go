@@ -0,0 +1,211 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestNAT64(t *testing.T) {
+	// Examples from RFC 6052, §2.4.
+	tests := []struct {
+		name   string
+		prefix string
+		ipv4   string
+		ipv6   string
+		fail   bool
+	}{
+		{
+			name:   "/32",
+			prefix: "2001:db8::/32",
+			ipv4:   "192.0.2.33",
+			ipv6:   "2001:db8:c000:221::",
+		},
+		{
+			name:   "/40",
+			prefix: "2001:db8:100::/40",
+			ipv4:   "192.0.2.33",
+			ipv6:   "2001:db8:1c0:2:21::",
+		},
+		{
+			name:   "/48",
+			prefix: "2001:db8:122::/48",
+			ipv4:   "192.0.2.33",
+			ipv6:   "2001:db8:122:c000:2:2100::",
+		},
+		{
+			name:   "/56",
+			prefix: "2001:db8:122:300::/56",
+			ipv4:   "192.0.2.33",
+			ipv6:   "2001:db8:122:3c0:0:221::",
+		},
+		{
+			name:   "/64",
+			prefix: "2001:db8:122:344::/64",
+			ipv4:   "192.0.2.33",
+			ipv6:   "2001:db8:122:344:c0:2:2100:0",
+		},
+		{
+			name:   "/96",
+			prefix: "2001:db8:122:344::/96",
+			ipv4:   "192.0.2.33",
+			ipv6:   "2001:db8:122:344::c000:221",
+		},
+		{
+			name:   "well-known prefix",
+			prefix: "64:ff9b::/96",
+			ipv4:   "192.0.2.33",
+			ipv6:   "64:ff9b::c000:221",
+		},
+		{
+			name:   "invalid prefix length",
+			prefix: "2001:db8::/80",
+			ipv4:   "192.0.2.33",
+			fail:   true,
+		},
+		{
+			name:   "u octet set in prefix",
+			prefix: "2001:db8:0:0:100::/96",
+			ipv4:   "192.0.2.33",
+			fail:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prefix := sockaddr.MustIPv6Addr(test.prefix)
+			ipv6, err := sockaddr.NewIPv6AddrFromNAT64(prefix, sockaddr.MustIPv4Addr(test.ipv4))
+			switch {
+			case test.fail && err == nil:
+				t.Fatalf("expected an error, got %s", ipv6)
+			case test.fail:
+				return
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ipv6.String() != test.ipv6 {
+				t.Fatalf("NewIPv6AddrFromNAT64() = %s, want %s", ipv6, test.ipv6)
+			}
+
+			ipv4, err := ipv6.NAT64IPv4Addr(prefix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ipv4.String() != test.ipv4 {
+				t.Errorf("NAT64IPv4Addr() = %s, want %s", ipv4, test.ipv4)
+			}
+		})
+	}
+}
+
+func TestIPv6Addr_NAT64IPv4Addr_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		ipv6   string
+		prefix string
+	}{
+		{
+			name:   "outside prefix",
+			ipv6:   "2001:db9::c000:221",
+			prefix: "2001:db8::/32",
+		},
+		{
+			name:   "u octet set",
+			ipv6:   "2001:db8:c000:221:ff00::",
+			prefix: "2001:db8::/32",
+		},
+		{
+			name:   "invalid prefix length",
+			ipv6:   "2001:db8::c000:221",
+			prefix: "2001:db8::/33",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ipv6 := sockaddr.MustIPv6Addr(test.ipv6)
+			if ipv4, err := ipv6.NAT64IPv4Addr(sockaddr.MustIPv6Addr(test.prefix)); err == nil {
+				t.Fatalf("expected an error, got %s", ipv4)
+			}
+		})
+	}
+}
+
+func TestIPv6Addr_EmbeddedIPv4Addr(t *testing.T) {
+	tests := []struct {
+		name   string
+		ipv6   string
+		ipv4   string
+		server string
+		client string
+	}{
+		{
+			name: "nat64 well-known prefix",
+			ipv6: "64:ff9b::c000:221",
+			ipv4: "192.0.2.33",
+		},
+		{
+			name: "6to4",
+			ipv6: "2002:c000:201::1",
+			ipv4: "192.0.2.1",
+		},
+		{
+			// RFC 4380, §4
+			name:   "teredo",
+			ipv6:   "2001:0:4136:e378:8000:63bf:3fff:fdd2",
+			ipv4:   "192.0.2.45",
+			server: "65.54.227.120",
+			client: "192.0.2.45:40000",
+		},
+		{
+			name: "isatap link-local",
+			ipv6: "fe80::5efe:c000:201",
+			ipv4: "192.0.2.1",
+		},
+		{
+			name: "isatap global unicast",
+			ipv6: "2001:db8::200:5efe:c000:201",
+			ipv4: "192.0.2.1",
+		},
+		{
+			name: "network-specific nat64 prefix",
+			ipv6: "2001:db8:c000:221::",
+		},
+		{
+			name: "native",
+			ipv6: "2001:db8::1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ipv6 := sockaddr.MustIPv6Addr(test.ipv6)
+
+			ipv4, ok := ipv6.EmbeddedIPv4Addr()
+			if ok != (test.ipv4 != "") {
+				t.Fatalf("EmbeddedIPv4Addr() ok = %t, want %t", ok, !ok)
+			}
+			if ok && ipv4.String() != test.ipv4 {
+				t.Errorf("EmbeddedIPv4Addr() = %s, want %s", ipv4, test.ipv4)
+			}
+			if got := sockaddr.IPv6AddrAttr(ipv6, "embedded_ipv4"); got != test.ipv4 {
+				t.Errorf("embedded_ipv4 = %q, want %q", got, test.ipv4)
+			}
+
+			server, client, ok := ipv6.TeredoIPv4Addrs()
+			if ok != (test.server != "") {
+				t.Fatalf("TeredoIPv4Addrs() ok = %t, want %t", ok, !ok)
+			}
+			if ok && (server.String() != test.server || client.String() != test.client) {
+				t.Errorf("TeredoIPv4Addrs() = (%s, %s), want (%s, %s)", server, client, test.server, test.client)
+			}
+			if got := sockaddr.IPv6AddrAttr(ipv6, "teredo_client"); got != test.client {
+				t.Errorf("teredo_client = %q, want %q", got, test.client)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// InterfaceKind is the kind of a network interface, such as a physical NIC or
+// one of the virtual devices created by container runtimes and VPNs.  The
+// empty InterfaceKind means the platform did not report the kind.
+type InterfaceKind string
+
+const (
+	InterfaceKindBond      InterfaceKind = "bond"
+	InterfaceKindBridge    InterfaceKind = "bridge"
+	InterfaceKindLoopback  InterfaceKind = "loopback"
+	InterfaceKindPhysical  InterfaceKind = "physical"
+	InterfaceKindTap       InterfaceKind = "tap"
+	InterfaceKindTun       InterfaceKind = "tun"
+	InterfaceKindVeth      InterfaceKind = "veth"
+	InterfaceKindVLAN      InterfaceKind = "vlan"
+	InterfaceKindWireGuard InterfaceKind = "wireguard"
+
+	// InterfaceKindVirtual is a virtual interface whose kind is not known,
+	// e.g. a dummy interface, or a macvlan or veth interface when the kind
+	// can only be guessed from sysfs.
+	InterfaceKindVirtual InterfaceKind = "virtual"
+)
+
+// OperState is the RFC 2863 operational state of a network interface, which
+// unlike the "up" flag tells whether the interface can pass packets.  The empty
+// OperState means the platform did not report the state.
+type OperState string
+
+const (
+	OperStateUnknown        OperState = "unknown"
+	OperStateNotPresent     OperState = "notpresent"
+	OperStateDown           OperState = "down"
+	OperStateLowerLayerDown OperState = "lowerlayerdown"
+	OperStateTesting        OperState = "testing"
+	OperStateDormant        OperState = "dormant"
+	OperStateUp             OperState = "up"
+)
+
+// operStates is the list of valid OperStates.
+var operStates = []OperState{
+	OperStateUnknown,
+	OperStateNotPresent,
+	OperStateDown,
+	OperStateLowerLayerDown,
+	OperStateTesting,
+	OperStateDormant,
+	OperStateUp,
+}
+
+// LinkCarrier is whether a network interface has a carrier, e.g. whether a
+// cable is plugged in.
+type LinkCarrier uint8
+
+const (
+	// LinkCarrierUnknown means the platform did not report the carrier, as
+	// is the case for interfaces that are administratively down on Linux.
+	LinkCarrierUnknown LinkCarrier = iota
+	LinkCarrierDown
+	LinkCarrierUp
+)
+
+// String returns "up", "down" or "unknown".
+func (c LinkCarrier) String() string {
+	switch c {
+	case LinkCarrierDown:
+		return "down"
+	case LinkCarrierUp:
+		return "up"
+	default:
+		return "unknown"
+	}
+}
+
+// parseLinkCarrier parses the output of LinkCarrier.String().  The empty string
+// parses to LinkCarrierUnknown.
+func parseLinkCarrier(s string) (LinkCarrier, error) {
+	switch strings.ToLower(s) {
+	case "", "unknown":
+		return LinkCarrierUnknown, nil
+	case "down":
+		return LinkCarrierDown, nil
+	case "up":
+		return LinkCarrierUp, nil
+	default:
+		return LinkCarrierUnknown, fmt.Errorf("invalid carrier %q", s)
+	}
+}
+
+// LinkInfo is the state of a network interface that net.Interface does not
+// report.
+type LinkInfo struct {
+	Kind      InterfaceKind
+	OperState OperState
+	Carrier   LinkCarrier
+
+	// Speed is the speed of the link in Mb/s, or 0 if unknown.
+	Speed int
+
+	// Duplex is "full" or "half", or empty if unknown.
+	Duplex string
+}
+
+// Linux's tun_flags from include/uapi/linux/if_tun.h.
+const iffTap = 0x0002
+
+// readSysfsInterfaceKind returns the kind of intf from its directory in root,
+// a copy of Linux's /sys/class/net.  Devices that announce their type in
+// uevent (e.g. "vlan" or "wireguard") report it and devices backed by hardware
+// are physical.  Other virtual devices are InterfaceKindVirtual: sysfs can not
+// tell the end of a veth pair from e.g. a macvlan, which are both links to
+// another interface.  An error is returned if the interface has no directory
+// in root.
+func readSysfsInterfaceKind(root string, intf net.Interface) (InterfaceKind, error) {
+	if intf.Flags&net.FlagLoopback != 0 {
+		return InterfaceKindLoopback, nil
+	}
+
+	dir := filepath.Join(root, intf.Name)
+	if _, err := os.Stat(dir); err != nil {
+		return "", err
+	}
+
+	devType := readSysfsUevent(dir, "DEVTYPE")
+	switch InterfaceKind(devType) {
+	case InterfaceKindBond, InterfaceKindBridge, InterfaceKindVLAN, InterfaceKindWireGuard:
+		return InterfaceKind(devType), nil
+	}
+
+	switch {
+	case sysfsExists(dir, "bridge"):
+		return InterfaceKindBridge, nil
+	case sysfsExists(dir, "bonding"):
+		return InterfaceKindBond, nil
+	}
+
+	if tunFlags, ok := readSysfsUint(dir, "tun_flags"); ok {
+		if tunFlags&iffTap != 0 {
+			return InterfaceKindTap, nil
+		}
+		return InterfaceKindTun, nil
+	}
+
+	if sysfsExists(dir, "device") {
+		return InterfaceKindPhysical, nil
+	}
+	if devType != "" {
+		return InterfaceKind(devType), nil
+	}
+
+	return InterfaceKindVirtual, nil
+}
+
+// readSysfsLinkInfo returns the LinkInfo of intf from its directory in root, a
+// copy of Linux's /sys/class/net.  Along with its kind, the operational state,
+// carrier, speed and duplex of the link are read.  The kernel refuses to report
+// the carrier, speed and duplex of some links, such as those that are down or
+// virtual, in which case they are left unknown.  An error is returned if the
+// interface has no directory in root.
+func readSysfsLinkInfo(root string, intf net.Interface) (LinkInfo, error) {
+	kind, err := readSysfsInterfaceKind(root, intf)
+	if err != nil {
+		return LinkInfo{}, err
+	}
+
+	dir := filepath.Join(root, intf.Name)
+	info := LinkInfo{Kind: kind}
+	if operState, ok := readSysfsString(dir, "operstate"); ok {
+		info.OperState = OperState(operState)
+	}
+
+	if carrier, ok := readSysfsUint(dir, "carrier"); ok {
+		info.Carrier = LinkCarrierDown
+		if carrier != 0 {
+			info.Carrier = LinkCarrierUp
+		}
+	}
+
+	// Links of unknown speed report -1.
+	if s, ok := readSysfsString(dir, "speed"); ok {
+		if speed, err := strconv.Atoi(s); err == nil && speed > 0 {
+			info.Speed = speed
+		}
+	}
+
+	if duplex, ok := readSysfsString(dir, "duplex"); ok && duplex != "unknown" {
+		info.Duplex = duplex
+	}
+
+	return info, nil
+}
+
+// sysfsExists returns true if the named entry of dir exists.
+func sysfsExists(dir, name string) bool {
+	_, err := os.Lstat(filepath.Join(dir, name))
+	return err == nil
+}
+
+// readSysfsString returns the trimmed contents of the named file in dir, or
+// false if it can not be read.
+func readSysfsString(dir, name string) (string, bool) {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", false
+	}
+	return string(bytes.TrimSpace(b)), true
+}
+
+// readSysfsUint returns the decimal or "0x" prefixed hexadecimal number in the
+// named file in dir, or false if it can not be read or parsed.
+func readSysfsUint(dir, name string) (uint64, bool) {
+	s, ok := readSysfsString(dir, name)
+	if !ok {
+		return 0, false
+	}
+
+	v, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// readSysfsUevent returns the value of key in the uevent file in dir, or an
+// empty string if it is not set.
+func readSysfsUevent(dir, key string) string {
+	f, err := os.Open(filepath.Join(dir, "uevent"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if k, v, found := strings.Cut(scanner.Text(), "="); found && k == key {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,172 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// defaultSysfsRoot is the mount point of sysfs, whose class/net directory has
+// an entry for every network interface.
+const defaultSysfsRoot = "/sys"
+
+// Attributes nested in IFLA_LINKINFO from include/uapi/linux/if_link.h, and
+// IFLA_TUN_TYPE, which is nested in the IFLA_INFO_DATA of tun devices.
+const (
+	iflaInfoKind = 1
+	iflaInfoData = 2
+	iflaTunType  = 1
+)
+
+// interfaceLinkInfo returns the LinkInfo of intf read from the sysfs mounted at
+// sysfsRoot, or /sys if sysfsRoot is empty.  See allInterfaceLinkInfo().
+func interfaceLinkInfo(sysfsRoot string, intf net.Interface) (LinkInfo, error) {
+	infos, err := allInterfaceLinkInfo(sysfsRoot, []net.Interface{intf})
+	if err != nil {
+		return LinkInfo{}, err
+	}
+
+	return infos[intf.Index], nil
+}
+
+// allInterfaceLinkInfo returns the LinkInfo of ifs, keyed by interface index,
+// read from the sysfs mounted at sysfsRoot, or /sys if sysfsRoot is empty.  If
+// sysfsRoot is empty the kind of each interface is read over netlink, and the
+// kinds guessed from sysfs are only used for the interfaces netlink has no
+// kind for, e.g. physical ones, or when netlink is not available.  If sysfs is
+// not mounted or has no entry for an interface, only loopback interfaces have
+// a kind.  Other errors reading sysfs are returned.
+func allInterfaceLinkInfo(sysfsRoot string, ifs []net.Interface) (map[int]LinkInfo, error) {
+	var kinds map[int]InterfaceKind
+	if sysfsRoot == "" {
+		sysfsRoot = defaultSysfsRoot
+
+		// Netlink only reports the links of our network namespace, which
+		// a sysfs mounted elsewhere need not describe.
+		kinds, _ = netlinkLinkKinds()
+	}
+
+	infos := make(map[int]LinkInfo, len(ifs))
+	for _, intf := range ifs {
+		info, err := readSysfsLinkInfo(filepath.Join(sysfsRoot, "class", "net"), intf)
+		if errors.Is(err, fs.ErrNotExist) {
+			info, err = LinkInfo{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if kind, ok := kinds[intf.Index]; ok {
+			info.Kind = kind
+		}
+		infos[intf.Index] = info
+	}
+
+	return infos, nil
+}
+
+// netlinkLinkKinds dumps the kinds of all links over netlink.
+func netlinkLinkKinds() (map[int]InterfaceKind, error) {
+	tab, err := syscall.NetlinkRIB(syscall.RTM_GETLINK, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, os.NewSyscallError("netlinkrib", err)
+	}
+
+	msgs, err := syscall.ParseNetlinkMessage(tab)
+	if err != nil {
+		return nil, os.NewSyscallError("parsenetlinkmessage", err)
+	}
+
+	return parseNetlinkLinkMsgs(msgs)
+}
+
+// parseNetlinkLinkMsgs parses the RTM_NEWLINK messages of a netlink link dump
+// into the IFLA_INFO_KIND of each link, keyed by interface index.  Links
+// without a kind, such as physical and loopback interfaces, are omitted, as are
+// tun devices on kernels that do not report whether they are tun or tap.
+// Kinds without an InterfaceKind constant, e.g. "macvlan" or "vxlan", are
+// returned as is.
+func parseNetlinkLinkMsgs(msgs []syscall.NetlinkMessage) (map[int]InterfaceKind, error) {
+	kinds := make(map[int]InterfaceKind)
+	for i := range msgs {
+		m := &msgs[i]
+		if m.Header.Type == syscall.NLMSG_DONE {
+			break
+		}
+		if m.Header.Type != syscall.RTM_NEWLINK {
+			continue
+		}
+		if len(m.Data) < syscall.SizeofIfInfomsg {
+			return nil, fmt.Errorf("truncated link message of %d bytes", len(m.Data))
+		}
+
+		// struct ifinfomsg: family, type, index, flags, change.
+		ifIndex := int(binary.NativeEndian.Uint32(m.Data[4:8]))
+
+		attrs, err := syscall.ParseNetlinkRouteAttr(m)
+		if err != nil {
+			return nil, os.NewSyscallError("parsenetlinkrouteattr", err)
+		}
+
+		var linkInfo []syscall.NetlinkRouteAttr
+		for _, attr := range attrs {
+			if attr.Attr.Type == syscall.IFLA_LINKINFO {
+				if linkInfo, err = parseNetlinkNestedAttrs(attr.Value); err != nil {
+					return nil, fmt.Errorf("unable to parse link info: %w", err)
+				}
+			}
+		}
+
+		var kind InterfaceKind
+		var data []byte
+		for _, attr := range linkInfo {
+			switch attr.Attr.Type {
+			case iflaInfoKind:
+				kind = InterfaceKind(strings.TrimRight(string(attr.Value), "\x00"))
+			case iflaInfoData:
+				data = attr.Value
+			}
+		}
+
+		if kind == InterfaceKindTun {
+			if kind, err = netlinkTunKind(data); err != nil {
+				return nil, err
+			}
+		}
+		if kind != "" {
+			kinds[ifIndex] = kind
+		}
+	}
+
+	return kinds, nil
+}
+
+// netlinkTunKind returns InterfaceKindTun or InterfaceKindTap from the
+// IFLA_INFO_DATA of a tun device, or an empty InterfaceKind if it does not
+// report its type.
+func netlinkTunKind(data []byte) (InterfaceKind, error) {
+	attrs, err := parseNetlinkNestedAttrs(data)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse tun info: %w", err)
+	}
+
+	for _, attr := range attrs {
+		if attr.Attr.Type == iflaTunType && len(attr.Value) >= 1 {
+			if attr.Value[0]&iffTap != 0 {
+				return InterfaceKindTap, nil
+			}
+			return InterfaceKindTun, nil
+		}
+	}
+
+	return "", nil
+}
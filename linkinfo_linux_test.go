@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// netlinkLinkMsg returns an RTM_NEWLINK message for the interface with index
+// ifIndex followed by attrs.
+func netlinkLinkMsg(ifIndex uint32, attrs ...[]byte) syscall.NetlinkMessage {
+	data := make([]byte, syscall.SizeofIfInfomsg)
+	binary.NativeEndian.PutUint32(data[4:], ifIndex)
+	for _, attr := range attrs {
+		data = append(data, attr...)
+	}
+
+	return syscall.NetlinkMessage{
+		Header: syscall.NlMsghdr{Type: syscall.RTM_NEWLINK},
+		Data:   data,
+	}
+}
+
+// netlinkLinkInfo returns an IFLA_LINKINFO attribute of the given kind,
+// followed by the attributes of its IFLA_INFO_DATA if any.
+func netlinkLinkInfo(kind string, data ...[]byte) []byte {
+	info := netlinkAttr(iflaInfoKind, append([]byte(kind), 0))
+	if len(data) > 0 {
+		var b []byte
+		for _, attr := range data {
+			b = append(b, attr...)
+		}
+		info = append(info, netlinkAttr(iflaInfoData, b)...)
+	}
+	return netlinkAttr(syscall.IFLA_LINKINFO, info)
+}
+
+func Test_parseNetlinkLinkMsgs(t *testing.T) {
+	msgs := []syscall.NetlinkMessage{
+		netlinkLinkMsg(1, netlinkAttr(syscall.IFLA_IFNAME, []byte("lo\x00"))),
+		netlinkLinkMsg(2, netlinkAttr(syscall.IFLA_IFNAME, []byte("eth0\x00"))),
+		netlinkLinkMsg(3, netlinkAttr(syscall.IFLA_IFNAME, []byte("docker0\x00")), netlinkLinkInfo("bridge")),
+		netlinkLinkMsg(4, netlinkAttr(syscall.IFLA_IFNAME, []byte("veth1a2b3c\x00")), netlinkLinkInfo("veth")),
+		netlinkLinkMsg(5, netlinkAttr(syscall.IFLA_IFNAME, []byte("macvlan0\x00")), netlinkLinkInfo("macvlan")),
+		netlinkLinkMsg(6, netlinkLinkInfo("tun", netlinkAttr(iflaTunType, []byte{0x01}))),
+		netlinkLinkMsg(7, netlinkLinkInfo("tun", netlinkAttr(iflaTunType, []byte{0x02}))),
+		// Older kernels do not report the type of tun devices.
+		netlinkLinkMsg(8, netlinkLinkInfo("tun")),
+		{Header: syscall.NlMsghdr{Type: syscall.NLMSG_DONE}},
+	}
+
+	kinds, err := parseNetlinkLinkMsgs(msgs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[int]InterfaceKind{
+		3: InterfaceKindBridge,
+		4: InterfaceKindVeth,
+		5: "macvlan",
+		6: InterfaceKindTun,
+		7: InterfaceKindTap,
+	}
+	if !maps.Equal(kinds, want) {
+		t.Errorf("got %v, want %v", kinds, want)
+	}
+
+	truncated := []syscall.NetlinkMessage{
+		{Header: syscall.NlMsghdr{Type: syscall.RTM_NEWLINK}, Data: []byte{syscall.AF_UNSPEC, 0}},
+	}
+	if _, err := parseNetlinkLinkMsgs(truncated); err == nil {
+		t.Errorf("expected an error for a truncated link message")
+	}
+}
+
+func TestOSInterfaceProvider_LinkInfo(t *testing.T) {
+	root := t.TempDir()
+	writeSysfsFixture(t, filepath.Join(root, "class", "net"), "eth0", map[string]string{
+		"device/":   "",
+		"operstate": "up\n",
+		"carrier":   "1\n",
+		"speed":     "10000\n",
+		"duplex":    "full\n",
+	})
+
+	p := OSInterfaceProvider{SysfsRoot: root}
+	info, err := p.LinkInfo(net.Interface{Index: 2, Name: "eth0", Flags: net.FlagUp})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := LinkInfo{Kind: InterfaceKindPhysical, OperState: OperStateUp, Carrier: LinkCarrierUp, Speed: 10000, Duplex: "full"}
+	if info != want {
+		t.Errorf("got %+v, want %+v", info, want)
+	}
+
+	// Interfaces missing from sysfs have no LinkInfo, except for the kind of
+	// loopback interfaces.
+	if info, err = p.LinkInfo(net.Interface{Index: 3, Name: "eth1"}); err != nil || info != (LinkInfo{}) {
+		t.Errorf("got %+v, %v for an interface missing from sysfs", info, err)
+	}
+	if info, err = p.LinkInfo(net.Interface{Index: 1, Name: "lo", Flags: net.FlagLoopback}); err != nil || info != (LinkInfo{Kind: InterfaceKindLoopback}) {
+		t.Errorf("got %+v, %v for a loopback interface missing from sysfs", info, err)
+	}
+
+	// Other errors reading sysfs are returned.
+	broken := t.TempDir()
+	if err := os.WriteFile(filepath.Join(broken, "class"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p = OSInterfaceProvider{SysfsRoot: broken}
+	if info, err = p.LinkInfo(net.Interface{Index: 2, Name: "eth0"}); err == nil {
+		t.Errorf("got %+v for a sysfs root that is not a directory, want an error", info)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !linux
+
+package sockaddr
+
+import "net"
+
+// interfaceLinkInfo returns the LinkInfo of intf.  Only the kind of loopback
+// interfaces is reported on this platform, and sysfsRoot is ignored.
+func interfaceLinkInfo(_ string, intf net.Interface) (LinkInfo, error) {
+	if intf.Flags&net.FlagLoopback != 0 {
+		return LinkInfo{Kind: InterfaceKindLoopback}, nil
+	}
+
+	return LinkInfo{}, nil
+}
+
+// allInterfaceLinkInfo returns the LinkInfo of ifs, keyed by interface index.
+// See interfaceLinkInfo().
+func allInterfaceLinkInfo(sysfsRoot string, ifs []net.Interface) (map[int]LinkInfo, error) {
+	infos := make(map[int]LinkInfo, len(ifs))
+	for _, intf := range ifs {
+		info, err := interfaceLinkInfo(sysfsRoot, intf)
+		if err != nil {
+			return nil, err
+		}
+		infos[intf.Index] = info
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,356 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSysfsFixture creates the directory of an interface under root with the
+// given files.  Files named with a trailing "/" are created as directories.
+func writeSysfsFixture(t *testing.T, root, ifName string, files map[string]string) {
+	t.Helper()
+
+	dir := filepath.Join(root, ifName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if name[len(name)-1] == '/' {
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func Test_readSysfsInterfaceKind(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags net.Flags
+		files map[string]string
+		kind  InterfaceKind
+	}{
+		{
+			name:  "lo",
+			flags: net.FlagUp | net.FlagLoopback,
+			kind:  InterfaceKindLoopback,
+		},
+		{
+			name: "eth0",
+			files: map[string]string{
+				"uevent":  "INTERFACE=eth0\nIFINDEX=2\n",
+				"ifindex": "2\n",
+				"iflink":  "2\n",
+				"device/": "",
+			},
+			kind: InterfaceKindPhysical,
+		},
+		{
+			name: "wlan0",
+			files: map[string]string{
+				"uevent":  "DEVTYPE=wlan\nINTERFACE=wlan0\nIFINDEX=3\n",
+				"device/": "",
+			},
+			kind: InterfaceKindPhysical,
+		},
+		{
+			name: "docker0",
+			files: map[string]string{
+				"uevent":  "DEVTYPE=bridge\nINTERFACE=docker0\nIFINDEX=4\n",
+				"bridge/": "",
+			},
+			kind: InterfaceKindBridge,
+		},
+		{
+			name: "br0",
+			files: map[string]string{
+				"bridge/": "",
+			},
+			kind: InterfaceKindBridge,
+		},
+		{
+			name: "bond0",
+			files: map[string]string{
+				"uevent":   "DEVTYPE=bond\nINTERFACE=bond0\nIFINDEX=5\n",
+				"bonding/": "",
+			},
+			kind: InterfaceKindBond,
+		},
+		{
+			name: "eth0.100",
+			files: map[string]string{
+				"uevent":  "DEVTYPE=vlan\nINTERFACE=eth0.100\nIFINDEX=6\n",
+				"ifindex": "6\n",
+				"iflink":  "2\n",
+			},
+			kind: InterfaceKindVLAN,
+		},
+		{
+			name: "wg0",
+			files: map[string]string{
+				"uevent": "DEVTYPE=wireguard\nINTERFACE=wg0\nIFINDEX=7\n",
+			},
+			kind: InterfaceKindWireGuard,
+		},
+		{
+			name: "tun0",
+			files: map[string]string{
+				"tun_flags": "0x1001\n",
+			},
+			kind: InterfaceKindTun,
+		},
+		{
+			name: "tap0",
+			files: map[string]string{
+				"tun_flags": "0x1002\n",
+			},
+			kind: InterfaceKindTap,
+		},
+		{
+			// Only netlink tells a veth from other links to another
+			// interface.
+			name: "veth1a2b3c",
+			files: map[string]string{
+				"uevent":  "INTERFACE=veth1a2b3c\nIFINDEX=9\n",
+				"ifindex": "9\n",
+				"iflink":  "8\n",
+			},
+			kind: InterfaceKindVirtual,
+		},
+		{
+			name: "macvlan0",
+			files: map[string]string{
+				"uevent":  "INTERFACE=macvlan0\nIFINDEX=12\n",
+				"ifindex": "12\n",
+				"iflink":  "2\n",
+			},
+			kind: InterfaceKindVirtual,
+		},
+		{
+			name: "vxlan0",
+			files: map[string]string{
+				"uevent":  "DEVTYPE=vxlan\nINTERFACE=vxlan0\nIFINDEX=10\n",
+				"ifindex": "10\n",
+				"iflink":  "10\n",
+			},
+			kind: "vxlan",
+		},
+		{
+			name: "dummy0",
+			files: map[string]string{
+				"uevent":  "INTERFACE=dummy0\nIFINDEX=11\n",
+				"ifindex": "11\n",
+				"iflink":  "11\n",
+			},
+			kind: InterfaceKindVirtual,
+		},
+	}
+
+	root := t.TempDir()
+	for _, test := range tests {
+		writeSysfsFixture(t, root, test.name, test.files)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kind, err := readSysfsInterfaceKind(root, net.Interface{Name: test.name, Flags: test.flags})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if kind != test.kind {
+				t.Errorf("got %q, want %q", kind, test.kind)
+			}
+		})
+	}
+
+	if _, err := readSysfsInterfaceKind(root, net.Interface{Name: "missing0"}); err == nil {
+		t.Errorf("expected an error for an interface without a sysfs directory")
+	}
+}
+
+func Test_readSysfsLinkInfo(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags net.Flags
+		files map[string]string
+		want  LinkInfo
+	}{
+		{
+			name:  "lo",
+			flags: net.FlagUp | net.FlagLoopback,
+			files: map[string]string{
+				"operstate": "unknown\n",
+				"carrier":   "1\n",
+			},
+			want: LinkInfo{Kind: InterfaceKindLoopback, OperState: OperStateUnknown, Carrier: LinkCarrierUp},
+		},
+		{
+			name: "eth0",
+			files: map[string]string{
+				"device/":   "",
+				"operstate": "up\n",
+				"carrier":   "1\n",
+				"speed":     "25000\n",
+				"duplex":    "full\n",
+			},
+			want: LinkInfo{Kind: InterfaceKindPhysical, OperState: OperStateUp, Carrier: LinkCarrierUp, Speed: 25000, Duplex: "full"},
+		},
+		{
+			name: "eth1",
+			files: map[string]string{
+				"device/":   "",
+				"operstate": "down\n",
+				"carrier":   "0\n",
+				"speed":     "-1\n",
+				"duplex":    "unknown\n",
+			},
+			want: LinkInfo{Kind: InterfaceKindPhysical, OperState: OperStateDown, Carrier: LinkCarrierDown},
+		},
+		{
+			// The kernel fails reads of carrier, speed and duplex with
+			// EINVAL when the link is administratively down, which the
+			// fixture models by leaving them out.
+			name: "eth2",
+			files: map[string]string{
+				"device/":   "",
+				"operstate": "down\n",
+			},
+			want: LinkInfo{Kind: InterfaceKindPhysical, OperState: OperStateDown},
+		},
+		{
+			name: "wg0",
+			files: map[string]string{
+				"uevent":    "DEVTYPE=wireguard\nINTERFACE=wg0\nIFINDEX=7\n",
+				"operstate": "unknown\n",
+				"carrier":   "1\n",
+			},
+			want: LinkInfo{Kind: InterfaceKindWireGuard, OperState: OperStateUnknown, Carrier: LinkCarrierUp},
+		},
+	}
+
+	root := t.TempDir()
+	for _, test := range tests {
+		writeSysfsFixture(t, root, test.name, test.files)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := readSysfsLinkInfo(root, net.Interface{Name: test.name, Flags: test.flags})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if info != test.want {
+				t.Errorf("got %+v, want %+v", info, test.want)
+			}
+		})
+	}
+
+	if _, err := readSysfsLinkInfo(root, net.Interface{Name: "missing0"}); err == nil {
+		t.Errorf("expected an error for an interface without a sysfs directory")
+	}
+}
+
+func TestGetAllInterfacesFrom_LinkInfo(t *testing.T) {
+	eth0 := net.Interface{Index: 2, MTU: 9001, Name: "eth0", Flags: net.FlagUp | net.FlagBroadcast}
+	docker0 := net.Interface{Index: 3, MTU: 1500, Name: "docker0", Flags: net.FlagUp | net.FlagBroadcast}
+	veth := net.Interface{Index: 5, MTU: 1500, Name: "veth1a2b3c", Flags: net.FlagUp | net.FlagBroadcast}
+	eth1 := net.Interface{Index: 4, MTU: 1500, Name: "eth1", Flags: net.FlagUp | net.FlagBroadcast}
+	p := NewStaticInterfaceProvider(IfAddrs{
+		{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: eth0, Kind: InterfaceKindPhysical, OperState: OperStateUp, Carrier: LinkCarrierUp, Speed: 1000, Duplex: "full"},
+		{SockAddr: MustIPv4Addr("172.17.0.1/16"), Interface: docker0, Kind: InterfaceKindBridge, OperState: OperStateUp, Carrier: LinkCarrierUp},
+		{SockAddr: MustIPv6Addr("fe80::1/64"), Interface: veth, Kind: InterfaceKindVeth, OperState: OperStateUp, Carrier: LinkCarrierUp, Speed: 10000, Duplex: "full"},
+		{SockAddr: MustIPv4Addr("10.1.0.5/24"), Interface: eth1, Kind: InterfaceKindPhysical, OperState: OperStateDown, Carrier: LinkCarrierDown},
+	}, "eth0")
+
+	ifAddrs, err := GetAllInterfacesFrom(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	physical, err := IncludeIfs("kind", "physical", ifAddrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(physical) != 2 || physical[0].Name != "eth0" || physical[1].Name != "eth1" {
+		t.Errorf("unexpected physical interfaces: %v", physical)
+	}
+
+	got, err := JoinIfAddrs("kind", " ", ifAddrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "physical bridge veth physical"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	up, err := IncludeIfs("operstate", "up", physical)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(up) != 1 || up[0].Speed != 1000 || up[0].Duplex != "full" {
+		t.Errorf("unexpected interfaces with an up link: %v", up)
+	}
+
+	sorted, err := SortIfBy("-speed", ifAddrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := JoinIfAddrs("name", " ", sorted); got != "veth1a2b3c eth0 docker0 eth1" {
+		t.Errorf("unexpected order by speed: %q", got)
+	}
+}
+
+// linkDumpingProvider is a StaticInterfaceProvider that reads the LinkInfo of
+// all interfaces at once and counts the reads.
+type linkDumpingProvider struct {
+	*StaticInterfaceProvider
+	dumps int
+}
+
+func (p *linkDumpingProvider) LinkInfo(net.Interface) (LinkInfo, error) {
+	panic("LinkInfo called on a provider that reads all interfaces at once")
+}
+
+func (p *linkDumpingProvider) allLinkInfo(ifs []net.Interface) (map[int]LinkInfo, error) {
+	p.dumps++
+	return map[int]LinkInfo{
+		2: {Kind: InterfaceKindPhysical, OperState: OperStateUp},
+		3: {Kind: "macvlan", OperState: OperStateUp},
+	}, nil
+}
+
+func TestGetAllInterfacesFrom_AllLinkInfo(t *testing.T) {
+	eth0 := net.Interface{Index: 2, MTU: 1500, Name: "eth0", Flags: net.FlagUp | net.FlagBroadcast}
+	macvlan := net.Interface{Index: 3, MTU: 1500, Name: "macvlan0", Flags: net.FlagUp | net.FlagBroadcast}
+	p := &linkDumpingProvider{StaticInterfaceProvider: NewStaticInterfaceProvider(IfAddrs{
+		{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: eth0},
+		{SockAddr: MustIPv4Addr("10.0.0.6/24"), Interface: macvlan},
+	}, "eth0")}
+
+	ifAddrs, err := GetAllInterfacesFrom(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.dumps != 1 {
+		t.Errorf("got %d reads of all interfaces, want 1", p.dumps)
+	}
+
+	// A macvlan is not the end of a veth pair.
+	kept, err := ExcludeIfs("kind", "veth", ifAddrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := JoinIfAddrs("kind", " ", kept); got != "physical macvlan" {
+		t.Errorf("got kinds %q, want %q", got, "physical macvlan")
+	}
+}
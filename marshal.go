@@ -0,0 +1,580 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// sockAddrBinaryVersion is the version of the binary encoding produced by
+// MarshalBinary().  Every encoding begins with the version byte followed by
+// the SockAddrType byte and a type-specific payload (all integers are
+// big-endian):
+//
+//   - IPv4Addr: address (4 bytes), prefix length (1 byte), port (2 bytes)
+//   - IPv6Addr: address (16 bytes), prefix length (1 byte), port (2 bytes),
+//     zone (remaining bytes)
+//   - UnixSock: path (remaining bytes)
+//   - VSock: CID (4 bytes), port (4 bytes)
+//
+// SockAddrs are encoded as the version byte followed by each SockAddr's
+// encoding prefixed with its length as a uvarint.
+const sockAddrBinaryVersion = 1
+
+const (
+	ipv4BinaryLen  = 2 + IPv4len + 1 + 2
+	ipv6BinaryLen  = 2 + IPv6len + 1 + 2
+	vsockBinaryLen = 2 + 4 + 4
+)
+
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String(), except that a network with a port keeps its netmask between the
+// address and the port (e.g. "10.0.0.0/24:80").
+func (ipv4 IPv4Addr) MarshalText() ([]byte, error) {
+	if ipv4.Port != 0 && ipv4.Maskbits() != IPv4len*8 {
+		return fmt.Appendf(nil, "%s/%d:%d", ipv4.NetIP(), ipv4.Maskbits(), ipv4.Port), nil
+	}
+	return []byte(ipv4.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewIPv4Addr() and
+// accepts the output of MarshalText().
+func (ipv4 *IPv4Addr) UnmarshalText(text []byte) error {
+	s := string(text)
+	var port IPPort
+	if slash := strings.IndexByte(s, '/'); slash != -1 {
+		if colon := strings.LastIndexByte(s, ':'); colon > slash {
+			p, err := strconv.ParseUint(s[colon+1:], 10, 16)
+			if err != nil {
+				return fmt.Errorf("unable to parse the port of %+q: %v", s, err)
+			}
+			s, port = s[:colon], IPPort(p)
+		}
+	}
+
+	ipv4Addr, err := NewIPv4Addr(s)
+	if err != nil {
+		return err
+	}
+	if port != 0 {
+		ipv4Addr.Port = port
+	}
+	*ipv4 = ipv4Addr
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  An error is returned if
+// the netmask is not contiguous.
+func (ipv4 IPv4Addr) MarshalBinary() ([]byte, error) {
+	maskBits := bits.LeadingZeros32(^uint32(ipv4.Mask))
+	if bits.OnesCount32(uint32(ipv4.Mask)) != maskBits {
+		return nil, fmt.Errorf("unable to marshal %s: non-contiguous netmask", ipv4)
+	}
+
+	b := make([]byte, 0, ipv4BinaryLen)
+	b = append(b, sockAddrBinaryVersion, byte(TypeIPv4))
+	b = binary.BigEndian.AppendUint32(b, uint32(ipv4.Address))
+	b = append(b, byte(maskBits))
+	b = binary.BigEndian.AppendUint16(b, uint16(ipv4.Port))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (ipv4 *IPv4Addr) UnmarshalBinary(data []byte) error {
+	payload, err := checkBinaryHeader(data, TypeIPv4)
+	if err != nil {
+		return err
+	}
+	if len(data) != ipv4BinaryLen {
+		return fmt.Errorf("invalid IPv4Addr encoding length %d", len(data))
+	}
+
+	maskBits := int(payload[IPv4len])
+	if maskBits > IPv4len*8 {
+		return fmt.Errorf("invalid IPv4Addr prefix length %d", maskBits)
+	}
+
+	*ipv4 = IPv4Addr{
+		Address: IPv4Address(binary.BigEndian.Uint32(payload)),
+		Mask:    IPv4Mask(uint64(IPv4HostMask) << uint(IPv4len*8-maskBits)),
+		Port:    IPPort(binary.BigEndian.Uint16(payload[IPv4len+1:])),
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String(), except that a network with a port keeps its netmask inside the
+// brackets (e.g. "[2001:db8::/64]:443").
+func (ipv6 IPv6Addr) MarshalText() ([]byte, error) {
+	if ipv6.Port != 0 && ipv6.Maskbits() != IPv6len*8 {
+		return fmt.Appendf(nil, "[%s/%d]:%d", ipv6.addressString(), ipv6.Maskbits(), ipv6.Port), nil
+	}
+	return []byte(ipv6.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewIPv6Addr() and
+// accepts the output of MarshalText().
+func (ipv6 *IPv6Addr) UnmarshalText(text []byte) error {
+	s := string(text)
+	var port IPPort
+	if strings.HasPrefix(s, "[") {
+		if end := strings.LastIndex(s, "]:"); end != -1 && strings.Contains(s[:end], "/") {
+			p, err := strconv.ParseUint(s[end+2:], 10, 16)
+			if err != nil {
+				return fmt.Errorf("unable to parse the port of %+q: %v", s, err)
+			}
+			s, port = s[1:end], IPPort(p)
+		}
+	}
+
+	ipv6Addr, err := NewIPv6Addr(s)
+	if err != nil {
+		return err
+	}
+	if port != 0 {
+		ipv6Addr.Port = port
+	}
+	*ipv6 = ipv6Addr
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  An error is returned if
+// the netmask is not contiguous.
+func (ipv6 IPv6Addr) MarshalBinary() ([]byte, error) {
+	maskBits := Uint128(ipv6.Mask).Not().LeadingZeros()
+	if mask128(maskBits) != Uint128(ipv6.Mask) {
+		return nil, fmt.Errorf("unable to marshal %s: non-contiguous netmask", ipv6)
+	}
+
+	address := Uint128(ipv6.Address).Bytes()
+	b := make([]byte, 0, ipv6BinaryLen+len(ipv6.Zone))
+	b = append(b, sockAddrBinaryVersion, byte(TypeIPv6))
+	b = append(b, address[:]...)
+	b = append(b, byte(maskBits))
+	b = binary.BigEndian.AppendUint16(b, uint16(ipv6.Port))
+	b = append(b, ipv6.Zone...)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (ipv6 *IPv6Addr) UnmarshalBinary(data []byte) error {
+	payload, err := checkBinaryHeader(data, TypeIPv6)
+	if err != nil {
+		return err
+	}
+	if len(data) < ipv6BinaryLen {
+		return fmt.Errorf("invalid IPv6Addr encoding length %d", len(data))
+	}
+
+	maskBits := int(payload[IPv6len])
+	if maskBits > IPv6len*8 {
+		return fmt.Errorf("invalid IPv6Addr prefix length %d", maskBits)
+	}
+
+	*ipv6 = IPv6Addr{
+		Address: IPv6Address(Uint128FromBytes([IPv6len]byte(payload))),
+		Mask:    IPv6Mask(mask128(maskBits)),
+		Port:    IPPort(binary.BigEndian.Uint16(payload[IPv6len+1:])),
+		Zone:    string(payload[IPv6len+3:]),
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the unquoted path
+// of the socket.
+func (us UnixSock) MarshalText() ([]byte, error) {
+	return []byte(us.path), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewUnixSock().
+func (us *UnixSock) UnmarshalText(text []byte) error {
+	unixSock, err := NewUnixSock(string(text))
+	if err != nil {
+		return err
+	}
+	*us = unixSock
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (us UnixSock) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 2+len(us.path))
+	b = append(b, sockAddrBinaryVersion, byte(TypeUnix))
+	return append(b, us.path...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (us *UnixSock) UnmarshalBinary(data []byte) error {
+	payload, err := checkBinaryHeader(data, TypeUnix)
+	if err != nil {
+		return err
+	}
+	return us.UnmarshalText(payload)
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String() (e.g. "vsock://3:1024").
+func (vs VSock) MarshalText() ([]byte, error) {
+	return []byte(vs.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewVSock().
+func (vs *VSock) UnmarshalText(text []byte) error {
+	vsock, err := NewVSock(string(text))
+	if err != nil {
+		return err
+	}
+	*vs = vsock
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (vs VSock) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, vsockBinaryLen)
+	b = append(b, sockAddrBinaryVersion, byte(TypeVSock))
+	b = binary.BigEndian.AppendUint32(b, uint32(vs.CID))
+	b = binary.BigEndian.AppendUint32(b, uint32(vs.Port))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (vs *VSock) UnmarshalBinary(data []byte) error {
+	payload, err := checkBinaryHeader(data, TypeVSock)
+	if err != nil {
+		return err
+	}
+	if len(data) != vsockBinaryLen {
+		return fmt.Errorf("invalid VSock encoding length %d", len(data))
+	}
+
+	*vs = VSock{
+		CID:  VSockCID(binary.BigEndian.Uint32(payload)),
+		Port: VSockPort(binary.BigEndian.Uint32(payload[4:])),
+	}
+	return nil
+}
+
+// MarshalJSON encodes sas as an array of strings in the MarshalText() format
+// of each SockAddr, with UnixSocks prefixed by "unix:".
+func (sas SockAddrs) MarshalJSON() ([]byte, error) {
+	strs := make([]string, 0, len(sas))
+	for _, sa := range sas {
+		text, err := marshalSockAddrText(sa)
+		if err != nil {
+			return nil, err
+		}
+		strs = append(strs, text)
+	}
+	return json.Marshal(strs)
+}
+
+// UnmarshalJSON decodes an array of strings in the MarshalJSON() format into
+// sas.
+func (sas *SockAddrs) UnmarshalJSON(data []byte) error {
+	var strs []string
+	if err := json.Unmarshal(data, &strs); err != nil {
+		return err
+	}
+
+	out := make(SockAddrs, 0, len(strs))
+	for _, str := range strs {
+		sa, err := unmarshalSockAddrText(str)
+		if err != nil {
+			return err
+		}
+		out = append(out, sa)
+	}
+	*sas = out
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler so that SockAddrs can be
+// encoded without registering each SockAddr type with encoding/gob.
+func (sas SockAddrs) MarshalBinary() ([]byte, error) {
+	b := []byte{sockAddrBinaryVersion}
+	for _, sa := range sas {
+		m, ok := sa.(interface{ MarshalBinary() ([]byte, error) })
+		if !ok {
+			return nil, fmt.Errorf("unable to marshal SockAddr type %T", sa)
+		}
+
+		data, err := m.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b = binary.AppendUvarint(b, uint64(len(data)))
+		b = append(b, data...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (sas *SockAddrs) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != sockAddrBinaryVersion {
+		return fmt.Errorf("unsupported SockAddrs encoding")
+	}
+
+	var out SockAddrs
+	for data = data[1:]; len(data) > 0; {
+		n, l := binary.Uvarint(data)
+		if l <= 0 || n > uint64(len(data)-l) {
+			return fmt.Errorf("truncated SockAddrs encoding")
+		}
+		sa, err := unmarshalSockAddrBinary(data[l : l+int(n)])
+		if err != nil {
+			return err
+		}
+		out = append(out, sa)
+		data = data[l+int(n):]
+	}
+	*sas = out
+	return nil
+}
+
+// ifAddrJSON is the JSON representation of an IfAddr.
+type ifAddrJSON struct {
+	Address      string `json:"address"`
+	Name         string `json:"name"`
+	Index        int    `json:"index"`
+	MTU          int    `json:"mtu"`
+	HardwareAddr string `json:"hardware_addr,omitempty"`
+	Flags        string `json:"flags"`
+	AddrFlags    string `json:"address_flags,omitempty"`
+	AddrScope    string `json:"scope,omitempty"`
+	Kind         string `json:"kind,omitempty"`
+	OperState    string `json:"operstate,omitempty"`
+	Carrier      string `json:"carrier,omitempty"`
+	Speed        int    `json:"speed,omitempty"`
+	Duplex       string `json:"duplex,omitempty"`
+}
+
+// MarshalJSON encodes ifAddr as an object with its address in the
+// MarshalText() format and the interface's name, index, MTU, hardware address
+// and flags, followed by the flags and scope of the address and the kind and
+// link state of the interface if they are known.
+// IfAddrs are encoded as an array of these objects.
+func (ifAddr IfAddr) MarshalJSON() ([]byte, error) {
+	address, err := marshalSockAddrText(ifAddr.SockAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	j := ifAddrJSON{
+		Address: address,
+		Name:    ifAddr.Name,
+		Index:   ifAddr.Index,
+		MTU:     ifAddr.MTU,
+	}
+	if len(ifAddr.HardwareAddr) > 0 {
+		j.HardwareAddr = ifAddr.HardwareAddr.String()
+	}
+	if ifAddr.Flags != 0 {
+		j.Flags = ifAddr.Flags.String()
+	}
+	if ifAddr.AddrFlags != 0 {
+		j.AddrFlags = ifAddr.AddrFlags.String()
+	}
+	j.AddrScope = ifAddr.AddrScope.String()
+	j.Kind = string(ifAddr.Kind)
+	j.OperState = string(ifAddr.OperState)
+	if ifAddr.Carrier != LinkCarrierUnknown {
+		j.Carrier = ifAddr.Carrier.String()
+	}
+	j.Speed = ifAddr.Speed
+	j.Duplex = ifAddr.Duplex
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ifAddr *IfAddr) UnmarshalJSON(data []byte) error {
+	var j ifAddrJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	sa, err := unmarshalSockAddrText(j.Address)
+	if err != nil {
+		return err
+	}
+
+	var hwAddr net.HardwareAddr
+	if j.HardwareAddr != "" {
+		if hwAddr, err = net.ParseMAC(j.HardwareAddr); err != nil {
+			return err
+		}
+	}
+
+	flags, err := parseNetFlags(j.Flags)
+	if err != nil {
+		return err
+	}
+
+	addrFlags, err := parseAddrFlags(j.AddrFlags)
+	if err != nil {
+		return err
+	}
+
+	addrScope, err := parseAddrScope(j.AddrScope)
+	if err != nil {
+		return err
+	}
+
+	carrier, err := parseLinkCarrier(j.Carrier)
+	if err != nil {
+		return err
+	}
+
+	*ifAddr = IfAddr{
+		SockAddr: sa,
+		Interface: net.Interface{
+			Index:        j.Index,
+			MTU:          j.MTU,
+			Name:         j.Name,
+			HardwareAddr: hwAddr,
+			Flags:        flags,
+		},
+		AddrFlags: addrFlags,
+		AddrScope: addrScope,
+		Kind:      InterfaceKind(j.Kind),
+		OperState: OperState(j.OperState),
+		Carrier:   carrier,
+		Speed:     j.Speed,
+		Duplex:    j.Duplex,
+	}
+	return nil
+}
+
+// netFlagsByName maps the names used by net.Flags.String() back to their
+// flag.
+var netFlagsByName = func() map[string]net.Flags {
+	m := make(map[string]net.Flags)
+	for i := 0; i < 32; i++ {
+		if flag := net.Flags(1 << i); flag.String() != "0" {
+			m[flag.String()] = flag
+		}
+	}
+	return m
+}()
+
+// parseNetFlags parses the "|" separated output of net.Flags.String().
+func parseNetFlags(s string) (net.Flags, error) {
+	var flags net.Flags
+	if s == "" {
+		return flags, nil
+	}
+
+	for flagName := range strings.SplitSeq(s, "|") {
+		flag, found := netFlagsByName[flagName]
+		if !found {
+			return 0, fmt.Errorf("unknown interface flag: %+q", flagName)
+		}
+		flags |= flag
+	}
+	return flags, nil
+}
+
+// checkBinaryHeader validates the version and type bytes of a binary encoding
+// and returns the payload that follows them.
+func checkBinaryHeader(data []byte, sockType SockAddrType) ([]byte, error) {
+	switch {
+	case len(data) < 2:
+		return nil, fmt.Errorf("truncated %s encoding", sockType)
+	case data[0] != sockAddrBinaryVersion:
+		return nil, fmt.Errorf("unsupported %s encoding version %d", sockType, data[0])
+	case SockAddrType(data[1]) != sockType:
+		return nil, fmt.Errorf("unable to unmarshal SockAddr type %d into a %s", data[1], sockType)
+	}
+	return data[2:], nil
+}
+
+// unixTextPrefix marks a UnixSock in the text form of a SockAddr of any type,
+// as the path of a UnixSock may be relative, empty or look like an address.
+const unixTextPrefix = "unix:"
+
+// marshalSockAddrText returns the MarshalText() form of sa, prefixed with
+// unixTextPrefix if sa is a UnixSock.
+func marshalSockAddrText(sa SockAddr) (string, error) {
+	if us, ok := sa.(UnixSock); ok {
+		return unixTextPrefix + us.path, nil
+	}
+
+	m, ok := sa.(interface{ MarshalText() ([]byte, error) })
+	if !ok {
+		return "", fmt.Errorf("unable to marshal SockAddr type %T", sa)
+	}
+
+	text, err := m.MarshalText()
+	if err != nil {
+		return "", err
+	}
+	return string(text), nil
+}
+
+// unmarshalSockAddrText decodes a SockAddr of any type from the output of
+// marshalSockAddrText() using the UnmarshalText() method of its type.  Text
+// that is not in that format, such as a hand-written absolute path, is parsed
+// with NewSockAddr().
+func unmarshalSockAddrText(text string) (SockAddr, error) {
+	switch {
+	case strings.HasPrefix(text, unixTextPrefix):
+		return NewUnixSock(text[len(unixTextPrefix):])
+	case strings.HasPrefix(text, vsockScheme):
+		return NewVSock(text)
+	}
+
+	var ipv4 IPv4Addr
+	if err := ipv4.UnmarshalText([]byte(text)); err == nil {
+		return ipv4, nil
+	}
+
+	var ipv6 IPv6Addr
+	if err := ipv6.UnmarshalText([]byte(text)); err == nil {
+		return ipv6, nil
+	}
+
+	return NewSockAddr(text)
+}
+
+// unmarshalSockAddrBinary decodes a single SockAddr of any type from its
+// MarshalBinary() encoding.
+func unmarshalSockAddrBinary(data []byte) (SockAddr, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("truncated SockAddr encoding")
+	}
+
+	switch sockType := SockAddrType(data[1]); sockType {
+	case TypeIPv4:
+		var ipv4 IPv4Addr
+		if err := ipv4.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return ipv4, nil
+	case TypeIPv6:
+		var ipv6 IPv6Addr
+		if err := ipv6.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return ipv6, nil
+	case TypeUnix:
+		var us UnixSock
+		if err := us.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return us, nil
+	case TypeVSock:
+		var vs VSock
+		if err := vs.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return vs, nil
+	default:
+		return nil, fmt.Errorf("unsupported SockAddr type %d", sockType)
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+// marshaler is implemented by every SockAddr type.
+type marshaler interface {
+	sockaddr.SockAddr
+	encoding.TextMarshaler
+	encoding.BinaryMarshaler
+}
+
+func TestSockAddr_MarshalRoundTrip(t *testing.T) {
+	tests := []struct {
+		input  marshaler
+		text   string
+		binLen int
+	}{
+		{input: sockaddr.MustIPv4Addr("192.168.1.2"), text: "192.168.1.2", binLen: 9},
+		{input: sockaddr.MustIPv4Addr("10.1.2.3/8"), text: "10.1.2.3/8", binLen: 9},
+		{input: sockaddr.MustIPv4Addr("127.0.0.1:8080"), text: "127.0.0.1:8080", binLen: 9},
+		{input: sockaddr.MustIPv4Addr("0.0.0.0/0"), text: "0.0.0.0/0", binLen: 9},
+		{input: sockaddr.IPv4Addr{Address: 0x0a000000, Mask: 0xffffff00, Port: 80}, text: "10.0.0.0/24:80", binLen: 9},
+		{input: sockaddr.MustIPv6Addr("::1"), text: "::1", binLen: 21},
+		{input: sockaddr.MustIPv6Addr("2001:db8::/32"), text: "2001:db8::/32", binLen: 21},
+		{input: sockaddr.MustIPv6Addr("[2001:db8::1]:443"), text: "[2001:db8::1]:443", binLen: 21},
+		{input: sockaddr.MustIPv6Addr("fe80::1%eth0/64"), text: "fe80::1%eth0/64", binLen: 25},
+		{input: withIPv6Port(sockaddr.MustIPv6Addr("2001:db8::/64"), 443), text: "[2001:db8::/64]:443", binLen: 21},
+		{input: withIPv6Port(sockaddr.MustIPv6Addr("fe80::1%eth0/64"), 8080), text: "[fe80::1%eth0/64]:8080", binLen: 25},
+		{input: sockaddr.MustUnixSock("/tmp/foo.sock"), text: "/tmp/foo.sock", binLen: 15},
+		{input: sockaddr.MustUnixSock("@abstract"), text: "@abstract", binLen: 11},
+		{input: sockaddr.MustUnixSock(""), text: "", binLen: 2},
+		{input: sockaddr.MustUnixSock("sock"), text: "sock", binLen: 6},
+		{input: sockaddr.MustVSock("vsock://3:1024"), text: "vsock://3:1024", binLen: 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input.String(), func(t *testing.T) {
+			text, err := test.input.MarshalText()
+			if err != nil {
+				t.Fatalf("unable to marshal text: %v", err)
+			}
+			if string(text) != test.text {
+				t.Errorf("expected text %+q, received %+q", test.text, text)
+			}
+
+			bin, err := test.input.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unable to marshal binary: %v", err)
+			}
+			if len(bin) != test.binLen {
+				t.Errorf("expected a %d byte encoding, received %d bytes", test.binLen, len(bin))
+			}
+
+			// Decode into a new value of the same concrete type.
+			for name, unmarshal := range map[string]func(encoding.TextUnmarshaler, encoding.BinaryUnmarshaler) error{
+				"text":   func(u encoding.TextUnmarshaler, _ encoding.BinaryUnmarshaler) error { return u.UnmarshalText(text) },
+				"binary": func(_ encoding.TextUnmarshaler, u encoding.BinaryUnmarshaler) error { return u.UnmarshalBinary(bin) },
+			} {
+				out := reflect.New(reflect.TypeOf(test.input))
+				if err := unmarshal(out.Interface().(encoding.TextUnmarshaler), out.Interface().(encoding.BinaryUnmarshaler)); err != nil {
+					t.Fatalf("unable to unmarshal %s: %v", name, err)
+				}
+				if sa := out.Elem().Interface().(sockaddr.SockAddr); !reflect.DeepEqual(sa, test.input) {
+					t.Errorf("expected %s round-trip of %#v, received %#v", name, test.input, sa)
+				}
+			}
+		})
+	}
+}
+
+func withIPv6Port(ipv6 sockaddr.IPv6Addr, port sockaddr.IPPort) sockaddr.IPv6Addr {
+	ipv6.Port = port
+	return ipv6
+}
+
+func TestSockAddrs_MarshalJSON(t *testing.T) {
+	in := sockaddr.SockAddrs{
+		sockaddr.MustIPv4Addr("192.168.1.2"),
+		sockaddr.IPv4Addr{Address: 0x0a000000, Mask: 0xffffff00, Port: 80},
+		sockaddr.MustIPv6Addr("2001:db8::/32"),
+		withIPv6Port(sockaddr.MustIPv6Addr("2001:db8::/64"), 443),
+		sockaddr.MustUnixSock("/tmp/foo.sock"),
+		sockaddr.MustUnixSock("sock"),
+		sockaddr.MustUnixSock("10.0.0.1"),
+		sockaddr.MustUnixSock("@abstract"),
+		sockaddr.MustUnixSock(""),
+		sockaddr.MustVSock("vsock://3:1024"),
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	expected := `["192.168.1.2","10.0.0.0/24:80","2001:db8::/32","[2001:db8::/64]:443","unix:/tmp/foo.sock","unix:sock","unix:10.0.0.1","unix:@abstract","unix:","vsock://3:1024"]`
+	if string(b) != expected {
+		t.Errorf("expected %s, received %s", expected, b)
+	}
+
+	var out sockaddr.SockAddrs
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("unable to unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("expected %#v, received %#v", in, out)
+	}
+	for i := range in {
+		if !in[i].Equal(out[i]) {
+			t.Errorf("%s did not round-trip, received %s", in[i], out[i])
+		}
+	}
+
+	// Absolute paths are accepted without the "unix:" prefix.
+	if err := json.Unmarshal([]byte(`["/tmp/foo.sock"]`), &out); err != nil || len(out) != 1 || out[0].Type() != sockaddr.TypeUnix {
+		t.Errorf("expected an unprefixed absolute path to decode as a UnixSock, received %v, %v", out, err)
+	}
+}
+
+func TestSockAddr_UnmarshalErrors(t *testing.T) {
+	ipv4Bin, _ := sockaddr.MustIPv4Addr("192.168.1.2").MarshalBinary()
+	ipv6Bin, _ := sockaddr.MustIPv6Addr("::1").MarshalBinary()
+
+	var ipv4 sockaddr.IPv4Addr
+	var ipv6 sockaddr.IPv6Addr
+	var vs sockaddr.VSock
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{"ipv4 text", func() error { return ipv4.UnmarshalText([]byte("::1")) }},
+		{"ipv6 text", func() error { return ipv6.UnmarshalText([]byte("foo")) }},
+		{"vsock text", func() error { return vs.UnmarshalText([]byte("3")) }},
+		{"ipv4 text port", func() error { return ipv4.UnmarshalText([]byte("10.0.0.0/24:http")) }},
+		{"ipv6 text port", func() error { return ipv6.UnmarshalText([]byte("[2001:db8::/64]:65536")) }},
+		{"empty", func() error { return ipv4.UnmarshalBinary(nil) }},
+		{"truncated", func() error { return ipv4.UnmarshalBinary(ipv4Bin[:5]) }},
+		{"wrong type", func() error { return ipv4.UnmarshalBinary(ipv6Bin) }},
+		{"wrong version", func() error { return ipv4.UnmarshalBinary(append([]byte{9}, ipv4Bin[1:]...)) }},
+		{"bad prefix", func() error {
+			b := append([]byte(nil), ipv4Bin...)
+			b[6] = 33
+			return ipv4.UnmarshalBinary(b)
+		}},
+		{"ipv6 truncated", func() error { return ipv6.UnmarshalBinary(ipv6Bin[:10]) }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.fn(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+
+	if _, err := (sockaddr.IPv4Addr{Address: 1, Mask: 0xff00ff00}).MarshalBinary(); err == nil {
+		t.Errorf("expected a non-contiguous netmask to fail")
+	}
+}
+
+func TestSockAddr_MarshalConfig(t *testing.T) {
+	type config struct {
+		Bind   sockaddr.IPv4Addr  `json:"bind"`
+		Public sockaddr.IPv6Addr  `json:"public"`
+		Socket sockaddr.UnixSock  `json:"socket"`
+		Guest  sockaddr.VSock     `json:"guest"`
+		Peers  sockaddr.SockAddrs `json:"peers"`
+	}
+
+	in := config{
+		Bind:   sockaddr.MustIPv4Addr("0.0.0.0:8500"),
+		Public: sockaddr.MustIPv6Addr("2001:db8::/64"),
+		Socket: sockaddr.MustUnixSock("/var/run/agent.sock"),
+		Guest:  sockaddr.MustVSock("vsock://3:1024"),
+		Peers: sockaddr.SockAddrs{
+			sockaddr.MustIPv4Addr("10.0.0.1:8300"),
+			sockaddr.MustIPv6Addr("[2001:db8::1]:8300"),
+			sockaddr.MustUnixSock("/tmp/peer.sock"),
+			sockaddr.MustVSock("vsock://4:8300"),
+		},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("unable to marshal: %v", err)
+		}
+
+		expected := `{"bind":"0.0.0.0:8500","public":"2001:db8::/64","socket":"/var/run/agent.sock","guest":"vsock://3:1024","peers":["10.0.0.1:8300","[2001:db8::1]:8300","unix:/tmp/peer.sock","vsock://4:8300"]}`
+		if string(b) != expected {
+			t.Errorf("expected %s, received %s", expected, b)
+		}
+
+		var out config
+		if err := json.Unmarshal(b, &out); err != nil {
+			t.Fatalf("unable to unmarshal: %v", err)
+		}
+		if !reflect.DeepEqual(in, out) {
+			t.Errorf("expected %#v, received %#v", in, out)
+		}
+	})
+
+	t.Run("gob", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+			t.Fatalf("unable to encode: %v", err)
+		}
+
+		var out config
+		if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+			t.Fatalf("unable to decode: %v", err)
+		}
+		if !reflect.DeepEqual(in, out) {
+			t.Errorf("expected %#v, received %#v", in, out)
+		}
+	})
+}
+
+func TestIfAddrs_MarshalJSON(t *testing.T) {
+	hwAddr, _ := net.ParseMAC("00:11:22:33:44:55")
+	in := sockaddr.IfAddrs{
+		{
+			SockAddr: sockaddr.MustIPv4Addr("192.168.1.10/24"),
+			Interface: net.Interface{
+				Index:        2,
+				MTU:          1500,
+				Name:         "eth0",
+				HardwareAddr: hwAddr,
+				Flags:        net.FlagUp | net.FlagBroadcast | net.FlagMulticast,
+			},
+			Kind:      sockaddr.InterfaceKindPhysical,
+			OperState: sockaddr.OperStateUp,
+			Carrier:   sockaddr.LinkCarrierUp,
+			Speed:     1000,
+			Duplex:    "full",
+		},
+		{
+			SockAddr: sockaddr.MustIPv6Addr("::1"),
+			Interface: net.Interface{
+				Index: 1,
+				MTU:   65536,
+				Name:  "lo",
+				Flags: net.FlagUp | net.FlagLoopback,
+			},
+			AddrFlags: sockaddr.AddrFlagPermanent,
+			AddrScope: sockaddr.AddrScopeHost,
+		},
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	expected := `[{"address":"192.168.1.10/24","name":"eth0","index":2,"mtu":1500,"hardware_addr":"00:11:22:33:44:55","flags":"up|broadcast|multicast","kind":"physical","operstate":"up","carrier":"up","speed":1000,"duplex":"full"},{"address":"::1","name":"lo","index":1,"mtu":65536,"flags":"up|loopback","address_flags":"permanent","scope":"host"}]`
+	if string(b) != expected {
+		t.Errorf("expected %s, received %s", expected, b)
+	}
+
+	var out sockaddr.IfAddrs
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("unable to unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("expected %#v, received %#v", in, out)
+	}
+
+	if err := json.Unmarshal([]byte(`[{"address":"::1","flags":"up|bogus"}]`), &out); err == nil {
+		t.Errorf("expected an unknown flag to fail")
+	}
+	if err := json.Unmarshal([]byte(`[{"address":"::1","address_flags":"bogus"}]`), &out); err == nil {
+		t.Errorf("expected an unknown address flag to fail")
+	}
+	if err := json.Unmarshal([]byte(`[{"address":"::1","scope":"bogus"}]`), &out); err == nil {
+		t.Errorf("expected an unknown scope to fail")
+	}
+	if err := json.Unmarshal([]byte(`[{"address":"::1","carrier":"bogus"}]`), &out); err == nil {
+		t.Errorf("expected an unknown carrier to fail")
+	}
+
+	unix := sockaddr.IfAddrs{{SockAddr: sockaddr.MustUnixSock("sock")}, {SockAddr: sockaddr.MustUnixSock("")}}
+	if b, err = json.Marshal(unix); err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("unable to unmarshal %s: %v", b, err)
+	}
+	if !reflect.DeepEqual(unix, out) {
+		t.Errorf("expected %#v, received %#v", unix, out)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// PrefixTable maps IPv4 and IPv6 networks to values of type V and supports
+// longest-prefix-match lookups.  It is implemented as a path-compressed binary
+// radix tree with a separate root for each address family, so lookups cost at
+// most one node visit per bit of the address.  IPv6 zones and ports are
+// ignored.  The zero value is an empty table ready to use.  A PrefixTable is
+// not safe for concurrent use if any goroutine calls Insert().
+type PrefixTable[V any] struct {
+	ipv4 *prefixNode[V]
+	ipv6 *prefixNode[V]
+	len  int
+}
+
+// prefixNode is a node in a PrefixTable.  Addresses are stored left-aligned in
+// a Uint128 so both address families share the same bit operations.  Nodes
+// without a value only exist to branch between two children.
+type prefixNode[V any] struct {
+	key      Uint128
+	bits     int
+	child    [2]*prefixNode[V]
+	network  IPAddr
+	value    V
+	hasValue bool
+}
+
+// NewPrefixTable returns an empty PrefixTable.
+func NewPrefixTable[V any]() *PrefixTable[V] {
+	return &PrefixTable[V]{}
+}
+
+// Get returns the value stored for exactly the network of ipAddr.
+func (t *PrefixTable[V]) Get(ipAddr IPAddr) (V, bool) {
+	key, maskBits, root, ok := t.prefixKey(ipAddr)
+	if ok {
+		for n := *root; n != nil && n.bits <= maskBits && commonPrefixLen(n.key, key) >= n.bits; n = n.child[keyBit(key, n.bits)] {
+			if n.bits == maskBits {
+				if n.hasValue {
+					return n.value, true
+				}
+				break
+			}
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Insert stores value for the network of ipAddr, replacing any existing value
+// for the same network.  Host bits of ipAddr are ignored (e.g. `10.1.2.3/8`
+// is stored as `10.0.0.0/8`).  Insert panics if ipAddr has a non-contiguous
+// netmask.
+func (t *PrefixTable[V]) Insert(ipAddr IPAddr, value V) {
+	key, maskBits, root, ok := t.prefixKey(ipAddr)
+	if !ok {
+		panic("sockaddr: unable to insert a network with a non-contiguous netmask into a PrefixTable: " + ipAddr.String())
+	}
+
+	leaf := &prefixNode[V]{
+		key:      key,
+		bits:     maskBits,
+		network:  ipAddr.Network(),
+		value:    value,
+		hasValue: true,
+	}
+
+	for n := root; ; {
+		cur := *n
+		if cur == nil {
+			*n = leaf
+			t.len++
+			return
+		}
+
+		common := min(commonPrefixLen(cur.key, key), cur.bits, maskBits)
+		switch {
+		case common == cur.bits && common == maskBits:
+			// Same network, replace the value in place.
+			if !cur.hasValue {
+				t.len++
+			}
+			cur.network, cur.value, cur.hasValue = leaf.network, value, true
+			return
+		case common == cur.bits:
+			// cur contains the new network, descend.
+			n = &cur.child[keyBit(key, cur.bits)]
+			continue
+		case common == maskBits:
+			// The new network contains cur.
+			leaf.child[keyBit(cur.key, maskBits)] = cur
+			*n = leaf
+		default:
+			// The networks diverge, branch at their common prefix.
+			branch := &prefixNode[V]{
+				key:  key.And(mask128(common)),
+				bits: common,
+			}
+			branch.child[keyBit(key, common)] = leaf
+			branch.child[keyBit(cur.key, common)] = cur
+			*n = branch
+		}
+		t.len++
+		return
+	}
+}
+
+// Len returns the number of networks stored in the table.
+func (t *PrefixTable[V]) Len() int {
+	return t.len
+}
+
+// Lookup returns the most specific network in the table that contains the
+// network of sa, along with its value.  SockAddrs that are not IP addresses
+// never match.
+func (t *PrefixTable[V]) Lookup(sa SockAddr) (IPAddr, V, bool) {
+	var match *prefixNode[V]
+	if ipAddr, ok := sa.(IPAddr); ok {
+		if key, maskBits, root, ok := t.prefixKey(ipAddr); ok {
+			for n := *root; n != nil && n.bits <= maskBits && commonPrefixLen(n.key, key) >= n.bits; n = n.child[keyBit(key, n.bits)] {
+				if n.hasValue {
+					match = n
+				}
+				if n.bits == maskBits {
+					break
+				}
+			}
+		}
+	}
+
+	if match == nil {
+		var zero V
+		return nil, zero, false
+	}
+	return match.network, match.value, true
+}
+
+// Matches returns every network in the table that contains the network of sa,
+// from the least to the most specific.
+func (t *PrefixTable[V]) Matches(sa SockAddr) iter.Seq2[IPAddr, V] {
+	return func(yield func(IPAddr, V) bool) {
+		ipAddr, ok := sa.(IPAddr)
+		if !ok {
+			return
+		}
+
+		key, maskBits, root, ok := t.prefixKey(ipAddr)
+		if !ok {
+			return
+		}
+
+		for n := *root; n != nil && n.bits <= maskBits && commonPrefixLen(n.key, key) >= n.bits; n = n.child[keyBit(key, n.bits)] {
+			if n.hasValue && !yield(n.network, n.value) {
+				return
+			}
+			if n.bits == maskBits {
+				return
+			}
+		}
+	}
+}
+
+// Walk calls fn for every network in the table until fn returns false.  IPv4
+// networks are visited before IPv6 networks.  Within a family networks are
+// visited in ascending address order, with a network visited before the
+// networks it contains.
+func (t *PrefixTable[V]) Walk(fn func(network IPAddr, value V) bool) {
+	if t.ipv4.walk(fn) {
+		t.ipv6.walk(fn)
+	}
+}
+
+// prefixKey returns the left-aligned network address and prefix length of
+// ipAddr, along with the root of its address family.  ok is false if ipAddr
+// has a non-contiguous netmask or is not an IPv4Addr or IPv6Addr.
+func (t *PrefixTable[V]) prefixKey(ipAddr IPAddr) (key Uint128, maskBits int, root **prefixNode[V], ok bool) {
+	switch v := ipAddr.(type) {
+	case IPv4Addr:
+		maskBits = bits.LeadingZeros32(^uint32(v.Mask))
+		if bits.OnesCount32(uint32(v.Mask)) != maskBits {
+			return Uint128{}, 0, nil, false
+		}
+		return Uint128{Lo: uint64(v.NetworkAddress())}.Lsh(96), maskBits, &t.ipv4, true
+	case IPv6Addr:
+		maskBits = Uint128(v.Mask).Not().LeadingZeros()
+		if mask128(maskBits) != Uint128(v.Mask) {
+			return Uint128{}, 0, nil, false
+		}
+		return Uint128(v.NetworkAddress()), maskBits, &t.ipv6, true
+	default:
+		return Uint128{}, 0, nil, false
+	}
+}
+
+// walk visits n and its children in order, returning false if fn stopped the
+// walk.
+func (n *prefixNode[V]) walk(fn func(IPAddr, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.hasValue && !fn(n.network, n.value) {
+		return false
+	}
+	return n.child[0].walk(fn) && n.child[1].walk(fn)
+}
+
+// commonPrefixLen returns the number of leading bits shared by a and b.
+func commonPrefixLen(a, b Uint128) int {
+	return a.Xor(b).LeadingZeros()
+}
+
+// keyBit returns the bit of key at position i, counting from the most
+// significant bit.
+func keyBit(key Uint128, i int) int {
+	return int(key.Rsh(uint(127-i)).Lo & 1)
+}
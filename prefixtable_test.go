@@ -0,0 +1,221 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestPrefixTable(t *testing.T) {
+	table := sockaddr.NewPrefixTable[string]()
+	for _, network := range []string{
+		"10.0.0.0/8",
+		"10.1.0.0/16",
+		"10.1.2.0/24",
+		"0.0.0.0/0",
+		"192.168.0.0/16",
+		"2001:db8::/32",
+		"2001:db8:1::/48",
+		"fe80::/10",
+	} {
+		table.Insert(sockaddr.MustIPAddr(network), network)
+	}
+
+	// Replacing a value does not change the length.
+	table.Insert(sockaddr.MustIPAddr("10.1.255.255/16"), "10.1.0.0/16")
+
+	if n := table.Len(); n != 8 {
+		t.Errorf("expected Len() 8, received %d", n)
+	}
+
+	tests := []struct {
+		input   sockaddr.SockAddr
+		lookup  string
+		matches []string
+	}{
+		{
+			input:   sockaddr.MustIPv4Addr("10.1.2.3"),
+			lookup:  "10.1.2.0/24",
+			matches: []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24"},
+		},
+		{
+			input:   sockaddr.MustIPv4Addr("10.1.3.4:80"),
+			lookup:  "10.1.0.0/16",
+			matches: []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16"},
+		},
+		{
+			input:   sockaddr.MustIPv4Addr("10.1.0.0/15"),
+			lookup:  "10.0.0.0/8",
+			matches: []string{"0.0.0.0/0", "10.0.0.0/8"},
+		},
+		{
+			input:   sockaddr.MustIPv4Addr("8.8.8.8"),
+			lookup:  "0.0.0.0/0",
+			matches: []string{"0.0.0.0/0"},
+		},
+		{
+			input:   sockaddr.MustIPv6Addr("2001:db8:1::1"),
+			lookup:  "2001:db8:1::/48",
+			matches: []string{"2001:db8::/32", "2001:db8:1::/48"},
+		},
+		{
+			input:   sockaddr.MustIPv6Addr("fe80::1%eth0"),
+			lookup:  "fe80::/10",
+			matches: []string{"fe80::/10"},
+		},
+		{
+			input: sockaddr.MustIPv6Addr("2001:db9::1"),
+		},
+		{
+			input: sockaddr.MustIPv6Addr("::ffff:10.1.2.3"),
+		},
+		{
+			input: sockaddr.MustUnixSock("/tmp/foo"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input.String(), func(t *testing.T) {
+			network, value, found := table.Lookup(test.input)
+			switch {
+			case test.lookup == "" && found:
+				t.Errorf("expected no match, received %s", network)
+			case test.lookup != "" && !found:
+				t.Errorf("expected a match of %s, received none", test.lookup)
+			case found && (network.String() != test.lookup || value != test.lookup):
+				t.Errorf("expected %s, received %s (%s)", test.lookup, network, value)
+			}
+
+			var matches []string
+			for network, value := range table.Matches(test.input) {
+				if network.String() != value {
+					t.Errorf("expected value %s for %s, received %s", network, network, value)
+				}
+				matches = append(matches, value)
+			}
+			if !reflect.DeepEqual(matches, test.matches) {
+				t.Errorf("expected Matches() %v, received %v", test.matches, matches)
+			}
+		})
+	}
+
+	if v, found := table.Get(sockaddr.MustIPv4Addr("10.1.0.0/16")); !found || v != "10.1.0.0/16" {
+		t.Errorf("expected Get() to find 10.1.0.0/16, received %q %t", v, found)
+	}
+	if _, found := table.Get(sockaddr.MustIPv4Addr("10.1.0.0/17")); found {
+		t.Errorf("expected Get() to not find 10.1.0.0/17")
+	}
+	if _, found := table.Get(sockaddr.MustIPv6Addr("2001:db8::/31")); found {
+		t.Errorf("expected Get() to not find the branch 2001:db8::/31")
+	}
+
+	var walked []string
+	table.Walk(func(network sockaddr.IPAddr, value string) bool {
+		walked = append(walked, value)
+		return true
+	})
+	expected := []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "192.168.0.0/16", "2001:db8::/32", "2001:db8:1::/48", "fe80::/10"}
+	if !reflect.DeepEqual(walked, expected) {
+		t.Errorf("expected Walk() %v, received %v", expected, walked)
+	}
+
+	walked = nil
+	table.Walk(func(network sockaddr.IPAddr, value string) bool {
+		walked = append(walked, value)
+		return len(walked) < 2
+	})
+	if len(walked) != 2 {
+		t.Errorf("expected Walk() to stop after 2 networks, received %v", walked)
+	}
+}
+
+// TestPrefixTable_Random compares Lookup() against a linear scan of random
+// IPv4 networks.
+func TestPrefixTable_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	var table sockaddr.PrefixTable[int]
+	var networks []sockaddr.IPv4Addr
+	for i := 0; i < 500; i++ {
+		network := sockaddr.MustIPv4Addr(fmt.Sprintf("10.%d.%d.0/%d", r.Intn(4), r.Intn(256), 8+r.Intn(17))).Network().(sockaddr.IPv4Addr)
+		if _, found := table.Get(network); !found {
+			networks = append(networks, network)
+		}
+		table.Insert(network, network.Maskbits())
+	}
+
+	if table.Len() != len(networks) {
+		t.Fatalf("expected Len() %d, received %d", len(networks), table.Len())
+	}
+
+	for i := 0; i < 2000; i++ {
+		addr := sockaddr.MustIPv4Addr(fmt.Sprintf("10.%d.%d.%d", r.Intn(5), r.Intn(256), r.Intn(256)))
+
+		best := -1
+		for _, network := range networks {
+			if network.Contains(addr) && network.Maskbits() > best {
+				best = network.Maskbits()
+			}
+		}
+
+		network, value, found := table.Lookup(addr)
+		switch {
+		case best == -1 && found:
+			t.Fatalf("%s: expected no match, received %s", addr, network)
+		case best != -1 && (!found || value != best || !network.Contains(addr)):
+			t.Fatalf("%s: expected a /%d match, received %v %d %t", addr, best, network, value, found)
+		}
+	}
+}
+
+func BenchmarkIsRFC(b *testing.B) {
+	ipv4 := sockaddr.MustIPv4Addr("192.168.1.1")
+	ipv6 := sockaddr.MustIPv6Addr("2001:db8::1")
+	b.ReportAllocs()
+	for b.Loop() {
+		sockaddr.IsRFC(1918, ipv4)
+		sockaddr.IsRFC(sockaddr.ForwardingBlacklist, ipv4)
+		sockaddr.IsRFC(3849, ipv6)
+	}
+}
+
+// BenchmarkIsRFC_Linear is the linear scan over KnownRFCs() that IsRFC used
+// before it was backed by a PrefixTable, kept as a baseline.
+func BenchmarkIsRFC_Linear(b *testing.B) {
+	ipv4 := sockaddr.MustIPv4Addr("192.168.1.1")
+	ipv6 := sockaddr.MustIPv6Addr("2001:db8::1")
+	isRFC := func(rfcNum uint, sa sockaddr.SockAddr) bool {
+		for _, rfcNet := range sockaddr.KnownRFCs()[rfcNum] {
+			if rfcNet.Contains(sa) {
+				return true
+			}
+		}
+		return false
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		isRFC(1918, ipv4)
+		isRFC(sockaddr.ForwardingBlacklist, ipv4)
+		isRFC(3849, ipv6)
+	}
+}
+
+func BenchmarkPrefixTable_Lookup(b *testing.B) {
+	var table sockaddr.PrefixTable[int]
+	for i := 0; i < 4096; i++ {
+		table.Insert(sockaddr.MustIPv4Addr(fmt.Sprintf("10.%d.%d.0/24", i/256, i%256)), i)
+	}
+	addr := sockaddr.MustIPv4Addr("10.15.255.1")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		table.Lookup(addr)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"net"
+	"net/netip"
+)
+
+// InterfaceProvider supplies the network interfaces, their addresses and the
+// name of the interface with the default route to GetAllInterfacesFrom() and
+// the other `*From()` functions.  OSInterfaceProvider queries the host and is
+// used by GetAllInterfaces() and friends.  StaticInterfaceProvider returns a
+// fixed set of interfaces, which makes the output of templates reproducible
+// regardless of the host's network configuration.
+type InterfaceProvider interface {
+	// Interfaces returns the list of network interfaces.
+	Interfaces() ([]net.Interface, error)
+
+	// Addrs returns the addresses assigned to intf, in the same form as
+	// net.Interface.Addrs().
+	Addrs(intf net.Interface) ([]net.Addr, error)
+
+	// DefaultInterfaceName returns the name of the interface that has a
+	// default route or an error and an empty string if a problem was
+	// encountered.
+	DefaultInterfaceName() (string, error)
+}
+
+// RouteProvider is an InterfaceProvider that also supplies the routing table,
+// which LookupRouteFrom() and GetInterfacesForDestinationFrom() search for the
+// route to a destination.
+type RouteProvider interface {
+	InterfaceProvider
+
+	// Routes returns the entries of the routing table.
+	Routes() ([]Route, error)
+}
+
+// AddrInfoProvider is an InterfaceProvider that also supplies the flags and
+// scope of each address, which GetAllInterfacesFrom() attaches to the IfAddrs
+// it returns.
+type AddrInfoProvider interface {
+	InterfaceProvider
+
+	// AddrInfo returns the AddrInfo of the addresses assigned to intf, keyed
+	// by address without a zone.  Addresses without an entry have no flags
+	// and an unknown scope.
+	AddrInfo(intf net.Interface) (map[netip.Addr]AddrInfo, error)
+}
+
+// allAddrInfoProvider is an AddrInfoProvider that can read the AddrInfo of
+// all interfaces at once, keyed by interface index, which
+// GetAllInterfacesFrom() prefers over calling AddrInfo() for every interface.
+type allAddrInfoProvider interface {
+	AddrInfoProvider
+
+	allAddrInfo() (map[int]map[netip.Addr]AddrInfo, error)
+}
+
+// LinkInfoProvider is an InterfaceProvider that also supplies the LinkInfo of
+// each interface, which GetAllInterfacesFrom() attaches to the IfAddrs it
+// returns.
+type LinkInfoProvider interface {
+	InterfaceProvider
+
+	// LinkInfo returns the LinkInfo of intf.
+	LinkInfo(intf net.Interface) (LinkInfo, error)
+}
+
+// allLinkInfoProvider is a LinkInfoProvider that can read the LinkInfo of a
+// list of interfaces at once, keyed by interface index, which
+// GetAllInterfacesFrom() prefers over calling LinkInfo() for every interface.
+type allLinkInfoProvider interface {
+	LinkInfoProvider
+
+	allLinkInfo(ifs []net.Interface) (map[int]LinkInfo, error)
+}
+
+// OSInterfaceProvider is an InterfaceProvider backed by the host's network
+// stack and route table.
+type OSInterfaceProvider struct {
+	// SysfsRoot is the directory sysfs is mounted on, which the LinkInfo of
+	// each interface is read from on Linux.  If empty, /sys is used.  Tests
+	// can point it at a fixture tree with a class/net directory.
+	SysfsRoot string
+}
+
+// Interfaces returns the host's network interfaces.
+func (OSInterfaceProvider) Interfaces() ([]net.Interface, error) {
+	return net.Interfaces()
+}
+
+// Addrs returns the addresses assigned to intf.
+func (OSInterfaceProvider) Addrs(intf net.Interface) ([]net.Addr, error) {
+	return intf.Addrs()
+}
+
+// DefaultInterfaceName returns the name of the interface with the default
+// route, as reported by NewRouteInfo().
+func (OSInterfaceProvider) DefaultInterfaceName() (string, error) {
+	ri, err := NewRouteInfo()
+	if err != nil {
+		return "", err
+	}
+
+	return ri.GetDefaultInterfaceName()
+}
+
+// AddrInfo returns the flags and scope of the addresses assigned to intf.  They
+// are only reported on Linux, where they are read over netlink or, failing
+// that, from /proc/net/if_inet6 for IPv6 addresses.  Other platforms return an
+// empty map.
+func (OSInterfaceProvider) AddrInfo(intf net.Interface) (map[netip.Addr]AddrInfo, error) {
+	return interfaceAddrInfo(intf)
+}
+
+// allAddrInfo returns the flags and scope of the addresses of all interfaces
+// from a single netlink dump.
+func (OSInterfaceProvider) allAddrInfo() (map[int]map[netip.Addr]AddrInfo, error) {
+	return allInterfaceAddrInfo()
+}
+
+// LinkInfo returns the kind and link state of intf.  On Linux they are read
+// from the class/net directory of SysfsRoot and, if SysfsRoot is empty, the
+// kind is read over netlink.  Other platforms only report the kind of loopback
+// interfaces.
+func (p OSInterfaceProvider) LinkInfo(intf net.Interface) (LinkInfo, error) {
+	return interfaceLinkInfo(p.SysfsRoot, intf)
+}
+
+// allLinkInfo returns the kind and link state of ifs, reading the kinds from
+// a single netlink dump.
+func (p OSInterfaceProvider) allLinkInfo(ifs []net.Interface) (map[int]LinkInfo, error) {
+	return allInterfaceLinkInfo(p.SysfsRoot, ifs)
+}
+
+// Routes returns the host's routing table, as reported by NewRouteInfo().
+func (OSInterfaceProvider) Routes() ([]Route, error) {
+	ri, err := NewRouteInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.Routes()
+}
+
+// StaticInterfaceProvider is an InterfaceProvider that returns a fixed set of
+// interfaces and addresses, typically a test fixture.  Interfaces are derived
+// from the Interface of each IfAddr, in order of first appearance, and are
+// matched by name.  IfAddrs that are not IP addresses are ignored.
+type StaticInterfaceProvider struct {
+	// IfAddrs is the list of addresses returned by the provider.
+	IfAddrs IfAddrs
+
+	// DefaultInterface is the name of the interface with the default route.
+	// If empty, DefaultInterfaceName() returns ErrNoInterface.
+	DefaultInterface string
+
+	// RouteTable is the routing table returned by Routes().
+	RouteTable []Route
+}
+
+// NewStaticInterfaceProvider returns a StaticInterfaceProvider for ifAddrs
+// with defaultIfName as the interface with the default route.
+func NewStaticInterfaceProvider(ifAddrs IfAddrs, defaultIfName string) *StaticInterfaceProvider {
+	return &StaticInterfaceProvider{
+		IfAddrs:          ifAddrs,
+		DefaultInterface: defaultIfName,
+	}
+}
+
+// Interfaces returns the unique interfaces of the provider's IfAddrs.
+func (p *StaticInterfaceProvider) Interfaces() ([]net.Interface, error) {
+	ifs := make([]net.Interface, 0, len(p.IfAddrs))
+	seen := make(map[string]bool, len(p.IfAddrs))
+	for _, ifAddr := range p.IfAddrs {
+		if seen[ifAddr.Name] {
+			continue
+		}
+		seen[ifAddr.Name] = true
+		ifs = append(ifs, ifAddr.Interface)
+	}
+
+	return ifs, nil
+}
+
+// Addrs returns the IP addresses of the provider's IfAddrs on the interface
+// named intf.Name.
+func (p *StaticInterfaceProvider) Addrs(intf net.Interface) ([]net.Addr, error) {
+	var addrs []net.Addr
+	for _, ifAddr := range p.IfAddrs {
+		if ifAddr.Name != intf.Name {
+			continue
+		}
+
+		// NetIPNet() would drop the host bits of the address.
+		if ipAddr, ok := ifAddr.SockAddr.(IPAddr); ok {
+			addrs = append(addrs, &net.IPNet{
+				IP:   *ipAddr.NetIP(),
+				Mask: *ipAddr.NetIPMask(),
+			})
+		}
+	}
+
+	return addrs, nil
+}
+
+// DefaultInterfaceName returns the provider's DefaultInterface.
+func (p *StaticInterfaceProvider) DefaultInterfaceName() (string, error) {
+	if p.DefaultInterface == "" {
+		return "", ErrNoInterface
+	}
+
+	return p.DefaultInterface, nil
+}
+
+// Routes returns the provider's RouteTable.
+func (p *StaticInterfaceProvider) Routes() ([]Route, error) {
+	return p.RouteTable, nil
+}
+
+// AddrInfo returns the AddrFlags and AddrScope of the provider's IfAddrs on the
+// interface named intf.Name.
+func (p *StaticInterfaceProvider) AddrInfo(intf net.Interface) (map[netip.Addr]AddrInfo, error) {
+	infos := make(map[netip.Addr]AddrInfo)
+	for _, ifAddr := range p.IfAddrs {
+		if ifAddr.Name != intf.Name {
+			continue
+		}
+
+		if ipAddr, ok := ifAddr.SockAddr.(IPAddr); ok {
+			infos[ipAddr.NetipAddr().WithZone("")] = AddrInfo{
+				Flags: ifAddr.AddrFlags,
+				Scope: ifAddr.AddrScope,
+			}
+		}
+	}
+
+	return infos, nil
+}
+
+// LinkInfo returns the kind and link state of the first of the provider's
+// IfAddrs on the interface named intf.Name.
+func (p *StaticInterfaceProvider) LinkInfo(intf net.Interface) (LinkInfo, error) {
+	for _, ifAddr := range p.IfAddrs {
+		if ifAddr.Name == intf.Name {
+			return LinkInfo{
+				Kind:      ifAddr.Kind,
+				OperState: ifAddr.OperState,
+				Carrier:   ifAddr.Carrier,
+				Speed:     ifAddr.Speed,
+				Duplex:    ifAddr.Duplex,
+			}, nil
+		}
+	}
+
+	return LinkInfo{}, nil
+}
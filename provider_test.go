@@ -0,0 +1,484 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+// providerFixture returns a StaticInterfaceProvider with a loopback interface,
+// a default interface with private and public addresses, and a second
+// interface with a public IPv4 address.
+func providerFixture(defaultIfName string) *sockaddr.StaticInterfaceProvider {
+	lo0 := net.Interface{
+		Index: 1,
+		MTU:   16384,
+		Name:  "lo0",
+		Flags: net.FlagUp | net.FlagLoopback | net.FlagMulticast,
+	}
+	en0 := net.Interface{
+		Index:        4,
+		MTU:          1500,
+		Name:         "en0",
+		HardwareAddr: net.HardwareAddr{0x60, 0x3e, 0x5f, 0x48, 0x75, 0xff},
+		Flags:        net.FlagUp | net.FlagBroadcast | net.FlagMulticast,
+	}
+	en1 := net.Interface{
+		Index:        5,
+		MTU:          9000,
+		Name:         "en1",
+		HardwareAddr: net.HardwareAddr{0x3a, 0x71, 0x85, 0xd8, 0xaa, 0xdc},
+		Flags:        net.FlagUp | net.FlagBroadcast | net.FlagMulticast,
+	}
+
+	return sockaddr.NewStaticInterfaceProvider(sockaddr.IfAddrs{
+		{SockAddr: sockaddr.MustIPv4Addr("127.0.0.1/8"), Interface: lo0},
+		{SockAddr: sockaddr.MustIPv6Addr("::1"), Interface: lo0},
+		{SockAddr: sockaddr.MustIPv6Addr("fe80::1/64"), Interface: lo0},
+		{SockAddr: sockaddr.MustIPv4Addr("17.1.2.3/16"), Interface: en1},
+		{SockAddr: sockaddr.MustIPv4Addr("192.168.0.102/24"), Interface: en0},
+		{SockAddr: sockaddr.MustIPv6Addr("fe80::2b:112f:ce21:7b6f/64"), Interface: en0},
+		{SockAddr: sockaddr.MustIPv6Addr("2406:7400:63:ef5:1415:8bc3:fa5e:2578/64"), Interface: en0},
+	}, defaultIfName)
+}
+
+func TestGetAllInterfacesFrom(t *testing.T) {
+	ifAddrs, err := sockaddr.GetAllInterfacesFrom(providerFixture("en0"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Addresses are grouped by interface in order of first appearance and
+	// link-local addresses are scoped to their interface.
+	want := []string{
+		"lo0 127.0.0.1/8",
+		"lo0 ::1",
+		"lo0 fe80::1%lo0/64",
+		"en1 17.1.2.3/16",
+		"en0 192.168.0.102/24",
+		"en0 fe80::2b:112f:ce21:7b6f%en0/64",
+		"en0 2406:7400:63:ef5:1415:8bc3:fa5e:2578/64",
+	}
+	if len(ifAddrs) != len(want) {
+		t.Fatalf("got %d IfAddrs, want %d: %v", len(ifAddrs), len(want), ifAddrs)
+	}
+	for i, ifAddr := range ifAddrs {
+		if got := ifAddr.Name + " " + ifAddr.SockAddr.String(); got != want[i] {
+			t.Errorf("IfAddr %d: got %q, want %q", i, got, want[i])
+		}
+	}
+
+	if ifAddrs[4].MTU != 1500 || ifAddrs[4].HardwareAddr.String() != "60:3e:5f:48:75:ff" {
+		t.Errorf("interface not preserved: %+v", ifAddrs[4].Interface)
+	}
+}
+
+func TestGetDefaultInterfacesFrom(t *testing.T) {
+	ifAddrs, err := sockaddr.GetDefaultInterfacesFrom(providerFixture("en1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ifAddrs) != 1 || ifAddrs[0].SockAddr.String() != "17.1.2.3/16" {
+		t.Errorf("unexpected default interfaces: %v", ifAddrs)
+	}
+
+	_, err = sockaddr.GetDefaultInterfacesFrom(providerFixture(""))
+	if !errors.Is(err, sockaddr.ErrNoInterface) {
+		t.Errorf("expected ErrNoInterface, got %v", err)
+	}
+}
+
+func TestProviderIPs(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultIfName string
+		fn            func(sockaddr.InterfaceProvider) (string, error)
+		want          string
+	}{
+		{
+			name:          "private ip",
+			defaultIfName: "en0",
+			fn:            sockaddr.GetPrivateIPFrom,
+			want:          "192.168.0.102",
+		},
+		{
+			name:          "private ips",
+			defaultIfName: "en0",
+			fn:            sockaddr.GetPrivateIPsFrom,
+			want:          "192.168.0.102",
+		},
+		{
+			name:          "public ip default en0",
+			defaultIfName: "en0",
+			fn:            sockaddr.GetPublicIPFrom,
+			want:          "2406:7400:63:ef5:1415:8bc3:fa5e:2578",
+		},
+		{
+			name:          "public ip default en1",
+			defaultIfName: "en1",
+			fn:            sockaddr.GetPublicIPFrom,
+			want:          "17.1.2.3",
+		},
+		{
+			name:          "public ips",
+			defaultIfName: "en0",
+			fn:            sockaddr.GetPublicIPsFrom,
+			want:          "17.1.2.3 2406:7400:63:ef5:1415:8bc3:fa5e:2578",
+		},
+		{
+			name:          "interface ip",
+			defaultIfName: "en0",
+			fn: func(p sockaddr.InterfaceProvider) (string, error) {
+				return sockaddr.GetInterfaceIPFrom(p, "^en0$")
+			},
+			want: "192.168.0.102",
+		},
+		{
+			name:          "interface ips",
+			defaultIfName: "en0",
+			fn: func(p sockaddr.InterfaceProvider) (string, error) {
+				return sockaddr.GetInterfaceIPsFrom(p, "^en0$")
+			},
+			want: "192.168.0.102 fe80::2b:112f:ce21:7b6f 2406:7400:63:ef5:1415:8bc3:fa5e:2578",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.fn(providerFixture(test.defaultIfName))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSortIfByFrom(t *testing.T) {
+	p := providerFixture("en1")
+	ifAddrs, err := sockaddr.GetAllInterfacesFrom(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sorted, err := sockaddr.SortIfByFrom(p, "default", ifAddrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sorted[0].Name != "en1" {
+		t.Errorf("default interface not sorted first: %v", sorted)
+	}
+
+	sorted, err = sockaddr.SortIfByFrom(p, "-default", ifAddrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sorted[len(sorted)-1].Name != "en1" {
+		t.Errorf("default interface not sorted last: %v", sorted)
+	}
+}
+
+// routeFixture returns providerFixture("en0") with a routing table that
+// reaches 10.0.0.0/8 through en1 and everything else through en0.
+func routeFixture() *sockaddr.StaticInterfaceProvider {
+	p := providerFixture("en0")
+	p.RouteTable = []sockaddr.Route{
+		{Destination: sockaddr.MustIPv4Addr("127.0.0.0/8"), Interface: "lo0", Family: sockaddr.TypeIPv4, Table: 255},
+		{Destination: sockaddr.MustIPv4Addr("0.0.0.0/0"), Gateway: sockaddr.MustIPv4Addr("192.168.0.1"), Interface: "en0", Metric: 100, Family: sockaddr.TypeIPv4, Table: 254},
+		{Destination: sockaddr.MustIPv4Addr("192.168.0.0/24"), Interface: "en0", Source: sockaddr.MustIPv4Addr("192.168.0.102"), Family: sockaddr.TypeIPv4, Table: 254},
+		{Destination: sockaddr.MustIPv4Addr("17.1.0.0/16"), Interface: "en1", Family: sockaddr.TypeIPv4, Table: 254},
+		{Destination: sockaddr.MustIPv4Addr("10.0.0.0/8"), Gateway: sockaddr.MustIPv4Addr("192.168.0.254"), Interface: "en0", Metric: 200, Family: sockaddr.TypeIPv4, Table: 254},
+		{Destination: sockaddr.MustIPv4Addr("10.0.0.0/8"), Gateway: sockaddr.MustIPv4Addr("17.1.0.1"), Interface: "en1", Metric: 50, Family: sockaddr.TypeIPv4, Table: 254},
+		{Destination: sockaddr.MustIPv4Addr("10.9.0.0/16"), Gateway: sockaddr.MustIPv4Addr("17.1.0.9"), Interface: "en1", Family: sockaddr.TypeIPv4, Table: 100},
+		{Destination: sockaddr.MustIPv6Addr("::1"), Interface: "lo0", Family: sockaddr.TypeIPv6, Table: 255},
+		{Destination: sockaddr.MustIPv6Addr("::/0"), Gateway: sockaddr.MustIPv6Addr("fe80::1%en0"), Interface: "en0", Metric: 1024, Family: sockaddr.TypeIPv6, Table: 254},
+		{Destination: sockaddr.MustIPv6Addr("fe80::/64"), Interface: "en0", Metric: 256, Family: sockaddr.TypeIPv6, Table: 254},
+		{Destination: sockaddr.MustIPv6Addr("fe80::/64"), Interface: "lo0", Metric: 256, Family: sockaddr.TypeIPv6, Table: 254},
+	}
+	return p
+}
+
+func TestLookupRouteFrom(t *testing.T) {
+	tests := []struct {
+		name  string
+		dst   string
+		route string
+	}{
+		{
+			name:  "default route",
+			dst:   "8.8.8.8",
+			route: "default via 192.168.0.1 dev en0 src 192.168.0.102 metric 100 table 254",
+		},
+		{
+			name:  "directly connected",
+			dst:   "17.1.9.9",
+			route: "17.1.0.0/16 dev en1 src 17.1.2.3 table 254",
+		},
+		{
+			name:  "source hint",
+			dst:   "192.168.0.7",
+			route: "192.168.0.0/24 dev en0 src 192.168.0.102 table 254",
+		},
+		{
+			name:  "lowest metric",
+			dst:   "10.1.2.3",
+			route: "10.0.0.0/8 via 17.1.0.1 dev en1 src 17.1.2.3 metric 50 table 254",
+		},
+		{
+			name:  "custom table ignored",
+			dst:   "10.9.1.1",
+			route: "10.0.0.0/8 via 17.1.0.1 dev en1 src 17.1.2.3 metric 50 table 254",
+		},
+		{
+			name:  "local table",
+			dst:   "127.0.0.1",
+			route: "127.0.0.0/8 dev lo0 src 127.0.0.1 table 255",
+		},
+		{
+			name:  "global ipv6 source",
+			dst:   "2001:db8::1",
+			route: "default via fe80::1%en0 dev en0 src 2406:7400:63:ef5:1415:8bc3:fa5e:2578 metric 1024 table 254",
+		},
+		{
+			name:  "link-local ipv6 source",
+			dst:   "fe80::99%en0",
+			route: "fe80::/64 dev en0 src fe80::2b:112f:ce21:7b6f%en0 metric 256 table 254",
+		},
+		{
+			name:  "zone selects interface",
+			dst:   "fe80::99%lo0",
+			route: "fe80::/64 dev lo0 src fe80::1%lo0 metric 256 table 254",
+		},
+	}
+
+	p := routeFixture()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			route, err := sockaddr.LookupRouteFrom(p, sockaddr.MustIPAddr(test.dst))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := route.String(); got != test.route {
+				t.Errorf("got %q, want %q", got, test.route)
+			}
+		})
+	}
+
+	// Without an IPv4 default route, there is no route to a public address.
+	p.RouteTable = p.RouteTable[2:]
+	if route, err := sockaddr.LookupRouteFrom(p, sockaddr.MustIPAddr("8.8.8.8")); err == nil {
+		t.Errorf("expected an error, got %v", route)
+	}
+}
+
+func TestGetInterfacesForDestinationFrom(t *testing.T) {
+	tests := []struct {
+		dst  string
+		want string
+	}{
+		{
+			dst:  "8.8.8.8",
+			want: "en0 192.168.0.102/24",
+		},
+		{
+			dst:  "10.1.2.3",
+			want: "en1 17.1.2.3/16",
+		},
+		{
+			dst:  "2001:db8::1",
+			want: "en0 2406:7400:63:ef5:1415:8bc3:fa5e:2578/64",
+		},
+	}
+
+	p := routeFixture()
+	for _, test := range tests {
+		t.Run(test.dst, func(t *testing.T) {
+			ifAddrs, err := sockaddr.GetInterfacesForDestinationFrom(p, test.dst)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(ifAddrs) != 1 {
+				t.Fatalf("got %d IfAddrs, want 1: %v", len(ifAddrs), ifAddrs)
+			}
+			if got := ifAddrs[0].Name + " " + ifAddrs[0].SockAddr.String(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	if _, err := sockaddr.GetInterfacesForDestinationFrom(p, "not an address"); err == nil {
+		t.Errorf("expected an error for an invalid destination")
+	}
+}
+
+func TestLookupRouteFrom_LocalRoutes(t *testing.T) {
+	// Like /proc/net/route, the IPv4 routes lack the local table.
+	p := routeFixture()
+	p.RouteTable = p.RouteTable[1:]
+
+	tests := []struct {
+		dst   string
+		route string
+	}{
+		{
+			dst:   "127.0.0.2",
+			route: "127.0.0.0/8 dev lo0 src 127.0.0.1 table 255",
+		},
+		{
+			dst:   "192.168.0.102",
+			route: "192.168.0.102 dev en0 src 192.168.0.102 table 255",
+		},
+		{
+			dst:   "17.1.2.3",
+			route: "17.1.2.3 dev en1 src 17.1.2.3 table 255",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dst, func(t *testing.T) {
+			route, err := sockaddr.LookupRouteFrom(p, sockaddr.MustIPAddr(test.dst))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := route.String(); got != test.route {
+				t.Errorf("got %q, want %q", got, test.route)
+			}
+		})
+	}
+}
+
+// dualStackFixture returns routeFixture() with the IPv6 default route moved to
+// en1 and a second, higher metric IPv4 default route through en1.
+func dualStackFixture() *sockaddr.StaticInterfaceProvider {
+	p := routeFixture()
+	p.IfAddrs = append(p.IfAddrs, sockaddr.IfAddr{
+		SockAddr:  sockaddr.MustIPv6Addr("2001:db8::5/64"),
+		Interface: p.IfAddrs[3].Interface,
+	})
+	for i, route := range p.RouteTable {
+		if route.IsDefault() && route.Family == sockaddr.TypeIPv6 {
+			p.RouteTable[i].Interface = "en1"
+			p.RouteTable[i].Gateway = sockaddr.MustIPv6Addr("fe80::9%en1")
+		}
+	}
+	p.RouteTable = append(p.RouteTable,
+		sockaddr.Route{Destination: sockaddr.MustIPv4Addr("0.0.0.0/0"), Gateway: sockaddr.MustIPv4Addr("17.1.0.1"), Interface: "en1", Metric: 600, Family: sockaddr.TypeIPv4, Table: 254},
+		sockaddr.Route{Destination: sockaddr.MustIPv4Addr("0.0.0.0/0"), Gateway: sockaddr.MustIPv4Addr("17.1.0.9"), Interface: "en1", Metric: 10, Family: sockaddr.TypeIPv4, Table: 100},
+	)
+	return p
+}
+
+func TestGetDefaultInterfaceNamesFrom(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      sockaddr.InterfaceProvider
+		family sockaddr.SockAddrType
+		want   string
+	}{
+		{
+			name:   "ipv4 ordered by metric",
+			p:      dualStackFixture(),
+			family: sockaddr.TypeIPv4,
+			want:   "en0 en1",
+		},
+		{
+			name:   "ipv6",
+			p:      dualStackFixture(),
+			family: sockaddr.TypeIPv6,
+			want:   "en1",
+		},
+		{
+			name:   "both families",
+			p:      dualStackFixture(),
+			family: sockaddr.TypeIP,
+			want:   "en0 en1",
+		},
+		{
+			name:   "no routing table",
+			p:      providerFixture("en1"),
+			family: sockaddr.TypeIPv6,
+			want:   "en1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ifNames, err := sockaddr.GetDefaultInterfaceNamesFrom(test.p, test.family)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(ifNames, " "); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	if _, err := sockaddr.GetDefaultInterfaceNamesFrom(providerFixture(""), sockaddr.TypeIPv4); !errors.Is(err, sockaddr.ErrNoInterface) {
+		t.Errorf("expected ErrNoInterface, got %v", err)
+	}
+}
+
+func TestGetDefaultInterfacesFrom_DualStack(t *testing.T) {
+	p := dualStackFixture()
+	p.RouteTable = p.RouteTable[:len(p.RouteTable)-2]
+
+	tests := []struct {
+		name string
+		fn   func(sockaddr.InterfaceProvider) (sockaddr.IfAddrs, error)
+		want string
+	}{
+		{
+			name: "default interfaces",
+			fn:   sockaddr.GetDefaultInterfacesFrom,
+			want: "en1 2001:db8::5/64, en0 192.168.0.102/24",
+		},
+		{
+			name: "default ipv4 interfaces",
+			fn:   sockaddr.GetDefaultIPv4InterfacesFrom,
+			want: "en0 192.168.0.102/24",
+		},
+		{
+			name: "default ipv6 interfaces",
+			fn:   sockaddr.GetDefaultIPv6InterfacesFrom,
+			want: "en1 2001:db8::5/64",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ifAddrs, err := test.fn(p)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make([]string, 0, len(ifAddrs))
+			for _, ifAddr := range ifAddrs {
+				got = append(got, ifAddr.Name+" "+ifAddr.SockAddr.String())
+			}
+			if strings.Join(got, ", ") != test.want {
+				t.Errorf("got %q, want %q", strings.Join(got, ", "), test.want)
+			}
+		})
+	}
+
+	ifAddrs, err := sockaddr.GetAllInterfacesFrom(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sorted, err := sockaddr.SortIfByFrom(p, "default", ifAddrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, want := range []string{"2001:db8::5/64", "192.168.0.102/24"} {
+		if got := sorted[i].SockAddr.String(); got != want {
+			t.Errorf("sorted[%d] = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -3,26 +3,39 @@
 
 package sockaddr
 
+import (
+	"sync"
+)
+
 // ForwardingBlacklist is a faux RFC that includes a list of non-forwardable IP
 // blocks.
 const ForwardingBlacklist = 4294967295
 const ForwardingBlacklistRFC = "4294967295"
 
+// rfcTables returns a cached PrefixTable of the networks of each known RFC,
+// built once from KnownRFCs().
+var rfcTables = sync.OnceValue(func() map[uint]*PrefixTable[struct{}] {
+	tables := make(map[uint]*PrefixTable[struct{}])
+	for rfcNum, sas := range KnownRFCs() {
+		table := NewPrefixTable[struct{}]()
+		for _, sa := range sas {
+			if ipAddr, ok := sa.(IPAddr); ok {
+				table.Insert(ipAddr, struct{}{})
+			}
+		}
+		tables[rfcNum] = table
+	}
+	return tables
+})
+
 // IsRFC tests to see if an SockAddr matches the specified RFC
 func IsRFC(rfcNum uint, sa SockAddr) bool {
-	rfcNetMap := KnownRFCs()
-	rfcNets, ok := rfcNetMap[rfcNum]
+	table, ok := rfcTables()[rfcNum]
 	if !ok {
 		return false
 	}
 
-	var contained bool
-	for _, rfcNet := range rfcNets {
-		if rfcNet.Contains(sa) {
-			contained = true
-			break
-		}
-	}
+	_, _, contained := table.Lookup(sa)
 	return contained
 }
 
@@ -39,8 +52,8 @@ func IsRFC(rfcNum uint, sa SockAddr) bool {
 // * https://www.iana.org/assignments/ipv6-unicast-address-assignments/ipv6-unicast-address-assignments.xhtml
 // * https://www.iana.org/assignments/ipv6-address-space/ipv6-address-space.xhtml
 func KnownRFCs() map[uint]SockAddrs {
-	// NOTE(sean@): Multiple SockAddrs per RFC lend themselves well to a
-	// RADIX tree, but `ENOTIME`.  Patches welcome.
+	// NOTE: IsRFC() and friends look up RFC membership in a PrefixTable built
+	// once from this map, see rfcTables.
 	return map[uint]SockAddrs{
 		919: {
 			// [RFC919] Broadcasting Internet Datagrams
@@ -0,0 +1,983 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Linux routing table IDs from include/uapi/linux/rtnetlink.h.
+const (
+	rtTableDefault = 253
+	rtTableMain    = 254
+	rtTableLocal   = 255
+)
+
+// rtfLocal marks routes from the local table in /proc/net/ipv6_route.
+const rtfLocal = 0x80000000
+
+// Route is an entry in a host's routing table.
+type Route struct {
+	// Destination is the network reached through the route.  The default
+	// route has a prefix length of zero.
+	Destination IPAddr
+
+	// Gateway is the next hop of the route, or nil if Destination is
+	// directly connected to Interface.
+	Gateway IPAddr
+
+	// Interface is the name of the outgoing interface.
+	Interface string
+
+	// Metric is the cost of the route, lower is preferred.  Metric is zero
+	// on platforms that do not report one.
+	Metric uint32
+
+	// Source is the preferred source address for traffic sent through the
+	// route, or nil if the platform does not provide a hint.
+	Source IPAddr
+
+	// Family is the address family of the route, either TypeIPv4 or
+	// TypeIPv6.
+	Family SockAddrType
+
+	// Table is the ID of the routing table holding the route, or zero on
+	// platforms that only report a single table.
+	Table uint32
+}
+
+// IsDefault returns true if r is a default route.
+func (r Route) IsDefault() bool {
+	return r.Destination != nil && r.Destination.Maskbits() == 0
+}
+
+// String returns a string representation of the route similar to the output
+// of ip(8), e.g. `default via 192.0.2.1 dev eth0 metric 100`.
+func (r Route) String() string {
+	var b strings.Builder
+	switch {
+	case r.Destination == nil:
+		b.WriteString("<nil>")
+	case r.IsDefault():
+		b.WriteString("default")
+	default:
+		b.WriteString(r.Destination.String())
+	}
+	if r.Gateway != nil {
+		fmt.Fprintf(&b, " via %s", r.Gateway)
+	}
+	if r.Interface != "" {
+		fmt.Fprintf(&b, " dev %s", r.Interface)
+	}
+	if r.Source != nil {
+		fmt.Fprintf(&b, " src %s", r.Source)
+	}
+	if r.Metric != 0 {
+		fmt.Fprintf(&b, " metric %d", r.Metric)
+	}
+	if r.Table != 0 {
+		fmt.Fprintf(&b, " table %d", r.Table)
+	}
+	return b.String()
+}
+
+// defaultRouteDestination returns the destination of the default route for
+// the given address family.
+func defaultRouteDestination(family SockAddrType) (IPAddr, error) {
+	switch family {
+	case TypeIPv4:
+		return NewIPAddrFromNetipPrefix(netip.PrefixFrom(netip.IPv4Unspecified(), 0))
+	case TypeIPv6:
+		return NewIPAddrFromNetipPrefix(netip.PrefixFrom(netip.IPv6Unspecified(), 0))
+	default:
+		return nil, fmt.Errorf("unsupported address family %s", family)
+	}
+}
+
+// parseRouteAddr parses an address of the given family.  Addresses without a
+// prefix length are host addresses.
+func parseRouteAddr(family SockAddrType, s string) (IPAddr, error) {
+	switch family {
+	case TypeIPv4:
+		return NewIPv4Addr(s)
+	case TypeIPv6:
+		return NewIPv6Addr(s)
+	default:
+		return nil, fmt.Errorf("unsupported address family %s", family)
+	}
+}
+
+// parseRouteGateway parses a gateway of the given family.  Unspecified
+// addresses and gateways that are not IP addresses, such as the link-layer
+// gateways `link#4` or `a4:2b:b0:e8:5a:1c` printed by netstat(1), return nil.
+func parseRouteGateway(family SockAddrType, s string) IPAddr {
+	gw, err := parseRouteAddr(family, s)
+	if err != nil || gw.NetIP().IsUnspecified() {
+		return nil
+	}
+	return gw
+}
+
+// parseRoutesFromProcNetRoute parses the IPv4 routes in Linux's
+// /proc/net/route, which only lists the main routing table.  Routes that
+// reject traffic are omitted.
+func parseRoutesFromProcNetRoute(routeOut string) ([]Route, error) {
+	var routes []Route
+	for i, line := range strings.Split(routeOut, "\n") {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask MTU Window IRTT
+		fields := strings.Fields(line)
+		if i == 0 || len(fields) < 8 {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse flags %+q: %w", fields[3], err)
+		}
+		if flags&rtfUp == 0 || flags&rtfReject != 0 {
+			continue
+		}
+
+		dest, err := parseProcNetIPv4(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse destination %+q: %w", fields[1], err)
+		}
+		gw, err := parseProcNetIPv4(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse gateway %+q: %w", fields[2], err)
+		}
+		metric, err := strconv.ParseUint(fields[6], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse metric %+q: %w", fields[6], err)
+		}
+		mask, err := parseProcNetIPv4(fields[7])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse mask %+q: %w", fields[7], err)
+		}
+
+		maskLen, bits := net.IPMask(mask.AsSlice()).Size()
+		if bits == 0 {
+			return nil, fmt.Errorf("non-contiguous mask %s", mask)
+		}
+
+		route := Route{
+			Interface: fields[0],
+			Metric:    uint32(metric),
+			Family:    TypeIPv4,
+			Table:     rtTableMain,
+		}
+		if route.Destination, err = NewIPAddrFromNetipPrefix(netip.PrefixFrom(dest, maskLen)); err != nil {
+			return nil, err
+		}
+		if !gw.IsUnspecified() {
+			if route.Gateway, err = NewIPAddrFromNetipAddr(gw); err != nil {
+				return nil, err
+			}
+		}
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// parseProcNetIPv4 parses an IPv4 address from /proc/net/route, which the
+// kernel prints as a hex encoded integer in host byte order.
+func parseProcNetIPv4(s string) (netip.Addr, error) {
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	var b [4]byte
+	binary.NativeEndian.PutUint32(b[:], uint32(v))
+	return netip.AddrFrom4(b), nil
+}
+
+// parseRoutesFromProcNetIPv6Route parses the IPv6 routes in Linux's
+// /proc/net/ipv6_route.  Routes from the local table are reported in table
+// 255 and all others in the main table.  Routes that reject traffic are
+// omitted.
+func parseRoutesFromProcNetIPv6Route(routeOut string) ([]Route, error) {
+	var routes []Route
+	for _, line := range strings.Split(routeOut, "\n") {
+		// Destination DestPrefixLen Source SourcePrefixLen NextHop Metric
+		// RefCnt Use Flags Iface
+		fields := strings.Fields(line)
+		if len(fields) < 10 {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(fields[8], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse flags %+q: %w", fields[8], err)
+		}
+		if flags&rtfUp == 0 || flags&rtfReject != 0 {
+			continue
+		}
+
+		dest, err := parseProcNetIPv6(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse destination %+q: %w", fields[0], err)
+		}
+		prefixLen, err := strconv.ParseUint(fields[1], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse prefix length %+q: %w", fields[1], err)
+		}
+		gw, err := parseProcNetIPv6(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse next hop %+q: %w", fields[4], err)
+		}
+		metric, err := strconv.ParseUint(fields[5], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse metric %+q: %w", fields[5], err)
+		}
+
+		route := Route{
+			Interface: fields[9],
+			Metric:    uint32(metric),
+			Family:    TypeIPv6,
+			Table:     rtTableMain,
+		}
+		if flags&rtfLocal != 0 {
+			route.Table = rtTableLocal
+		}
+		if route.Destination, err = NewIPAddrFromNetipPrefix(netip.PrefixFrom(dest, int(prefixLen))); err != nil {
+			return nil, err
+		}
+		if !gw.IsUnspecified() {
+			if route.Gateway, err = NewIPAddrFromNetipAddr(gw); err != nil {
+				return nil, err
+			}
+		}
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// parseProcNetIPv6 parses an IPv6 address from /proc/net/ipv6_route, which the
+// kernel prints as 32 hex digits in network byte order.
+func parseProcNetIPv6(s string) (netip.Addr, error) {
+	var b [16]byte
+	if hex.DecodedLen(len(s)) != len(b) {
+		return netip.Addr{}, fmt.Errorf("expected %d hex digits", 2*len(b))
+	}
+	if _, err := hex.Decode(b[:], []byte(s)); err != nil {
+		return netip.Addr{}, err
+	}
+	return netip.AddrFrom16(b), nil
+}
+
+// parseRoutesFromIPCmd parses the output of `ip -4 route show table all` or
+// `ip -6 route show table all` on Linux.  Routes that reject traffic
+// (`unreachable`, `prohibit`, `blackhole` and `throw`) are omitted, and a
+// multipath route is returned once per next hop.
+func parseRoutesFromIPCmd(family SockAddrType, routeOut string) ([]Route, error) {
+	var routes []Route
+
+	// The next hops of a multipath route are indented below it, and each
+	// replaces or follows the route in routes.
+	var multipath *Route
+	var nexthops int
+	for _, line := range strings.Split(routeOut, "\n") {
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if line[0] == ' ' || line[0] == '\t' {
+			if multipath == nil || len(fields) == 0 || fields[0] != "nexthop" {
+				continue
+			}
+
+			nexthop := *multipath
+			nexthop.Gateway = nil
+			if err := parseIPCmdRouteFields(family, &nexthop, fields[1:]); err != nil {
+				return nil, err
+			}
+			if nexthops == 0 {
+				routes[len(routes)-1] = nexthop
+			} else {
+				routes = append(routes, nexthop)
+			}
+			nexthops++
+			continue
+		}
+		multipath, nexthops = nil, 0
+
+		switch fields[0] {
+		case "unicast", "local", "broadcast", "multicast", "anycast":
+			fields = fields[1:]
+		case "unreachable", "prohibit", "blackhole", "throw", "nat":
+			continue
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		route := Route{
+			Family: family,
+			Table:  rtTableMain,
+		}
+
+		var err error
+		if fields[0] == "default" {
+			route.Destination, err = defaultRouteDestination(family)
+		} else {
+			route.Destination, err = parseRouteAddr(family, fields[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse destination %+q: %w", fields[0], err)
+		}
+
+		if err := parseIPCmdRouteFields(family, &route, fields[1:]); err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+		multipath = &route
+	}
+
+	return routes, nil
+}
+
+// parseIPCmdRouteFields sets the gateway, interface, source, metric and table
+// of route from the fields that follow its destination, or the `nexthop`
+// keyword of a multipath next hop, in the output of `ip route`.
+func parseIPCmdRouteFields(family SockAddrType, route *Route, fields []string) error {
+	var err error
+	for i := 0; i+1 < len(fields); i++ {
+		switch fields[i] {
+		case "via":
+			// IPv4 routes with an IPv6 next hop are printed as
+			// `via inet6 fe80::1`.
+			gwFamily := family
+			switch fields[i+1] {
+			case "inet":
+				gwFamily = TypeIPv4
+				i++
+			case "inet6":
+				gwFamily = TypeIPv6
+				i++
+			}
+			if i+1 < len(fields) {
+				route.Gateway = parseRouteGateway(gwFamily, fields[i+1])
+			}
+		case "dev":
+			route.Interface = fields[i+1]
+		case "src":
+			if route.Source, err = parseRouteAddr(family, fields[i+1]); err != nil {
+				return fmt.Errorf("unable to parse source %+q: %w", fields[i+1], err)
+			}
+		case "metric":
+			metric, err := strconv.ParseUint(fields[i+1], 10, 32)
+			if err != nil {
+				return fmt.Errorf("unable to parse metric %+q: %w", fields[i+1], err)
+			}
+			route.Metric = uint32(metric)
+		case "table":
+			if route.Table, err = parseLinuxRouteTable(fields[i+1]); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+		i++
+	}
+
+	return nil
+}
+
+// parseLinuxRouteTable parses the name or ID of a Linux routing table.
+func parseLinuxRouteTable(s string) (uint32, error) {
+	switch s {
+	case "default":
+		return rtTableDefault, nil
+	case "main":
+		return rtTableMain, nil
+	case "local":
+		return rtTableLocal, nil
+	}
+
+	table, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse table %+q: %w", s, err)
+	}
+	return uint32(table), nil
+}
+
+// parseNetstatFamily returns the address family of a section header in the
+// output of netstat(1), e.g. `Internet6:` on the BSDs or `Route Tree for
+// Protocol Family 24 (Internet v6):` on AIX, and false if line is not a
+// section header.
+func parseNetstatFamily(line string) (SockAddrType, bool) {
+	switch {
+	case line == "Internet:", strings.HasSuffix(line, "(Internet):"),
+		line == "Routing Table: IPv4", line == "IRE Table: IPv4":
+		return TypeIPv4, true
+	case line == "Internet6:", strings.HasSuffix(line, "(Internet v6):"),
+		line == "Routing Table: IPv6", line == "IRE Table: IPv6":
+		return TypeIPv6, true
+	case strings.HasSuffix(line, ":") && !strings.Contains(line, " "),
+		strings.HasPrefix(line, "Route Tree for "):
+		return TypeUnknown, true
+	}
+
+	return TypeUnknown, false
+}
+
+// parseNetstatDestination parses a destination printed by netstat(1) on the
+// BSDs and AIX.  IPv4 networks may be abbreviated by omitting trailing zero
+// octets, in which case the prefix length is implied by the number of octets
+// when it is not given, e.g. `169.254` is `169.254.0.0/16` and `10.1/20` is
+// `10.1.0.0/20`.
+func parseNetstatDestination(family SockAddrType, s string) (IPAddr, error) {
+	if s == "default" {
+		return defaultRouteDestination(family)
+	}
+	if family != TypeIPv4 {
+		return parseRouteAddr(family, s)
+	}
+
+	addr, maskLen, hasMask := strings.Cut(s, "/")
+	octets := strings.Count(addr, ".") + 1
+	if octets < 4 {
+		addr += strings.Repeat(".0", 4-octets)
+		if !hasMask {
+			maskLen = strconv.Itoa(8 * octets)
+		}
+	} else if !hasMask {
+		maskLen = "32"
+	}
+
+	return NewIPv4Addr(addr + "/" + maskLen)
+}
+
+// parseRoutesFromNetstat parses the output of `netstat -rn` on the BSDs and
+// AIX.  Columns are located by the names in the header, which differ between
+// platforms (e.g. `Netif` on macOS and FreeBSD, `Iface` on OpenBSD and `If`
+// on AIX).  Sections for address families other than IPv4 and IPv6 are
+// skipped.  Interface-scoped routes, flagged `I` by macOS, are only used for
+// sockets bound to their interface and are skipped, except for those whose
+// destination has a zone that already names the interface.
+func parseRoutesFromNetstat(routeOut string) ([]Route, error) {
+	var routes []Route
+	family := TypeUnknown
+	destCol, gwCol, flagsCol, ifCol := -1, -1, -1, -1
+	for _, line := range strings.Split(routeOut, "\n") {
+		line = strings.TrimSpace(line)
+		if f, ok := parseNetstatFamily(line); ok {
+			family = f
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "Destination" {
+			destCol, gwCol, flagsCol, ifCol = -1, -1, -1, -1
+			for i, name := range fields {
+				switch name {
+				case "Destination":
+					destCol = i
+				case "Gateway":
+					gwCol = i
+				case "Flags":
+					flagsCol = i
+				case "Netif", "Iface", "Interface", "If":
+					ifCol = i
+				}
+			}
+			continue
+		}
+		if family == TypeUnknown || destCol < 0 || gwCol < 0 || ifCol < 0 || len(fields) <= ifCol {
+			continue
+		}
+
+		dest, err := parseNetstatDestination(family, fields[destCol])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse destination %+q: %w", fields[destCol], err)
+		}
+		if flagsCol >= 0 && strings.Contains(fields[flagsCol], "I") {
+			if ipv6, ok := dest.(IPv6Addr); !ok || ipv6.Zone == "" {
+				continue
+			}
+		}
+		routes = append(routes, Route{
+			Destination: dest,
+			Gateway:     parseRouteGateway(family, fields[gwCol]),
+			Interface:   fields[ifCol],
+			Family:      family,
+		})
+	}
+
+	return routes, nil
+}
+
+// parseRoutesFromSolarisNetstat parses the output of `netstat -rnv` on
+// Solaris and illumos.  Columns are fixed width, as given by the row of dashes
+// below each header, and may be empty (e.g. the `Device` of a route that is
+// resolved through its gateway) or overflow into the next column (e.g. long
+// interface names).
+func parseRoutesFromSolarisNetstat(routeOut string) ([]Route, error) {
+	type column struct {
+		name       string
+		start, end int
+	}
+
+	var routes []Route
+	var columns []column
+	var header string
+	family := TypeUnknown
+	for _, line := range strings.Split(routeOut, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if f, ok := parseNetstatFamily(trimmed); ok {
+			family, columns, header = f, nil, ""
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "Destination") {
+			header, columns = line, nil
+			continue
+		}
+		if strings.Trim(trimmed, "- ") == "" {
+			columns = columns[:0]
+			for start := 0; start < len(line); {
+				for start < len(line) && line[start] == ' ' {
+					start++
+				}
+				end := start
+				for end < len(line) && line[end] == '-' {
+					end++
+				}
+				if end == start {
+					break
+				}
+
+				col := column{start: start, end: end}
+				if start < len(header) {
+					col.name = strings.TrimSpace(header[start:min(end, len(header))])
+				}
+				columns = append(columns, col)
+				start = end
+			}
+			continue
+		}
+		if family == TypeUnknown || len(columns) == 0 {
+			continue
+		}
+
+		// Assign each value to the first unfilled column that it starts
+		// before the end of.
+		values := make(map[string]string, len(columns))
+		col := 0
+		for start := 0; start < len(line) && col < len(columns); {
+			for start < len(line) && line[start] == ' ' {
+				start++
+			}
+			end := start
+			for end < len(line) && line[end] != ' ' {
+				end++
+			}
+			if end == start {
+				break
+			}
+			for col < len(columns)-1 && start >= columns[col].end {
+				col++
+			}
+			values[columns[col].name] = line[start:end]
+			col++
+			start = end
+		}
+
+		destStr := values["Destination"]
+		if destStr == "" {
+			destStr = values["Destination/Mask"]
+		}
+
+		var dest IPAddr
+		var err error
+		switch mask := values["Mask"]; {
+		case destStr == "default":
+			dest, err = defaultRouteDestination(family)
+		case mask != "" && family == TypeIPv4:
+			maskAddr, maskErr := netip.ParseAddr(mask)
+			if maskErr != nil || !maskAddr.Is4() {
+				return nil, fmt.Errorf("invalid mask %+q", mask)
+			}
+			maskLen, bits := net.IPMask(maskAddr.AsSlice()).Size()
+			if bits == 0 {
+				return nil, fmt.Errorf("non-contiguous mask %+q", mask)
+			}
+			dest, err = NewIPv4Addr(fmt.Sprintf("%s/%d", destStr, maskLen))
+		default:
+			dest, err = parseRouteAddr(family, destStr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse destination %+q: %w", destStr, err)
+		}
+
+		ifName := values["Device"]
+		if ifName == "" {
+			ifName = values["If"]
+		}
+		if ifName == "" {
+			ifName = values["Interface"]
+		}
+
+		routes = append(routes, Route{
+			Destination: dest,
+			Gateway:     parseRouteGateway(family, values["Gateway"]),
+			Interface:   ifName,
+			Family:      family,
+		})
+	}
+
+	return routes, nil
+}
+
+// parseRoutesFromOnetstat parses the output of `onetstat -r` on z/OS.  IPv4
+// routes are listed one per row while each IPv6 route spans several
+// `Key: value` lines starting with `DestIP:`.
+func parseRoutesFromOnetstat(routeOut string) ([]Route, error) {
+	var routes []Route
+	var route *Route
+	family := TypeUnknown
+
+	// parseDest parses a destination, which is `Default` for the default
+	// route.
+	parseDest := func(s string) (IPAddr, error) {
+		if s == "Default" {
+			return defaultRouteDestination(family)
+		}
+		dest, err := parseRouteAddr(family, s)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse destination %+q: %w", s, err)
+		}
+		return dest, nil
+	}
+
+	for _, line := range strings.Split(routeOut, "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case line == "IPv4 Destinations":
+			family = TypeIPv4
+			continue
+		case line == "IPv6 Destinations":
+			family = TypeIPv6
+			continue
+		}
+
+		switch family {
+		case TypeIPv4:
+			// Destination Gateway Flags Refcnt Interface
+			if len(fields) < 5 || fields[0] == "Destination" || strings.Trim(fields[0], "-") == "" {
+				continue
+			}
+			dest, err := parseDest(fields[0])
+			if err != nil {
+				return nil, err
+			}
+			routes = append(routes, Route{
+				Destination: dest,
+				Gateway:     parseRouteGateway(family, fields[1]),
+				Interface:   fields[4],
+				Family:      family,
+			})
+		case TypeIPv6:
+			if len(fields) < 2 {
+				continue
+			}
+			switch fields[0] {
+			case "DestIP:":
+				dest, err := parseDest(fields[1])
+				if err != nil {
+					return nil, err
+				}
+				routes = append(routes, Route{
+					Destination: dest,
+					Family:      family,
+				})
+				route = &routes[len(routes)-1]
+			case "Gw:":
+				if route != nil {
+					route.Gateway = parseRouteGateway(family, fields[1])
+				}
+			case "Intf:":
+				if route != nil {
+					route.Interface = fields[1]
+				}
+			}
+		}
+	}
+
+	return routes, nil
+}
+
+// routeTableRank ranks the routing tables in the order they are consulted by
+// Linux's default policy rules: local, main and then default.  Platforms with
+// a single table report it as table zero, which ranks with main.  Other tables
+// are only consulted through custom rules, which are not known, and return
+// false.
+func routeTableRank(table uint32) (int, bool) {
+	switch table {
+	case rtTableLocal:
+		return 0, true
+	case 0, rtTableMain:
+		return 1, true
+	case rtTableDefault:
+		return 2, true
+	}
+
+	return 0, false
+}
+
+// defaultInterfaceNames returns the names of the interfaces with a default
+// route of family in routes, ordered by address family and then by the metric
+// of their default routes.  Default routes in tables that are not consulted by
+// the default policy rules are ignored.  If routes could not be read
+// (routesErr is not nil) or are empty, the single interface returned by
+// defaultIfName is used instead.
+func defaultInterfaceNames(routes []Route, routesErr error, family SockAddrType, defaultIfName func() (string, error)) ([]string, error) {
+	if routesErr != nil || len(routes) == 0 {
+		ifName, err := defaultIfName()
+		if err != nil {
+			return nil, err
+		}
+		return []string{ifName}, nil
+	}
+
+	var defaults []Route
+	for _, route := range routes {
+		if _, ok := routeTableRank(route.Table); !ok || !route.IsDefault() || route.Family&family == 0 || route.Interface == "" {
+			continue
+		}
+		defaults = append(defaults, route)
+	}
+
+	slices.SortStableFunc(defaults, func(r1, r2 Route) int {
+		if r1.Family != r2.Family {
+			return int(r1.Family) - int(r2.Family)
+		}
+		switch {
+		case r1.Metric < r2.Metric:
+			return -1
+		case r1.Metric > r2.Metric:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	ifNames := make([]string, 0, len(defaults))
+	for _, route := range defaults {
+		if !slices.Contains(ifNames, route.Interface) {
+			ifNames = append(ifNames, route.Interface)
+		}
+	}
+
+	return ifNames, nil
+}
+
+// lookupRoute returns the route in routes that the host would use to reach
+// dst: the most specific route in the first table with a route to dst, with
+// ties broken by the lowest metric.  If dst is an IPv6 address with a zone,
+// only routes through the interface named by the zone are considered.
+func lookupRoute(routes []Route, dst IPAddr) (Route, bool) {
+	var zone string
+	if ipv6, ok := dst.(IPv6Addr); ok {
+		zone = ipv6.Zone
+	}
+
+	var tables [3]*PrefixTable[[]Route]
+	for _, route := range routes {
+		rank, ok := routeTableRank(route.Table)
+		if !ok || route.Destination == nil || (zone != "" && route.Interface != zone) {
+			continue
+		}
+
+		if tables[rank] == nil {
+			tables[rank] = NewPrefixTable[[]Route]()
+		}
+		sameDest, _ := tables[rank].Get(route.Destination)
+		tables[rank].Insert(route.Destination, append(sameDest, route))
+	}
+
+	for _, table := range tables {
+		if table == nil {
+			continue
+		}
+
+		if _, candidates, ok := table.Lookup(dst.Host()); ok {
+			best := candidates[0]
+			for _, route := range candidates[1:] {
+				if route.Metric < best.Metric {
+					best = route
+				}
+			}
+			return best, true
+		}
+	}
+
+	return Route{}, false
+}
+
+// localRoutes returns the routes of Linux's local table that the kernel derives
+// from the addresses in ifAddrs, for each address family whose main table is
+// in routes without a local table.  /proc/net/route only lists the main table,
+// so without these routes the host's own addresses would be reached through
+// the default route.  A host route is returned for every address and a route
+// to the network of every address on a loopback interface.
+func localRoutes(routes []Route, ifAddrs IfAddrs) []Route {
+	var hasMain, hasLocal [2]bool
+	familyIndex := func(family SockAddrType) int {
+		if family == TypeIPv6 {
+			return 1
+		}
+		return 0
+	}
+	for _, route := range routes {
+		switch route.Table {
+		case rtTableMain:
+			hasMain[familyIndex(route.Family)] = true
+		case rtTableLocal:
+			hasLocal[familyIndex(route.Family)] = true
+		}
+	}
+
+	var local []Route
+	for _, ifAddr := range ifAddrs {
+		ip, ok := ifAddr.SockAddr.(IPAddr)
+		if !ok {
+			continue
+		}
+		if i := familyIndex(ip.Type()); !hasMain[i] || hasLocal[i] {
+			continue
+		}
+
+		dest := ip.Host()
+		if ifAddr.Flags&net.FlagLoopback != 0 {
+			dest = ip.Network()
+		}
+		local = append(local, Route{
+			Destination: dest,
+			Interface:   ifAddr.Name,
+			Source:      ip.Host(),
+			Family:      ip.Type(),
+			Table:       rtTableLocal,
+		})
+	}
+
+	return local
+}
+
+// preferredSource returns the IfAddr in ifAddrs with the address the host
+// would use as the source address to reach dst through route: dst itself if
+// it is assigned to the route's interface, otherwise an address of the
+// interface with the same scope as dst, preferring one on the same network as
+// the next hop.  Returns false if the interface has no address of the route's
+// family.
+func preferredSource(ifAddrs IfAddrs, route Route, dst IPAddr) (IfAddr, bool) {
+	nextHop := route.Gateway
+	if nextHop == nil {
+		nextHop = dst
+	}
+
+	dstAddr := dst.NetipAddr().WithZone("")
+	nextHopAddr := nextHop.NetipAddr().WithZone("")
+
+	var best IfAddr
+	bestScore := -1
+	for _, ifAddr := range ifAddrs {
+		ip, ok := ifAddr.SockAddr.(IPAddr)
+		if !ok || ifAddr.Name != route.Interface || ip.Type() != route.Family {
+			continue
+		}
+
+		addr := ip.NetipAddr().WithZone("")
+		var score int
+		if addr == dstAddr {
+			score += 4
+		}
+		if addr.IsLinkLocalUnicast() == dstAddr.IsLinkLocalUnicast() {
+			score += 2
+		}
+		if netip.PrefixFrom(addr, ip.Maskbits()).Masked().Contains(nextHopAddr) {
+			score++
+		}
+
+		if score > bestScore {
+			best, bestScore = ifAddr, score
+		}
+	}
+
+	return best, bestScore >= 0
+}
+
+// LookupRoute returns the route the host would use to reach dst, found by a
+// longest-prefix match over the routing table.  When the platform does not
+// report the route's preferred source address, Source is set to the address
+// the host would pick from the route's interface, or left nil if the
+// interface has no address of the route's family.
+func LookupRoute(dst IPAddr) (Route, error) {
+	return LookupRouteFrom(OSInterfaceProvider{}, dst)
+}
+
+// LookupRouteFrom is LookupRoute() using the routing table and interfaces
+// supplied by p.
+func LookupRouteFrom(p RouteProvider, dst IPAddr) (Route, error) {
+	route, _, err := lookupRouteSource(p, dst)
+	return route, err
+}
+
+// lookupRouteSource returns the route to dst with its Source set, along with
+// the IfAddr of the source address if it is assigned to one of p's
+// interfaces.
+func lookupRouteSource(p RouteProvider, dst IPAddr) (Route, IfAddrs, error) {
+	routes, err := p.Routes()
+	if err != nil {
+		return Route{}, nil, err
+	}
+
+	ifAddrs, err := GetAllInterfacesFrom(p)
+	if err != nil {
+		return Route{}, nil, err
+	}
+
+	route, ok := lookupRoute(slices.Concat(routes, localRoutes(routes, ifAddrs)), dst)
+	if !ok {
+		return Route{}, nil, fmt.Errorf("no route to %s", dst)
+	}
+
+	if route.Source == nil {
+		src, ok := preferredSource(ifAddrs, route, dst)
+		if !ok {
+			return route, IfAddrs{}, nil
+		}
+		route.Source = src.SockAddr.(IPAddr).Host()
+		return route, IfAddrs{src}, nil
+	}
+
+	// The source hint of a route may be assigned to another interface, such
+	// as a loopback or dummy interface.
+	srcAddr := route.Source.NetipAddr().WithZone("")
+	for _, ifAddr := range ifAddrs {
+		if ip, ok := ifAddr.SockAddr.(IPAddr); ok && ip.NetipAddr().WithZone("") == srcAddr {
+			return route, IfAddrs{ifAddr}, nil
+		}
+	}
+
+	return route, IfAddrs{}, nil
+}
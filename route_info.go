@@ -5,6 +5,7 @@ package sockaddr
 
 import (
 	"errors"
+	"os/exec"
 	"slices"
 )
 
@@ -20,10 +21,40 @@ type RouteInterface interface {
 	// default route or an error and an empty string if a problem was
 	// encountered.
 	GetDefaultInterfaceName() (string, error)
+
+	// GetDefaultInterfaceNames returns the names of the interfaces with a
+	// default route of the given address family, ordered by metric.
+	GetDefaultInterfaceNames(family SockAddrType) ([]string, error)
+
+	// Routes returns the entries of the routing table or an error if the
+	// table could not be read.
+	Routes() ([]Route, error)
 }
 
 type routeInfo struct {
 	cmds map[string][]string
+
+	// record, if not nil, is called with the output of every command run
+	// by run.
+	record func(name string, cmd []string, out []byte, err error)
+}
+
+// run runs cmd, named name, and returns its standard output.
+func (ri routeInfo) run(name string, cmd []string) ([]byte, error) {
+	out, err := exec.Command(cmd[0], cmd[1:]...).Output()
+	if ri.record != nil {
+		ri.record(name, slices.Clone(cmd), out, err)
+	}
+	return out, err
+}
+
+// GetDefaultInterfaceNames returns the names of the interfaces with a default
+// route of family, ordered by the metric of their default routes.  Platforms
+// whose routing table can not be read report the interface returned by
+// GetDefaultInterfaceName().
+func (ri routeInfo) GetDefaultInterfaceNames(family SockAddrType) ([]string, error) {
+	routes, err := ri.Routes()
+	return defaultInterfaceNames(routes, err, family, ri.GetDefaultInterfaceName)
 }
 
 // VisitCommands visits each command used by the platform-specific RouteInfo
@@ -7,7 +7,6 @@ package sockaddr
 
 import (
 	"errors"
-	"os/exec"
 	"strings"
 )
 
@@ -22,7 +21,7 @@ func NewRouteInfo() (routeInfo, error) {
 // GetDefaultInterfaceName returns the interface name attached to the default
 // route on the default interface.
 func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
-	out, err := exec.Command(ri.cmds["ip"][0], ri.cmds["ip"][1:]...).Output()
+	out, err := ri.run("ip", ri.cmds["ip"])
 	if err != nil {
 		return "", err
 	}
@@ -45,3 +44,8 @@ func parseDefaultIfNameFromIPCmdAndroid(routeOut string) (string, error) {
 
 	return "", errors.New("no default interface found")
 }
+
+// Routes is not implemented on Android and returns ErrNoRoute.
+func (ri routeInfo) Routes() ([]Route, error) {
+	return nil, ErrNoRoute
+}
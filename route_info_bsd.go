@@ -5,10 +5,9 @@
 
 package sockaddr
 
-import "os/exec"
-
 var cmds = map[string][]string{
-	"route": {"/sbin/route", "-n", "get", "default"},
+	"route":   {"/sbin/route", "-n", "get", "default"},
+	"netstat": {"/usr/bin/netstat", "-rn"},
 }
 
 // NewRouteInfo returns a BSD-specific implementation of the RouteInfo
@@ -22,7 +21,7 @@ func NewRouteInfo() (routeInfo, error) {
 // GetDefaultInterfaceName returns the interface name attached to the default
 // route on the default interface.
 func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
-	out, err := exec.Command(cmds["route"][0], cmds["route"][1:]...).Output()
+	out, err := ri.run("route", cmds["route"])
 	if err != nil {
 		return "", err
 	}
@@ -33,3 +32,13 @@ func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 	}
 	return ifName, nil
 }
+
+// Routes returns the routes reported by `netstat -rn`.
+func (ri routeInfo) Routes() ([]Route, error) {
+	out, err := ri.run("netstat", cmds["netstat"])
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRoutesFromNetstat(string(out))
+}
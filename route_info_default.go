@@ -19,3 +19,8 @@ func NewRouteInfo() (routeInfo, error) {
 func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 	return "", ErrNoInterface
 }
+
+// Routes returns ErrNoRoute on unsupported platforms.
+func (ri routeInfo) Routes() ([]Route, error) {
+	return nil, ErrNoRoute
+}
@@ -7,9 +7,18 @@ package sockaddr
 
 import (
 	"errors"
+	"os"
 	"os/exec"
 )
 
+// The routing tables exported by the kernel, which are read before falling
+// back to ip(8) so that containers without iproute2 can find their default
+// interface.
+var (
+	procNetRoutePath     = "/proc/net/route"
+	procNetIPv6RoutePath = "/proc/net/ipv6_route"
+)
+
 // NewRouteInfo returns a Linux-specific implementation of the RouteInfo
 // interface.
 func NewRouteInfo() (routeInfo, error) {
@@ -26,9 +35,23 @@ func NewRouteInfo() (routeInfo, error) {
 }
 
 // GetDefaultInterfaceName returns the interface name attached to the default
-// route on the default interface.
+// route on the default interface.  The IPv4 routing table in /proc/net/route
+// is preferred, followed by the IPv6 routing table in /proc/net/ipv6_route.
+// If neither has a default route, the output of ip(8) is used.
 func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
-	out, err := exec.Command(ri.cmds["ip"][0], ri.cmds["ip"][1:]...).Output()
+	if out, err := os.ReadFile(procNetRoutePath); err == nil {
+		if ifName, err := parseDefaultIfNameFromProcNetRoute(string(out)); err == nil {
+			return ifName, nil
+		}
+	}
+
+	if out, err := os.ReadFile(procNetIPv6RoutePath); err == nil {
+		if ifName, err := parseDefaultIfNameFromProcNetIPv6Route(string(out)); err == nil {
+			return ifName, nil
+		}
+	}
+
+	out, err := ri.run("ip", ri.cmds["ip"])
 	if err != nil {
 		return "", err
 	}
@@ -39,3 +62,60 @@ func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 	}
 	return ifName, nil
 }
+
+// Routes returns the routes in all routing tables, read over netlink.  If
+// netlink is not available, the routes in /proc/net/route and
+// /proc/net/ipv6_route are returned, which only cover the main table and carry
+// no preferred source.  If /proc/net/route can not be read either, the routes
+// in all tables are read from ip(8).
+func (ri routeInfo) Routes() ([]Route, error) {
+	if routes, err := netlinkRoutes(); err == nil {
+		return routes, nil
+	}
+
+	out, err := os.ReadFile(procNetRoutePath)
+	if err != nil {
+		return ri.routesFromIPCmd()
+	}
+
+	routes, err := parseRoutesFromProcNetRoute(string(out))
+	if err != nil {
+		return nil, err
+	}
+
+	// The IPv6 routing table is absent when IPv6 is disabled.
+	if out, err := os.ReadFile(procNetIPv6RoutePath); err == nil {
+		ipv6Routes, err := parseRoutesFromProcNetIPv6Route(string(out))
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, ipv6Routes...)
+	}
+
+	return routes, nil
+}
+
+// routesFromIPCmd returns the IPv4 and IPv6 routes in all tables as reported
+// by ip(8).
+func (ri routeInfo) routesFromIPCmd() ([]Route, error) {
+	var routes []Route
+	for _, family := range []SockAddrType{TypeIPv4, TypeIPv6} {
+		familyFlag := "-4"
+		if family == TypeIPv6 {
+			familyFlag = "-6"
+		}
+
+		out, err := ri.run("ip"+familyFlag, []string{ri.cmds["ip"][0], familyFlag, "route", "show", "table", "all"})
+		if err != nil {
+			return nil, err
+		}
+
+		familyRoutes, err := parseRoutesFromIPCmd(family, string(out))
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, familyRoutes...)
+	}
+
+	return routes, nil
+}
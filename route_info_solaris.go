@@ -7,11 +7,11 @@ package sockaddr
 
 import (
 	"errors"
-	"os/exec"
 )
 
 var cmds map[string][]string = map[string][]string{
-	"route": {"/usr/sbin/route", "-n", "get", "default"},
+	"route":   {"/usr/sbin/route", "-n", "get", "default"},
+	"netstat": {"/usr/bin/netstat", "-rnv"},
 }
 
 // NewRouteInfo returns a BSD-specific implementation of the RouteInfo
@@ -25,7 +25,7 @@ func NewRouteInfo() (routeInfo, error) {
 // GetDefaultInterfaceName returns the interface name attached to the default
 // route on the default interface.
 func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
-	out, err := exec.Command(cmds["route"][0], cmds["route"][1:]...).Output()
+	out, err := ri.run("route", cmds["route"])
 	if err != nil {
 		return "", err
 	}
@@ -36,3 +36,13 @@ func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 	}
 	return ifName, nil
 }
+
+// Routes returns the routes reported by `netstat -rnv`.
+func (ri routeInfo) Routes() ([]Route, error) {
+	out, err := ri.run("netstat", cmds["netstat"])
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRoutesFromSolarisNetstat(string(out))
+}
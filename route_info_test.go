@@ -3,7 +3,11 @@
 
 package sockaddr
 
-import "testing"
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
 
 func Test_parseBSDDefaultIfName(t *testing.T) {
 	testCases := []struct {
@@ -76,6 +80,131 @@ func Test_parseLinuxDefaultIfName(t *testing.T) {
 	}
 }
 
+func Test_parseLinuxProcNetRoute(t *testing.T) {
+	testCases := []struct {
+		name     string
+		routeOut string
+		want     string
+		fail     bool
+	}{
+		{
+			name: "Linux container - Common",
+			routeOut: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT                                                       
+eth0	00000000	010200C0	0003	0	0	0	00000000	0	0	0                                                                               
+eth0	000200C0	00000000	0001	0	0	0	00FFFFFF	0	0	0                                                                               
+`,
+			want: "eth0",
+		},
+		{
+			name: "Linux laptop - Ethernet and WiFi",
+			routeOut: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT                                                       
+wlp2s0	00000000	0100A8C0	0003	0	0	600	00000000	0	0	0                                                                               
+enp0s31f6	00000000	0101A8C0	0003	0	0	100	00000000	0	0	0                                                                               
+docker0	000011AC	00000000	0001	0	0	0	0000FFFF	0	0	0                                                                               
+enp0s31f6	0001A8C0	00000000	0001	0	0	100	00FFFFFF	0	0	0                                                                               
+wlp2s0	0000A8C0	00000000	0001	0	0	600	00FFFFFF	0	0	0                                                                               
+`,
+			want: "enp0s31f6",
+		},
+		{
+			name: "Linux - down and unreachable defaults",
+			routeOut: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth1	00000000	0101A8C0	0002	0	0	0	00000000	0	0	0
+lo	00000000	00000000	0201	0	0	0	00000000	0	0	0
+eth0	00000000	0100A8C0	0003	0	0	1024	00000000	0	0	0
+`,
+			want: "eth0",
+		},
+		{
+			name: "Linux - no default route",
+			routeOut: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	000200C0	00000000	0001	0	0	0	00FFFFFF	0	0	0
+`,
+			fail: true,
+		},
+		{
+			name: "Linux - malformed",
+			routeOut: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	0000000Z	010200C0	0003	0	0	0	00000000	0	0	0
+`,
+			fail: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseDefaultIfNameFromProcNetRoute(tc.routeOut)
+			switch {
+			case tc.fail && err == nil:
+				t.Fatalf("expected an error, got %+q", got)
+			case tc.fail:
+				return
+			case err != nil:
+				t.Fatalf("unable to parse default interface from /proc/net/route: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %+q; want %+q", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_parseLinuxProcNetIPv6Route(t *testing.T) {
+	testCases := []struct {
+		name     string
+		routeOut string
+		want     string
+		fail     bool
+	}{
+		{
+			name: "Linux container - Common",
+			routeOut: `fd000000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001     eth0
+fe800000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000002 00000000 00000001     eth0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 fd000000000000000000000000000001 00000400 00000001 00000000 00000003     eth0
+00000000000000000000000000000001 80 00000000000000000000000000000000 00 00000000000000000000000000000000 00000000 00000002 00000000 80200001       lo
+fd000000000000000000000000000002 80 00000000000000000000000000000000 00 00000000000000000000000000000000 00000000 00000002 00000000 80200001     eth0
+ff000000000000000000000000000000 08 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000004 00000000 00000001     eth0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 00000000000000000000000000000000 ffffffff 00000001 00000000 00200200       lo
+`,
+			want: "eth0",
+		},
+		{
+			name: "Linux - router advertisements on two interfaces",
+			routeOut: `00000000000000000000000000000000 00 00000000000000000000000000000000 00 fe80000000000000021122fffe334455 00000258 00000001 00000000 00450003   wlp2s0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 fe800000000000000266778899aabbcc 00000064 00000001 00000000 00450003 enp0s31f6
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 00000000000000000000000000000000 ffffffff 00000001 00000000 00200200       lo
+`,
+			want: "enp0s31f6",
+		},
+		{
+			name: "Linux - only unreachable default",
+			routeOut: `fe800000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000002 00000000 00000001     eth0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 00000000000000000000000000000000 ffffffff 00000001 00000000 00200200       lo
+`,
+			fail: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseDefaultIfNameFromProcNetIPv6Route(tc.routeOut)
+			switch {
+			case tc.fail && err == nil:
+				t.Fatalf("expected an error, got %+q", got)
+			case tc.fail:
+				return
+			case err != nil:
+				t.Fatalf("unable to parse default interface from /proc/net/ipv6_route: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %+q; want %+q", got, tc.want)
+			}
+		})
+	}
+}
+
 func Test_parseWindowsDefaultIfName(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -197,3 +326,39 @@ func Test_VisitComands(t *testing.T) {
 		t.Fatalf("Expected more than 0 items")
 	}
 }
+
+func Test_routeInfoRun(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not found")
+	}
+
+	type record struct {
+		name string
+		cmd  []string
+		out  string
+		err  bool
+	}
+	var records []record
+	ri := routeInfo{
+		record: func(name string, cmd []string, out []byte, err error) {
+			records = append(records, record{name, cmd, string(out), err != nil})
+		},
+	}
+
+	out, err := ri.run("echo", []string{sh, "-c", "echo default"})
+	if err != nil || string(out) != "default\n" {
+		t.Fatalf("got %q, %v", out, err)
+	}
+	if _, err := ri.run("fail", []string{sh, "-c", "exit 1"}); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	want := []record{
+		{"echo", []string{sh, "-c", "echo default"}, "default\n", false},
+		{"fail", []string{sh, "-c", "exit 1"}, "", true},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %+v, want %+v", records, want)
+	}
+}
@@ -32,7 +32,7 @@ func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 		return ri.GetDefaultInterfaceNameLegacy()
 	}
 
-	ifNameOut, err := exec.Command(cmds["defaultInterface"][0], cmds["defaultInterface"][1:]...).Output()
+	ifNameOut, err := ri.run("defaultInterface", cmds["defaultInterface"])
 	if err != nil {
 		return "", err
 	}
@@ -44,12 +44,12 @@ func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 // GetDefaultInterfaceNameLegacy provides legacy behavior for GetDefaultInterfaceName
 // on Windows machines without powershell.
 func (ri routeInfo) GetDefaultInterfaceNameLegacy() (string, error) {
-	ifNameOut, err := exec.Command(cmds["netstat"][0], cmds["netstat"][1:]...).Output()
+	ifNameOut, err := ri.run("netstat", cmds["netstat"])
 	if err != nil {
 		return "", err
 	}
 
-	ipconfigOut, err := exec.Command(cmds["ipconfig"][0], cmds["ipconfig"][1:]...).Output()
+	ipconfigOut, err := ri.run("ipconfig", cmds["ipconfig"])
 	if err != nil {
 		return "", err
 	}
@@ -62,6 +62,11 @@ func (ri routeInfo) GetDefaultInterfaceNameLegacy() (string, error) {
 	return ifName, nil
 }
 
+// Routes is not implemented on Windows and returns ErrNoRoute.
+func (ri routeInfo) Routes() ([]Route, error) {
+	return nil, ErrNoRoute
+}
+
 func hasPowershell() bool {
 	_, err := exec.LookPath("powershell")
 	return (err != nil)
@@ -47,3 +47,12 @@ func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 	}
 	return zosProcessOnetstatOutput(output)
 }
+
+// Routes returns the routes reported by `onetstat -r`.
+func (ri routeInfo) Routes() ([]Route, error) {
+	output, err := zosGetDefaultInterfaceName()
+	if err != nil {
+		return nil, err
+	}
+	return parseRoutesFromOnetstat(output)
+}
@@ -0,0 +1,247 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"syscall"
+)
+
+// RTA_VIA from include/uapi/linux/rtnetlink.h, which carries a next hop of a
+// different address family than the route, such as an IPv6 gateway of an IPv4
+// route.
+const rtaVia = 0x12
+
+// netlinkRoutes dumps the IPv4 and IPv6 routes in all routing tables over
+// netlink.
+func netlinkRoutes() ([]Route, error) {
+	tab, err := syscall.NetlinkRIB(syscall.RTM_GETROUTE, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, os.NewSyscallError("netlinkrib", err)
+	}
+
+	msgs, err := syscall.ParseNetlinkMessage(tab)
+	if err != nil {
+		return nil, os.NewSyscallError("parsenetlinkmessage", err)
+	}
+
+	intfs, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	ifNames := make(map[int]string, len(intfs))
+	for _, intf := range intfs {
+		ifNames[intf.Index] = intf.Name
+	}
+
+	return parseNetlinkRouteMsgs(msgs, ifNames)
+}
+
+// parseNetlinkRouteMsgs parses the RTM_NEWROUTE messages of a netlink route
+// dump, naming the outgoing interface of each route from ifNames.  Routes
+// that reject traffic and cached routes are omitted, and a multipath route is
+// returned once per next hop.
+func parseNetlinkRouteMsgs(msgs []syscall.NetlinkMessage, ifNames map[int]string) ([]Route, error) {
+	var routes []Route
+	for i := range msgs {
+		m := &msgs[i]
+		if m.Header.Type == syscall.NLMSG_DONE {
+			break
+		}
+		if m.Header.Type != syscall.RTM_NEWROUTE {
+			continue
+		}
+		if len(m.Data) < syscall.SizeofRtMsg {
+			return nil, fmt.Errorf("truncated route message of %d bytes", len(m.Data))
+		}
+
+		// struct rtmsg: family, dst_len, src_len, tos, table, protocol,
+		// scope, type, flags.
+		var family SockAddrType
+		switch m.Data[0] {
+		case syscall.AF_INET:
+			family = TypeIPv4
+		case syscall.AF_INET6:
+			family = TypeIPv6
+		default:
+			continue
+		}
+		switch m.Data[7] {
+		case syscall.RTN_BLACKHOLE, syscall.RTN_UNREACHABLE, syscall.RTN_PROHIBIT, syscall.RTN_THROW, syscall.RTN_NAT:
+			continue
+		}
+		if binary.NativeEndian.Uint32(m.Data[8:12])&syscall.RTM_F_CLONED != 0 {
+			continue
+		}
+
+		attrs, err := syscall.ParseNetlinkRouteAttr(m)
+		if err != nil {
+			return nil, os.NewSyscallError("parsenetlinkrouteattr", err)
+		}
+
+		route := Route{
+			Family: family,
+			Table:  uint32(m.Data[4]),
+		}
+		dst := defaultNetipAddr(family)
+		var multipath []byte
+		for _, attr := range attrs {
+			switch attr.Attr.Type {
+			case syscall.RTA_DST:
+				if dst, err = netlinkRouteAddr(attr.Value); err != nil {
+					return nil, fmt.Errorf("unable to parse destination: %w", err)
+				}
+			case syscall.RTA_GATEWAY, rtaVia:
+				if route.Gateway, err = netlinkRouteGateway(attr.Attr.Type, attr.Value); err != nil {
+					return nil, fmt.Errorf("unable to parse gateway: %w", err)
+				}
+			case syscall.RTA_OIF:
+				if len(attr.Value) >= 4 {
+					route.Interface = ifNames[int(binary.NativeEndian.Uint32(attr.Value))]
+				}
+			case syscall.RTA_PRIORITY:
+				if len(attr.Value) >= 4 {
+					route.Metric = binary.NativeEndian.Uint32(attr.Value)
+				}
+			case syscall.RTA_PREFSRC:
+				src, err := netlinkRouteAddr(attr.Value)
+				if err != nil {
+					return nil, fmt.Errorf("unable to parse source: %w", err)
+				}
+				if route.Source, err = NewIPAddrFromNetipAddr(src); err != nil {
+					return nil, err
+				}
+			case syscall.RTA_TABLE:
+				// The header only has room for tables below 256.
+				if len(attr.Value) >= 4 {
+					route.Table = binary.NativeEndian.Uint32(attr.Value)
+				}
+			case syscall.RTA_MULTIPATH:
+				multipath = attr.Value
+			}
+		}
+
+		if route.Destination, err = NewIPAddrFromNetipPrefix(netip.PrefixFrom(dst, int(m.Data[1]))); err != nil {
+			return nil, fmt.Errorf("unable to parse destination: %w", err)
+		}
+
+		if multipath == nil {
+			routes = append(routes, route)
+			continue
+		}
+
+		nexthops, err := parseNetlinkNexthops(route, multipath, ifNames)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, nexthops...)
+	}
+
+	return routes, nil
+}
+
+// parseNetlinkNexthops expands the struct rtnexthop entries of an
+// RTA_MULTIPATH attribute into one copy of route per next hop.
+func parseNetlinkNexthops(route Route, b []byte, ifNames map[int]string) ([]Route, error) {
+	var routes []Route
+	for len(b) >= syscall.SizeofRtNexthop {
+		// struct rtnexthop: len, flags, hops, ifindex, followed by the
+		// attributes of the next hop.
+		nhLen := int(binary.NativeEndian.Uint16(b[0:2]))
+		if nhLen < syscall.SizeofRtNexthop || nhLen > len(b) {
+			return nil, fmt.Errorf("truncated next hop of %d bytes", len(b))
+		}
+
+		nexthop := route
+		nexthop.Interface = ifNames[int(binary.NativeEndian.Uint32(b[4:8]))]
+		nexthop.Gateway = nil
+		attrs, err := parseNetlinkNestedAttrs(b[syscall.SizeofRtNexthop:nhLen])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse next hop: %w", err)
+		}
+		for _, attr := range attrs {
+			if attr.Attr.Type == syscall.RTA_GATEWAY || attr.Attr.Type == rtaVia {
+				gw, err := netlinkRouteGateway(attr.Attr.Type, attr.Value)
+				if err != nil {
+					return nil, fmt.Errorf("unable to parse gateway: %w", err)
+				}
+				nexthop.Gateway = gw
+			}
+		}
+		routes = append(routes, nexthop)
+
+		nhLen = (nhLen + syscall.RTNH_ALIGNTO - 1) &^ (syscall.RTNH_ALIGNTO - 1)
+		b = b[min(nhLen, len(b)):]
+	}
+
+	return routes, nil
+}
+
+// parseNetlinkNestedAttrs parses the attributes nested in a netlink attribute
+// or following a struct within one.
+func parseNetlinkNestedAttrs(b []byte) ([]syscall.NetlinkRouteAttr, error) {
+	var attrs []syscall.NetlinkRouteAttr
+	for len(b) >= syscall.SizeofRtAttr {
+		attrLen := int(binary.NativeEndian.Uint16(b[0:2]))
+		if attrLen < syscall.SizeofRtAttr || attrLen > len(b) {
+			return nil, fmt.Errorf("truncated attribute of %d bytes", len(b))
+		}
+
+		attrs = append(attrs, syscall.NetlinkRouteAttr{
+			Attr: syscall.RtAttr{
+				Len:  uint16(attrLen),
+				Type: binary.NativeEndian.Uint16(b[2:4]),
+			},
+			Value: b[syscall.SizeofRtAttr:attrLen],
+		})
+
+		attrLen = (attrLen + syscall.RTA_ALIGNTO - 1) &^ (syscall.RTA_ALIGNTO - 1)
+		b = b[min(attrLen, len(b)):]
+	}
+
+	return attrs, nil
+}
+
+// netlinkRouteAddr parses the address carried by a route attribute.
+func netlinkRouteAddr(b []byte) (netip.Addr, error) {
+	addr, ok := netip.AddrFromSlice(b)
+	if !ok {
+		return netip.Addr{}, fmt.Errorf("invalid address of %d bytes", len(b))
+	}
+	return addr, nil
+}
+
+// netlinkRouteGateway parses an RTA_GATEWAY or RTA_VIA attribute.  Unspecified
+// gateways return nil.
+func netlinkRouteGateway(attrType uint16, b []byte) (IPAddr, error) {
+	// struct rtvia: a two byte address family followed by the address.
+	if attrType == rtaVia {
+		if len(b) < 2 {
+			return nil, fmt.Errorf("truncated via of %d bytes", len(b))
+		}
+		b = b[2:]
+	}
+
+	gw, err := netlinkRouteAddr(b)
+	if err != nil {
+		return nil, err
+	}
+	if gw.IsUnspecified() {
+		return nil, nil
+	}
+	return NewIPAddrFromNetipAddr(gw)
+}
+
+// defaultNetipAddr returns the unspecified address of family, which is the
+// destination of routes without an RTA_DST attribute.
+func defaultNetipAddr(family SockAddrType) netip.Addr {
+	if family == TypeIPv6 {
+		return netip.IPv6Unspecified()
+	}
+	return netip.IPv4Unspecified()
+}
@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"slices"
+	"syscall"
+	"testing"
+)
+
+// netlinkRouteMsg returns an RTM_NEWROUTE message for a route of the given
+// family, destination prefix length, table and type, followed by attrs.
+func netlinkRouteMsg(family, dstLen, table, routeType uint8, flags uint32, attrs ...[]byte) syscall.NetlinkMessage {
+	data := []byte{family, dstLen, 0, 0, table, 0, 0, routeType, 0, 0, 0, 0}
+	binary.NativeEndian.PutUint32(data[8:], flags)
+	for _, attr := range attrs {
+		data = append(data, attr...)
+	}
+
+	return syscall.NetlinkMessage{
+		Header: syscall.NlMsghdr{Type: syscall.RTM_NEWROUTE},
+		Data:   data,
+	}
+}
+
+// netlinkUint32 returns v in host byte order.
+func netlinkUint32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.NativeEndian.PutUint32(b, v)
+	return b
+}
+
+// netlinkNexthop returns a struct rtnexthop for ifIndex followed by attrs.
+func netlinkNexthop(ifIndex uint32, attrs ...[]byte) []byte {
+	b := make([]byte, syscall.SizeofRtNexthop)
+	binary.NativeEndian.PutUint32(b[4:], ifIndex)
+	for _, attr := range attrs {
+		b = append(b, attr...)
+	}
+	binary.NativeEndian.PutUint16(b[0:], uint16(len(b)))
+	return b
+}
+
+func Test_parseNetlinkRouteMsgs(t *testing.T) {
+	ifNames := map[int]string{1: "lo", 2: "eth0", 3: "eth1"}
+
+	msgs := []syscall.NetlinkMessage{
+		netlinkRouteMsg(syscall.AF_INET, 0, rtTableMain, syscall.RTN_UNICAST, 0,
+			netlinkAttr(syscall.RTA_TABLE, netlinkUint32(rtTableMain)),
+			netlinkAttr(syscall.RTA_GATEWAY, []byte{192, 0, 2, 1}),
+			netlinkAttr(syscall.RTA_OIF, netlinkUint32(2))),
+		netlinkRouteMsg(syscall.AF_INET, 24, rtTableMain, syscall.RTN_UNICAST, 0,
+			netlinkAttr(syscall.RTA_TABLE, netlinkUint32(rtTableMain)),
+			netlinkAttr(syscall.RTA_DST, []byte{192, 0, 2, 0}),
+			netlinkAttr(syscall.RTA_PREFSRC, []byte{192, 0, 2, 2}),
+			netlinkAttr(syscall.RTA_OIF, netlinkUint32(2))),
+		// Tables above 255 only fit in RTA_TABLE.
+		netlinkRouteMsg(syscall.AF_INET, 0, 252, syscall.RTN_UNICAST, 0,
+			netlinkAttr(syscall.RTA_TABLE, netlinkUint32(1000)),
+			netlinkAttr(syscall.RTA_PRIORITY, netlinkUint32(100)),
+			netlinkAttr(syscall.RTA_MULTIPATH, append(
+				netlinkNexthop(2, netlinkAttr(syscall.RTA_GATEWAY, []byte{192, 0, 2, 1})),
+				netlinkNexthop(3, netlinkAttr(syscall.RTA_GATEWAY, []byte{198, 51, 100, 1}))...))),
+		netlinkRouteMsg(syscall.AF_INET, 24, rtTableMain, syscall.RTN_UNREACHABLE, 0,
+			netlinkAttr(syscall.RTA_DST, []byte{203, 0, 113, 0})),
+		netlinkRouteMsg(syscall.AF_INET, 32, rtTableLocal, syscall.RTN_LOCAL, 0,
+			netlinkAttr(syscall.RTA_TABLE, netlinkUint32(rtTableLocal)),
+			netlinkAttr(syscall.RTA_DST, []byte{127, 0, 0, 1}),
+			netlinkAttr(syscall.RTA_PREFSRC, []byte{127, 0, 0, 1}),
+			netlinkAttr(syscall.RTA_OIF, netlinkUint32(1))),
+		netlinkRouteMsg(syscall.AF_INET6, 0, rtTableMain, syscall.RTN_UNICAST, 0,
+			netlinkAttr(syscall.RTA_GATEWAY, netip.MustParseAddr("fe80::1").AsSlice()),
+			netlinkAttr(syscall.RTA_OIF, netlinkUint32(2)),
+			netlinkAttr(syscall.RTA_PRIORITY, netlinkUint32(1024))),
+		// An IPv4 route with an IPv6 next hop.
+		netlinkRouteMsg(syscall.AF_INET, 8, rtTableMain, syscall.RTN_UNICAST, 0,
+			netlinkAttr(syscall.RTA_DST, []byte{10, 0, 0, 0}),
+			netlinkAttr(rtaVia, append([]byte{syscall.AF_INET6, 0}, netip.MustParseAddr("fe80::2").AsSlice()...)),
+			netlinkAttr(syscall.RTA_OIF, netlinkUint32(3))),
+		// Cached routes are not part of the routing table.
+		netlinkRouteMsg(syscall.AF_INET6, 128, rtTableMain, syscall.RTN_UNICAST, syscall.RTM_F_CLONED,
+			netlinkAttr(syscall.RTA_DST, netip.MustParseAddr("2001:db8::5").AsSlice()),
+			netlinkAttr(syscall.RTA_OIF, netlinkUint32(2))),
+		{Header: syscall.NlMsghdr{Type: syscall.NLMSG_DONE}},
+	}
+
+	routes, err := parseNetlinkRouteMsgs(msgs, ifNames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make([]string, 0, len(routes))
+	for _, route := range routes {
+		if route.Family != route.Destination.Type() {
+			t.Errorf("route %q has family %s", route, route.Family)
+		}
+		got = append(got, route.String())
+	}
+	want := []string{
+		"default via 192.0.2.1 dev eth0 table 254",
+		"192.0.2.0/24 dev eth0 src 192.0.2.2 table 254",
+		"default via 192.0.2.1 dev eth0 metric 100 table 1000",
+		"default via 198.51.100.1 dev eth1 metric 100 table 1000",
+		"127.0.0.1 dev lo src 127.0.0.1 table 255",
+		"default via fe80::1 dev eth0 metric 1024 table 254",
+		"10.0.0.0/8 via fe80::2 dev eth1 table 254",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %+q; want %+q", got, want)
+	}
+
+	truncated := []syscall.NetlinkMessage{
+		{Header: syscall.NlMsghdr{Type: syscall.RTM_NEWROUTE}, Data: []byte{syscall.AF_INET, 0}},
+	}
+	if _, err := parseNetlinkRouteMsgs(truncated, ifNames); err == nil {
+		t.Errorf("expected an error for a truncated route message")
+	}
+}
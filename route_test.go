@@ -0,0 +1,420 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// procNetRouteFixture returns the contents of /proc/net/route for rows of
+// "iface destination gateway flags metric mask", encoding the addresses in host
+// byte order like the kernel does.
+func procNetRouteFixture(rows ...string) string {
+	hex := func(s string) string {
+		b := netip.MustParseAddr(s).As4()
+		return fmt.Sprintf("%08X", binary.NativeEndian.Uint32(b[:]))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\t\tMTU\tWindow\tIRTT\n")
+	for _, row := range rows {
+		f := strings.Fields(row)
+		fmt.Fprintf(&sb, "%s\t%s\t%s\t%s\t0\t0\t%s\t%s\t0\t0\t0\n", f[0], hex(f[1]), hex(f[2]), f[3], f[4], hex(f[5]))
+	}
+	return sb.String()
+}
+
+func Test_parseRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		parse    func(string) ([]Route, error)
+		routeOut string
+		want     []string
+	}{
+		{
+			name:  "Linux /proc/net/route",
+			parse: parseRoutesFromProcNetRoute,
+			routeOut: procNetRouteFixture(
+				"eth0 0.0.0.0 192.0.2.1 0003 0 0.0.0.0",
+				"eth0 192.0.2.0 0.0.0.0 0001 0 255.255.255.0",
+				"wlan0 192.168.0.0 0.0.0.0 0001 600 255.255.255.0",
+				"lo 0.0.0.0 0.0.0.0 0201 0 0.0.0.0",
+				"eth1 10.0.0.0 0.0.0.0 0000 0 255.0.0.0",
+			),
+			want: []string{
+				"default via 192.0.2.1 dev eth0 table 254",
+				"192.0.2.0/24 dev eth0 table 254",
+				"192.168.0.0/24 dev wlan0 metric 600 table 254",
+			},
+		},
+		{
+			name:  "Linux /proc/net/ipv6_route",
+			parse: parseRoutesFromProcNetIPv6Route,
+			routeOut: `fd000000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001     eth0
+fe800000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000002 00000000 00000001     eth0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 fd000000000000000000000000000001 00000400 00000001 00000000 00000003     eth0
+00000000000000000000000000000001 80 00000000000000000000000000000000 00 00000000000000000000000000000000 00000000 00000002 00000000 80200001       lo
+fd000000000000000000000000000002 80 00000000000000000000000000000000 00 00000000000000000000000000000000 00000000 00000002 00000000 80200001     eth0
+ff000000000000000000000000000000 08 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000004 00000000 00000001     eth0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 00000000000000000000000000000000 ffffffff 00000001 00000000 00200200       lo
+`,
+			want: []string{
+				"fd00::/64 dev eth0 metric 256 table 254",
+				"fe80::/64 dev eth0 metric 256 table 254",
+				"default via fd00::1 dev eth0 metric 1024 table 254",
+				"::1 dev lo table 255",
+				"fd00::2 dev eth0 table 255",
+				"ff00::/8 dev eth0 metric 256 table 254",
+			},
+		},
+		{
+			name: "Linux ip -4 route show table all",
+			parse: func(routeOut string) ([]Route, error) {
+				return parseRoutesFromIPCmd(TypeIPv4, routeOut)
+			},
+			routeOut: `default via 192.0.2.1 dev eth0 proto dhcp src 192.0.2.5 metric 100
+192.0.2.0/24 dev eth0 proto kernel scope link src 192.0.2.5 metric 100
+198.51.100.0/24 via inet6 fe80::1 dev eth1 proto static
+10.8.0.0/16 via 192.0.2.254 dev eth0 table 100
+blackhole 10.9.0.0/16
+10.10.0.0/16 proto static metric 20
+	nexthop via 192.0.2.2 dev eth0 weight 1
+	nexthop via 198.51.100.3 dev eth1 weight 1
+local 127.0.0.0/8 dev lo table local proto kernel scope host src 127.0.0.1
+local 192.0.2.5 dev eth0 table local proto kernel scope host src 192.0.2.5
+broadcast 192.0.2.255 dev eth0 table local proto kernel scope link src 192.0.2.5
+`,
+			want: []string{
+				"default via 192.0.2.1 dev eth0 src 192.0.2.5 metric 100 table 254",
+				"192.0.2.0/24 dev eth0 src 192.0.2.5 metric 100 table 254",
+				"198.51.100.0/24 via fe80::1 dev eth1 table 254",
+				"10.8.0.0/16 via 192.0.2.254 dev eth0 table 100",
+				"10.10.0.0/16 via 192.0.2.2 dev eth0 metric 20 table 254",
+				"10.10.0.0/16 via 198.51.100.3 dev eth1 metric 20 table 254",
+				"127.0.0.0/8 dev lo src 127.0.0.1 table 255",
+				"192.0.2.5 dev eth0 src 192.0.2.5 table 255",
+				"192.0.2.255 dev eth0 src 192.0.2.5 table 255",
+			},
+		},
+		{
+			name: "Linux ip -6 route show table all",
+			parse: func(routeOut string) ([]Route, error) {
+				return parseRoutesFromIPCmd(TypeIPv6, routeOut)
+			},
+			routeOut: `::1 dev lo proto kernel metric 256 pref medium
+2001:db8::/64 dev eth0 proto ra metric 100 expires 86395sec pref medium
+fe80::/64 dev eth0 proto kernel metric 256 pref medium
+default via fe80::1 dev eth0 proto ra metric 100 expires 1795sec pref medium
+unreachable default dev lo table unspec proto kernel metric 4294967295 error -101 pref medium
+local ::1 dev lo table local proto kernel metric 0 pref medium
+multicast ff00::/8 dev eth0 table local proto kernel metric 256 pref medium
+`,
+			want: []string{
+				"::1 dev lo metric 256 table 254",
+				"2001:db8::/64 dev eth0 metric 100 table 254",
+				"fe80::/64 dev eth0 metric 256 table 254",
+				"default via fe80::1 dev eth0 metric 100 table 254",
+				"::1 dev lo table 255",
+				"ff00::/8 dev eth0 metric 256 table 255",
+			},
+		},
+		{
+			name:  "macOS Sonoma 14 - netstat -rn",
+			parse: parseRoutesFromNetstat,
+			routeOut: `Routing tables
+
+Internet:
+Destination        Gateway            Flags               Netif Expire
+default            192.168.1.1        UGScg                 en0
+127                127.0.0.1          UCS                   lo0
+127.0.0.1          127.0.0.1          UH                    lo0
+169.254            link#6             UCS                   en0      !
+192.168.1          link#6             UCS                   en0      !
+192.168.1.1/32     link#6             UCS                   en0      !
+192.168.1.1        a4:2b:b0:e8:5a:1c  UHLWIir               en0   1175
+224.0.0/4          link#6             UmCS                  en0      !
+255.255.255.255/32 link#6             UCS                   en0      !
+
+Internet6:
+Destination                             Gateway                                 Flags               Netif Expire
+default                                 fe80::%utun0                            UGcIg               utun0
+::1                                     ::1                                     UHL                   lo0
+fe80::%lo0/64                           fe80::1%lo0                             UcI                   lo0
+fe80::1%lo0                             link#1                                  UHLI                  lo0
+ff02::%lo0/32                           ::1                                     UmCI                  lo0
+`,
+			want: []string{
+				"default via 192.168.1.1 dev en0",
+				"127.0.0.0/8 via 127.0.0.1 dev lo0",
+				"127.0.0.1 via 127.0.0.1 dev lo0",
+				"169.254.0.0/16 dev en0",
+				"192.168.1.0/24 dev en0",
+				"192.168.1.1 dev en0",
+				"224.0.0.0/4 dev en0",
+				"255.255.255.255 dev en0",
+				"::1 via ::1 dev lo0",
+				"fe80::%lo0/64 via fe80::1%lo0 dev lo0",
+				"fe80::1%lo0 dev lo0",
+				"ff02::%lo0/32 via ::1 dev lo0",
+			},
+		},
+		{
+			name:  "OpenBSD 7.5 - netstat -rn",
+			parse: parseRoutesFromNetstat,
+			routeOut: `Routing tables
+
+Internet:
+Destination        Gateway            Flags   Refs      Use   Mtu  Prio Iface
+default            10.0.2.2           UGS        4       55     -     8 em0
+10.0.2/24          10.0.2.15          UCn        1        2     -     4 em0
+10.0.2.15          08:00:27:ab:cd:ef  UHLl       0        8     -     1 em0
+127/8              127.0.0.1          UGRS       0        0 32768     8 lo0
+127.0.0.1          127.0.0.1          UHhl       1        2 32768     1 lo0
+
+Internet6:
+Destination                        Gateway                        Flags   Refs      Use   Mtu  Prio Iface
+::/96                              ::1                            UGRS       0        0 32768     8 lo0
+::1                                ::1                            UHhl      10       20 32768     1 lo0
+fe80::%em0/64                      fe80::a00:27ff:feab:cdef%em0   UCn        0        0     -     4 em0
+`,
+			want: []string{
+				"default via 10.0.2.2 dev em0",
+				"10.0.2.0/24 via 10.0.2.15 dev em0",
+				"10.0.2.15 dev em0",
+				"127.0.0.0/8 via 127.0.0.1 dev lo0",
+				"127.0.0.1 via 127.0.0.1 dev lo0",
+				"::/96 via ::1 dev lo0",
+				"::1 via ::1 dev lo0",
+				"fe80::%em0/64 via fe80::a00:27ff:feab:cdef%em0 dev em0",
+			},
+		},
+		{
+			name:  "AIX 7.2 - netstat -rn",
+			parse: parseRoutesFromNetstat,
+			routeOut: `Routing tables
+Destination        Gateway           Flags   Refs     Use  If   Exp  Groups
+
+Route Tree for Protocol Family 2 (Internet):
+default            10.0.0.1          UG        3   123456 en0      -      -
+10.0.0.0           10.0.0.5          UHSb      0        0 en0      -      -      =>
+10.0.0/24          10.0.0.5          U         5    21012 en0      -      -
+10.0.0.5           127.0.0.1         UGHS      0       19 lo0      -      -
+127/8              127.0.0.1         U        10   111000 lo0      -      -
+
+Route Tree for Protocol Family 24 (Internet v6):
+::1%1              ::1%1             UH        1    20000 lo0      -      -
+`,
+			want: []string{
+				"default via 10.0.0.1 dev en0",
+				"10.0.0.0 via 10.0.0.5 dev en0",
+				"10.0.0.0/24 via 10.0.0.5 dev en0",
+				"10.0.0.5 via 127.0.0.1 dev lo0",
+				"127.0.0.0/8 via 127.0.0.1 dev lo0",
+				"::1%1 via ::1%1 dev lo0",
+			},
+		},
+		{
+			name:  "Solaris 11 - netstat -rnv",
+			parse: parseRoutesFromSolarisNetstat,
+			routeOut: `
+IRE Table: IPv4
+  Destination             Mask           Gateway          Device  MTU  Ref Flg  Out  In/Fwd
+-------------------- --------------- -------------------- ------ ----- --- --- ----- ------
+default              0.0.0.0         10.0.2.2                     1500   2 UG      27      0
+10.0.2.0             255.255.255.0   10.0.2.15            e1000g0  1500   3 U       38      0
+127.0.0.1            255.255.255.255 127.0.0.1            lo0     8232   2 UH     192    192
+
+IRE Table: IPv6
+  Destination/Mask            Gateway                    If    PMTU   Rtt  Ref Flags  Out   In/Fwd
+--------------------------- --------------------------- ----- ------ ----- --- ----- ------ ------
+::1                         ::1                         lo0    8252*     0   1 UH       0      0
+fe80::/10                   fe80::a00:27ff:fe3d:1a2b    e1000g0 1500*    0   1 U        0      0
+default                     fe80::1                     e1000g0 1500*    0   1 UG       0      0
+`,
+			want: []string{
+				"default via 10.0.2.2",
+				"10.0.2.0/24 via 10.0.2.15 dev e1000g0",
+				"127.0.0.1 via 127.0.0.1 dev lo0",
+				"::1 via ::1 dev lo0",
+				"fe80::/10 via fe80::a00:27ff:fe3d:1a2b dev e1000g0",
+				"default via fe80::1 dev e1000g0",
+			},
+		},
+		{
+			name:  "z/OS 2.5 - onetstat -r",
+			parse: parseRoutesFromOnetstat,
+			routeOut: `MVS TCP/IP NETSTAT CS V2R5       TCPIP Name: TCPIP           12:52:35
+IPv4 Destinations
+Destination        Gateway         Flags    Refcnt     Interface
+-----------        -------         -----    ------     ---------
+Default            9.12.20.1       UGO      0000000000 OSAQDIO4
+9.12.20.0/24       0.0.0.0         UO       0000000000 OSAQDIO4
+9.12.20.62/32      0.0.0.0         UH       0000000000 OSAQDIO4
+127.0.0.1/32       0.0.0.0         UH       0000000000 LOOPBACK
+IPv6 Destinations
+DestIP:   Default
+  Gw:     fe80::1
+  Intf:   OSAQDIO46         Refcnt:  0000000000
+  Flgs:   UGO               MTU:     1492
+DestIP:   ::1/128
+  Gw:     ::
+  Intf:   LOOPBACK6         Refcnt:  0000000000
+  Flgs:   UH                MTU:     65535
+DestIP:   2001:db8:1::/64
+  Gw:     ::
+  Intf:   OSAQDIO46         Refcnt:  0000000000
+  Flgs:   UO                MTU:     1492`,
+			want: []string{
+				"default via 9.12.20.1 dev OSAQDIO4",
+				"9.12.20.0/24 dev OSAQDIO4",
+				"9.12.20.62 dev OSAQDIO4",
+				"127.0.0.1 dev LOOPBACK",
+				"default via fe80::1 dev OSAQDIO46",
+				"::1 dev LOOPBACK6",
+				"2001:db8:1::/64 dev OSAQDIO46",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			routes, err := tc.parse(tc.routeOut)
+			if err != nil {
+				t.Fatalf("unable to parse routes: %v", err)
+			}
+
+			got := make([]string, 0, len(routes))
+			for _, route := range routes {
+				if route.Family != route.Destination.Type() {
+					t.Errorf("route %q has family %s", route, route.Family)
+				}
+				got = append(got, route.String())
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %+q; want %+q", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_defaultInterfaceNames_scopedDefaults(t *testing.T) {
+	// macOS adds a default route scoped to each secondary interface, which
+	// is only used by sockets bound to it.
+	routes, err := parseRoutesFromNetstat(`Routing tables
+
+Internet:
+Destination        Gateway            Flags               Netif Expire
+default            192.168.1.1        UGScg                 en0
+default            10.10.0.1          UGScIg                en7
+10.10.0/24         link#17            UCS                   en7      !
+192.168.1          link#6             UCS                   en0      !
+
+Internet6:
+Destination                             Gateway                                 Flags               Netif Expire
+default                                 fe80::%utun0                            UGcIg               utun0
+default                                 fe80::%utun1                            UGcIg               utun1
+::1                                     ::1                                     UHL                   lo0
+fe80::%utun0/64                         fe80::a2c6:2a01:81a5:66a8%utun0         UcI                 utun0
+`)
+	if err != nil {
+		t.Fatalf("unable to parse routes: %v", err)
+	}
+
+	noDefault := func() (string, error) {
+		t.Fatal("unexpected call to GetDefaultInterfaceName()")
+		return "", nil
+	}
+	for _, test := range []struct {
+		family SockAddrType
+		want   []string
+	}{
+		{TypeIPv4, []string{"en0"}},
+		{TypeIPv6, []string{}},
+	} {
+		ifNames, err := defaultInterfaceNames(routes, nil, test.family, noDefault)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(ifNames, test.want) {
+			t.Errorf("%s: got %+q; want %+q", test.family, ifNames, test.want)
+		}
+	}
+}
+
+func Test_defaultInterfaceNames_multipath(t *testing.T) {
+	// An ECMP default route is printed by `ip route` without an interface,
+	// which each of its next hops has.
+	routes, err := parseRoutesFromIPCmd(TypeIPv4, `default proto static metric 20
+	nexthop via 192.0.2.1 dev eth0 weight 1
+	nexthop via 198.51.100.1 dev eth1 weight 1
+default via 203.0.113.1 dev eth2 proto dhcp src 203.0.113.5 metric 100
+192.0.2.0/24 dev eth0 proto kernel scope link src 192.0.2.5
+198.51.100.0/24 dev eth1 proto kernel scope link src 198.51.100.5
+203.0.113.0/24 dev eth2 proto kernel scope link src 203.0.113.5 metric 100
+`)
+	if err != nil {
+		t.Fatalf("unable to parse routes: %v", err)
+	}
+
+	noDefault := func() (string, error) {
+		t.Fatal("unexpected call to GetDefaultInterfaceName()")
+		return "", nil
+	}
+	ifNames, err := defaultInterfaceNames(routes, nil, TypeIPv4, noDefault)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"eth0", "eth1", "eth2"}; !slices.Equal(ifNames, want) {
+		t.Errorf("got %+q; want %+q", ifNames, want)
+	}
+}
+
+func Test_parseRoutes_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		parse    func(string) ([]Route, error)
+		routeOut string
+	}{
+		{
+			name:  "Linux /proc/net/route - non-contiguous mask",
+			parse: parseRoutesFromProcNetRoute,
+			routeOut: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	000200C0	00000000	0001	0	0	0	00FF00FF	0	0	0
+`,
+		},
+		{
+			name:     "Linux /proc/net/ipv6_route - short address",
+			parse:    parseRoutesFromProcNetIPv6Route,
+			routeOut: "fd00 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001 eth0\n",
+		},
+		{
+			name: "Linux ip route - invalid metric",
+			parse: func(routeOut string) ([]Route, error) {
+				return parseRoutesFromIPCmd(TypeIPv4, routeOut)
+			},
+			routeOut: "default via 192.0.2.1 dev eth0 metric high\n",
+		},
+		{
+			name:  "netstat - invalid destination",
+			parse: parseRoutesFromNetstat,
+			routeOut: `Internet:
+Destination        Gateway            Flags               Netif Expire
+192.168.1/33       link#6             UCS                   en0      !
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if routes, err := tc.parse(tc.routeOut); err == nil {
+				t.Fatalf("expected an error, got %v", routes)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"runtime"
+	"sort"
+)
+
+// SnapshotVersion is the version of the Snapshot format written by
+// TakeSnapshot() and the only version accepted by ReadSnapshot().
+const SnapshotVersion = 1
+
+// Snapshot is a JSON serializable record of a host's network configuration:
+// its interfaces and their addresses, the interface with the default route,
+// its routing table and the output of the commands used to find them.  A Snapshot taken on one
+// host can be replayed on another through Provider(), which makes it possible
+// to reproduce the output of a template exactly as it was evaluated on the
+// original host.
+type Snapshot struct {
+	// Version is the version of the snapshot format, see SnapshotVersion.
+	Version int `json:"version"`
+
+	// GOOS and GOARCH identify the platform the snapshot was taken on.
+	GOOS   string `json:"goos"`
+	GOARCH string `json:"goarch"`
+
+	// DefaultInterface is the name of the interface with the default route.
+	// If it could not be determined, DefaultInterfaceError records why.
+	DefaultInterface      string `json:"default_interface"`
+	DefaultInterfaceError string `json:"default_interface_error,omitempty"`
+
+	// Interfaces is the list of network interfaces and their addresses.
+	Interfaces []SnapshotInterface `json:"interfaces"`
+
+	// Routes is the routing table.  If it could not be read, RoutesError
+	// records why.  Snapshots without routes replay DefaultInterface as the
+	// default interface of both address families.
+	Routes      []SnapshotRoute `json:"routes,omitempty"`
+	RoutesError string          `json:"routes_error,omitempty"`
+
+	// RouteCommands is the output of the platform-specific commands run to
+	// find the default interface and read the routing table, sorted by name.
+	// Platforms that read the routing table from the kernel, such as Linux
+	// with netlink or /proc, do not run any.
+	RouteCommands []SnapshotCommand `json:"route_commands,omitempty"`
+}
+
+// SnapshotInterface is a network interface in a Snapshot.  The kind and link
+// state are only recorded on platforms that report them.
+type SnapshotInterface struct {
+	Name         string   `json:"name"`
+	Index        int      `json:"index"`
+	MTU          int      `json:"mtu"`
+	HardwareAddr string   `json:"hardware_addr,omitempty"`
+	Flags        string   `json:"flags"`
+	Addresses    []string `json:"addresses"`
+	Kind         string   `json:"kind,omitempty"`
+	OperState    string   `json:"operstate,omitempty"`
+	Carrier      string   `json:"carrier,omitempty"`
+	Speed        int      `json:"speed,omitempty"`
+	Duplex       string   `json:"duplex,omitempty"`
+
+	// AddressInfo is the flags and scope of the Addresses that have any,
+	// keyed by the address as it appears in Addresses.
+	AddressInfo map[string]SnapshotAddrInfo `json:"address_info,omitempty"`
+}
+
+// SnapshotAddrInfo is the flags and scope of an address in a Snapshot, in the
+// format of the address_flags and scope attributes.
+type SnapshotAddrInfo struct {
+	Flags string `json:"flags,omitempty"`
+	Scope string `json:"scope,omitempty"`
+}
+
+// SnapshotRoute is a route in a Snapshot.  Its address family is that of
+// Destination.
+type SnapshotRoute struct {
+	Destination string `json:"destination"`
+	Gateway     string `json:"gateway,omitempty"`
+	Interface   string `json:"interface,omitempty"`
+	Metric      uint32 `json:"metric,omitempty"`
+	Source      string `json:"source,omitempty"`
+	Table       uint32 `json:"table,omitempty"`
+}
+
+// SnapshotCommand is the output of a route command in a Snapshot.
+type SnapshotCommand struct {
+	Name    string   `json:"name"`
+	Command []string `json:"command"`
+	Output  string   `json:"output"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// TakeSnapshot records the host's network interfaces, their addresses, link
+// state and address flags, the interface with the default route, the routing table and the output of the
+// route commands that were run to find them.  Failing to determine the default
+// interface, to read the routing table or to run a route command is recorded
+// in the Snapshot rather than returned as an error.
+func TakeSnapshot() (*Snapshot, error) {
+	s := &Snapshot{
+		Version: SnapshotVersion,
+		GOOS:    runtime.GOOS,
+		GOARCH:  runtime.GOARCH,
+	}
+
+	p := OSInterfaceProvider{}
+	ifs, err := p.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	addrInfo, err := p.allAddrInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	s.Interfaces = make([]SnapshotInterface, 0, len(ifs))
+	for _, intf := range ifs {
+		addrs, err := p.Addrs(intf)
+		if err != nil {
+			return nil, err
+		}
+
+		linkInfo, err := p.LinkInfo(intf)
+		if err != nil {
+			return nil, err
+		}
+
+		snapIf := SnapshotInterface{
+			Name:      intf.Name,
+			Index:     intf.Index,
+			MTU:       intf.MTU,
+			Addresses: make([]string, 0, len(addrs)),
+			Kind:      string(linkInfo.Kind),
+			OperState: string(linkInfo.OperState),
+			Speed:     linkInfo.Speed,
+			Duplex:    linkInfo.Duplex,
+		}
+		if linkInfo.Carrier != LinkCarrierUnknown {
+			snapIf.Carrier = linkInfo.Carrier.String()
+		}
+		if len(intf.HardwareAddr) > 0 {
+			snapIf.HardwareAddr = intf.HardwareAddr.String()
+		}
+		if intf.Flags != 0 {
+			snapIf.Flags = intf.Flags.String()
+		}
+		for _, addr := range addrs {
+			snapIf.Addresses = append(snapIf.Addresses, addr.String())
+
+			prefix, err := netip.ParsePrefix(addr.String())
+			if err != nil {
+				continue
+			}
+			info, ok := addrInfo[intf.Index][prefix.Addr()]
+			if !ok || info == (AddrInfo{}) {
+				continue
+			}
+
+			snapAddrInfo := SnapshotAddrInfo{Scope: info.Scope.String()}
+			if info.Flags != 0 {
+				snapAddrInfo.Flags = info.Flags.String()
+			}
+			if snapIf.AddressInfo == nil {
+				snapIf.AddressInfo = make(map[string]SnapshotAddrInfo)
+			}
+			snapIf.AddressInfo[addr.String()] = snapAddrInfo
+		}
+		s.Interfaces = append(s.Interfaces, snapIf)
+	}
+
+	ri, err := NewRouteInfo()
+	if err != nil {
+		s.DefaultInterfaceError = err.Error()
+		s.RoutesError = err.Error()
+		return s, nil
+	}
+
+	// Commands are recorded as GetDefaultInterfaceName() and Routes() run
+	// them rather than being run again, so the recorded output is the one
+	// the snapshot was taken from.
+	ri.record = func(name string, cmd []string, out []byte, err error) {
+		snapCmd := SnapshotCommand{
+			Name:    name,
+			Command: cmd,
+			Output:  string(out),
+		}
+		if err != nil {
+			snapCmd.Error = err.Error()
+		}
+		s.RouteCommands = append(s.RouteCommands, snapCmd)
+	}
+
+	if s.DefaultInterface, err = ri.GetDefaultInterfaceName(); err != nil {
+		s.DefaultInterfaceError = err.Error()
+	}
+
+	routes, err := ri.Routes()
+	if err != nil {
+		s.RoutesError = err.Error()
+	}
+	s.Routes = make([]SnapshotRoute, 0, len(routes))
+	for _, route := range routes {
+		snapRoute := SnapshotRoute{
+			Destination: route.Destination.String(),
+			Interface:   route.Interface,
+			Metric:      route.Metric,
+			Table:       route.Table,
+		}
+		if route.Gateway != nil {
+			snapRoute.Gateway = route.Gateway.String()
+		}
+		if route.Source != nil {
+			snapRoute.Source = route.Source.String()
+		}
+		s.Routes = append(s.Routes, snapRoute)
+	}
+
+	sort.Slice(s.RouteCommands, func(i, j int) bool {
+		return s.RouteCommands[i].Name < s.RouteCommands[j].Name
+	})
+
+	return s, nil
+}
+
+// ReadSnapshot decodes a JSON encoded Snapshot from r.  An error is returned
+// if the snapshot's version is not SnapshotVersion.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("unable to decode snapshot: %w", err)
+	}
+
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d, expected %d", s.Version, SnapshotVersion)
+	}
+
+	return &s, nil
+}
+
+// Provider returns a StaticInterfaceProvider that replays the snapshot's
+// interfaces, addresses, link state, address flags and scopes, default
+// interface and routing table.  Interfaces without addresses are omitted, as
+// GetAllInterfaces() never returns them.  An error is returned if an address,
+// hardware address, flag, carrier, scope or route in the snapshot can not be
+// parsed.
+func (s *Snapshot) Provider() (*StaticInterfaceProvider, error) {
+	var ifAddrs IfAddrs
+	for _, snapIf := range s.Interfaces {
+		intf := net.Interface{
+			Index: snapIf.Index,
+			MTU:   snapIf.MTU,
+			Name:  snapIf.Name,
+		}
+
+		var err error
+		if snapIf.HardwareAddr != "" {
+			if intf.HardwareAddr, err = net.ParseMAC(snapIf.HardwareAddr); err != nil {
+				return nil, fmt.Errorf("invalid hardware address for interface %+q: %w", snapIf.Name, err)
+			}
+		}
+		if intf.Flags, err = parseNetFlags(snapIf.Flags); err != nil {
+			return nil, fmt.Errorf("invalid flags for interface %+q: %w", snapIf.Name, err)
+		}
+		carrier, err := parseLinkCarrier(snapIf.Carrier)
+		if err != nil {
+			return nil, fmt.Errorf("invalid carrier for interface %+q: %w", snapIf.Name, err)
+		}
+
+		for _, addr := range snapIf.Addresses {
+			ipAddr, err := NewIPAddr(addr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %+q for interface %+q: %w", addr, snapIf.Name, err)
+			}
+
+			ifAddr := IfAddr{
+				SockAddr:  ipAddr,
+				Interface: intf,
+				Kind:      InterfaceKind(snapIf.Kind),
+				OperState: OperState(snapIf.OperState),
+				Carrier:   carrier,
+				Speed:     snapIf.Speed,
+				Duplex:    snapIf.Duplex,
+			}
+			if info, ok := snapIf.AddressInfo[addr]; ok {
+				if ifAddr.AddrFlags, err = parseAddrFlags(info.Flags); err != nil {
+					return nil, fmt.Errorf("invalid flags for address %+q of interface %+q: %w", addr, snapIf.Name, err)
+				}
+				if ifAddr.AddrScope, err = parseAddrScope(info.Scope); err != nil {
+					return nil, fmt.Errorf("invalid scope for address %+q of interface %+q: %w", addr, snapIf.Name, err)
+				}
+			}
+			ifAddrs = append(ifAddrs, ifAddr)
+		}
+	}
+
+	var routes []Route
+	for _, snapRoute := range s.Routes {
+		route, err := snapRoute.route()
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+
+	p := NewStaticInterfaceProvider(ifAddrs, s.DefaultInterface)
+	p.RouteTable = routes
+	return p, nil
+}
+
+// route parses the route recorded in r.
+func (r SnapshotRoute) route() (Route, error) {
+	route := Route{
+		Interface: r.Interface,
+		Metric:    r.Metric,
+		Table:     r.Table,
+	}
+
+	var err error
+	if route.Destination, err = NewIPAddr(r.Destination); err != nil {
+		return Route{}, fmt.Errorf("invalid destination %+q of route: %w", r.Destination, err)
+	}
+	route.Family = route.Destination.Type()
+	if r.Gateway != "" {
+		if route.Gateway, err = NewIPAddr(r.Gateway); err != nil {
+			return Route{}, fmt.Errorf("invalid gateway %+q of route to %s: %w", r.Gateway, r.Destination, err)
+		}
+	}
+	if r.Source != "" {
+		if route.Source, err = NewIPAddr(r.Source); err != nil {
+			return Route{}, fmt.Errorf("invalid source %+q of route to %s: %w", r.Source, r.Destination, err)
+		}
+	}
+
+	return route, nil
+}
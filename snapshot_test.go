@@ -0,0 +1,294 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+const snapshotFixture = `{
+  "version": 1,
+  "goos": "linux",
+  "goarch": "amd64",
+  "default_interface": "eth0",
+  "interfaces": [
+    {
+      "name": "lo",
+      "index": 1,
+      "mtu": 65536,
+      "flags": "up|loopback|running",
+      "addresses": ["127.0.0.1/8", "::1/128"]
+    },
+    {
+      "name": "ifb0",
+      "index": 2,
+      "mtu": 1500,
+      "flags": "broadcast",
+      "addresses": []
+    },
+    {
+      "name": "eth0",
+      "index": 3,
+      "mtu": 9001,
+      "hardware_addr": "02:42:ac:11:00:02",
+      "flags": "up|broadcast|multicast|running",
+      "addresses": ["10.1.2.3/16", "fe80::42:acff:fe11:2/64"],
+      "kind": "physical",
+      "operstate": "up",
+      "carrier": "up",
+      "speed": 10000,
+      "duplex": "full",
+      "address_info": {
+        "10.1.2.3/16": {"scope": "global"},
+        "fe80::42:acff:fe11:2/64": {"flags": "permanent", "scope": "link"}
+      }
+    }
+  ],
+  "routes": [
+    {"destination": "0.0.0.0/0", "gateway": "10.1.0.1", "interface": "eth0", "table": 254},
+    {"destination": "10.1.0.0/16", "interface": "eth0", "source": "10.1.2.3", "table": 254},
+    {"destination": "fe80::/64", "interface": "eth0", "metric": 256, "table": 254}
+  ],
+  "route_commands": [
+    {
+      "name": "ip",
+      "command": ["/sbin/ip", "route"],
+      "output": "default via 10.1.0.1 dev eth0\n"
+    }
+  ]
+}`
+
+func TestReadSnapshot(t *testing.T) {
+	snapshot, err := sockaddr.ReadSnapshot(strings.NewReader(snapshotFixture))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.DefaultInterface != "eth0" || len(snapshot.Interfaces) != 3 || len(snapshot.Routes) != 3 || len(snapshot.RouteCommands) != 1 {
+		t.Fatalf("unexpected snapshot: %+v", snapshot)
+	}
+
+	// Re-encoding the snapshot must not lose any information.
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, err := sockaddr.ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a, _ := json.Marshal(snapshot)
+	b, _ := json.Marshal(again)
+	if !bytes.Equal(a, b) {
+		t.Errorf("round trip mismatch:\n%s\n%s", a, b)
+	}
+}
+
+func TestReadSnapshot_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "invalid json",
+			input: `{"version": 1,`,
+		},
+		{
+			name:  "missing version",
+			input: `{"interfaces": []}`,
+		},
+		{
+			name:  "future version",
+			input: `{"version": 2, "interfaces": []}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := sockaddr.ReadSnapshot(strings.NewReader(test.input)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestSnapshot_Provider(t *testing.T) {
+	snapshot, err := sockaddr.ReadSnapshot(strings.NewReader(snapshotFixture))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p, err := snapshot.Provider()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ifAddrs, err := sockaddr.GetAllInterfacesFrom(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"lo 127.0.0.1/8", "lo ::1", "eth0 10.1.2.3/16", "eth0 fe80::42:acff:fe11:2%eth0/64"}
+	if len(ifAddrs) != len(want) {
+		t.Fatalf("got %d IfAddrs, want %d: %v", len(ifAddrs), len(want), ifAddrs)
+	}
+	for i, ifAddr := range ifAddrs {
+		if got := ifAddr.Name + " " + ifAddr.SockAddr.String(); got != want[i] {
+			t.Errorf("IfAddr %d: got %q, want %q", i, got, want[i])
+		}
+	}
+
+	eth0 := ifAddrs[2].Interface
+	if eth0.Index != 3 || eth0.MTU != 9001 || eth0.HardwareAddr.String() != "02:42:ac:11:00:02" ||
+		eth0.Flags != net.FlagUp|net.FlagBroadcast|net.FlagMulticast|net.FlagRunning {
+		t.Errorf("interface not restored: %+v", eth0)
+	}
+	if got := ifAddrs[2]; got.Kind != sockaddr.InterfaceKindPhysical || got.OperState != sockaddr.OperStateUp ||
+		got.Carrier != sockaddr.LinkCarrierUp || got.Speed != 10000 || got.Duplex != "full" {
+		t.Errorf("link state not restored: %+v", got)
+	}
+	if got := ifAddrs[2]; got.AddrFlags != 0 || got.AddrScope != sockaddr.AddrScopeGlobal {
+		t.Errorf("address info of %s not restored: %+v", got.SockAddr, got)
+	}
+	if got := ifAddrs[3]; got.AddrFlags != sockaddr.AddrFlagPermanent || got.AddrScope != sockaddr.AddrScopeLink {
+		t.Errorf("address info of %s not restored: %+v", got.SockAddr, got)
+	}
+	if got := ifAddrs[0]; got.Kind != "" || got.AddrScope != sockaddr.AddrScopeUnknown {
+		t.Errorf("unexpected link state or address info: %+v", got)
+	}
+
+	permanent, err := sockaddr.IncludeIfs("flags", "permanent", ifAddrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(permanent) != 1 || permanent[0].Name != "eth0" {
+		t.Errorf("unexpected permanent addresses: %v", permanent)
+	}
+
+	ip, err := sockaddr.GetPrivateIPFrom(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ip != "10.1.2.3" {
+		t.Errorf("GetPrivateIPFrom() = %q, want %q", ip, "10.1.2.3")
+	}
+
+	// The routing table is replayed, so routes can be looked up offline.
+	route, err := sockaddr.LookupRouteFrom(p, sockaddr.MustIPv4Addr("8.8.8.8"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := route.String(), "default via 10.1.0.1 dev eth0 src 10.1.2.3 table 254"; got != want {
+		t.Errorf("LookupRouteFrom() = %q, want %q", got, want)
+	}
+	if route.Family != sockaddr.TypeIPv4 {
+		t.Errorf("route family = %s, want %s", route.Family, sockaddr.TypeIPv4)
+	}
+}
+
+func TestSnapshot_Provider_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		intf  sockaddr.SnapshotInterface
+		route sockaddr.SnapshotRoute
+	}{
+		{
+			name: "invalid address",
+			intf: sockaddr.SnapshotInterface{Name: "eth0", Addresses: []string{"10.1.2.300/16"}},
+		},
+		{
+			name: "invalid hardware address",
+			intf: sockaddr.SnapshotInterface{Name: "eth0", HardwareAddr: "02:42"},
+		},
+		{
+			name: "invalid flag",
+			intf: sockaddr.SnapshotInterface{Name: "eth0", Flags: "up|bogus"},
+		},
+		{
+			name: "invalid carrier",
+			intf: sockaddr.SnapshotInterface{Name: "eth0", Carrier: "sideways"},
+		},
+		{
+			name: "invalid address flags",
+			intf: sockaddr.SnapshotInterface{
+				Name:        "eth0",
+				Addresses:   []string{"2001:db8::5/64"},
+				AddressInfo: map[string]sockaddr.SnapshotAddrInfo{"2001:db8::5/64": {Flags: "permanent|bogus"}},
+			},
+		},
+		{
+			name: "invalid address scope",
+			intf: sockaddr.SnapshotInterface{
+				Name:        "eth0",
+				Addresses:   []string{"2001:db8::5/64"},
+				AddressInfo: map[string]sockaddr.SnapshotAddrInfo{"2001:db8::5/64": {Scope: "galaxy"}},
+			},
+		},
+		{
+			name:  "invalid route destination",
+			route: sockaddr.SnapshotRoute{Destination: "default", Interface: "eth0"},
+		},
+		{
+			name:  "invalid route gateway",
+			route: sockaddr.SnapshotRoute{Destination: "0.0.0.0/0", Gateway: "10.1.0.300"},
+		},
+		{
+			name:  "invalid route source",
+			route: sockaddr.SnapshotRoute{Destination: "10.1.0.0/16", Source: "eth0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot := sockaddr.Snapshot{
+				Version:    sockaddr.SnapshotVersion,
+				Interfaces: []sockaddr.SnapshotInterface{test.intf},
+			}
+			if test.route.Destination != "" {
+				snapshot.Routes = []sockaddr.SnapshotRoute{test.route}
+			}
+			if _, err := snapshot.Provider(); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	snapshot, err := sockaddr.TakeSnapshot()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p, err := snapshot.Provider()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A snapshot of the host replays the host's addresses.
+	want, err := sockaddr.GetAllInterfaces()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := sockaddr.GetAllInterfacesFrom(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d IfAddrs, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || !got[i].SockAddr.Equal(want[i].SockAddr) ||
+			got[i].AddrFlags != want[i].AddrFlags || got[i].AddrScope != want[i].AddrScope ||
+			got[i].Kind != want[i].Kind || got[i].OperState != want[i].OperState || got[i].Carrier != want[i].Carrier ||
+			got[i].Speed != want[i].Speed || got[i].Duplex != want[i].Duplex {
+			t.Errorf("IfAddr %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
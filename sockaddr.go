@@ -18,6 +18,7 @@ const (
 	TypeUnix    SockAddrType = 0x1
 	TypeIPv4    SockAddrType = 0x2
 	TypeIPv6    SockAddrType = 0x4
+	TypeVSock   SockAddrType = 0x8
 
 	// TypeIP is the union of TypeIPv4 and TypeIPv6
 	TypeIP = 0x6
@@ -57,19 +58,25 @@ func init() {
 }
 
 // New creates a new SockAddr from the string.  The order in which New()
-// attempts to construct a SockAddr is: IPv4Addr, IPv6Addr, SockAddrUnix.
+// attempts to construct a SockAddr is: VSock (only for strings beginning with
+// "vsock://"), IPv4Addr, IPv6Addr, SockAddrUnix.
 //
 // NOTE: New() relies on the heuristic wherein if the path begins with either a
 // '.'  or '/' character before creating a new UnixSock.  For UNIX sockets that
 // are absolute paths or are nested within a sub-directory, this works as
 // expected, however if the UNIX socket is contained in the current working
 // directory, this will fail unless the path begins with "./"
-// (e.g. "./my-local-socket").  Calls directly to NewUnixSock() do not suffer
+// (e.g. "./my-local-socket").  Linux abstract socket names beginning with '@'
+// (e.g. "@my-service") are also recognized.  Calls directly to NewUnixSock() do not suffer
 // this limitation.  Invalid IP addresses such as "256.0.0.0/-1" will run afoul
 // of this heuristic and be assumed to be a valid UNIX socket path (which they
 // are, but it is probably not what you want and you won't realize it until you
 // stat(2) the file system to discover it doesn't exist).
 func NewSockAddr(s string) (SockAddr, error) {
+	if strings.HasPrefix(s, vsockScheme) {
+		return NewVSock(s)
+	}
+
 	ipv4Addr, err := NewIPv4Addr(s)
 	if err == nil {
 		return ipv4Addr, nil
@@ -81,15 +88,16 @@ func NewSockAddr(s string) (SockAddr, error) {
 	}
 
 	// Check to make sure the string begins with either a '.' or '/', or
-	// contains a '/'.
-	if len(s) > 1 && (strings.ContainsAny(s[0:1], "./") || bytes.ContainsAny([]byte(s), "/")) {
+	// contains a '/', or is a Linux abstract socket name beginning with '@'
+	// or a NUL byte.
+	if len(s) > 1 && (strings.ContainsAny(s[0:1], "./@\x00") || bytes.ContainsAny([]byte(s), "/")) {
 		unixSock, err := NewUnixSock(s)
 		if err == nil {
 			return unixSock, nil
 		}
 	}
 
-	return nil, fmt.Errorf("unable to convert %q to an IPv4 or IPv6 address, or a UNIX Socket", s)
+	return nil, fmt.Errorf("unable to convert %q to an IPv4 or IPv6 address, a UNIX Socket, or a vsock address", s)
 }
 
 // ToIPAddr returns an IPAddr type or nil if the type conversion fails.
@@ -131,6 +139,16 @@ func ToUnixSock(sa SockAddr) *UnixSock {
 	}
 }
 
+// ToVSock returns a VSock type or nil if the type conversion fails.
+func ToVSock(sa SockAddr) *VSock {
+	switch v := sa.(type) {
+	case VSock:
+		return &v
+	default:
+		return nil
+	}
+}
+
 // SockAddrAttr returns a string representation of an attribute for the given
 // SockAddr.
 func SockAddrAttr(sa SockAddr, selector AttrName) string {
@@ -143,7 +161,7 @@ func SockAddrAttr(sa SockAddr, selector AttrName) string {
 }
 
 // String() for SockAddrType returns a string representation of the
-// SockAddrType (e.g. "IPv4", "IPv6", "UNIX", "IP", or "unknown").
+// SockAddrType (e.g. "IPv4", "IPv6", "UNIX", "VSOCK", "IP", or "unknown").
 func (sat SockAddrType) String() string {
 	switch sat {
 	case TypeIPv4:
@@ -155,6 +173,8 @@ func (sat SockAddrType) String() string {
 	// 	return "IP"
 	case TypeUnix:
 		return "UNIX"
+	case TypeVSock:
+		return "VSOCK"
 	default:
 		panic("unsupported type")
 	}
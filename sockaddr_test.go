@@ -317,12 +317,13 @@ func TestSockAddrAttrs(t *testing.T) {
 
 func TestToFoo(t *testing.T) {
 	tests := []struct {
-		name     string
-		sa       sockaddr.SockAddr
-		passIP   bool
-		passIPv4 bool
-		passIPv6 bool
-		passUnix bool
+		name      string
+		sa        sockaddr.SockAddr
+		passIP    bool
+		passIPv4  bool
+		passIPv6  bool
+		passUnix  bool
+		passVSock bool
 	}{
 		{
 			name:     "ipv4",
@@ -341,6 +342,11 @@ func TestToFoo(t *testing.T) {
 			sa:       sockaddr.MustUnixSock("/tmp/foo"),
 			passUnix: true,
 		},
+		{
+			name:      "vsock",
+			sa:        sockaddr.MustVSock("vsock://3:1024"),
+			passVSock: true,
+		},
 	}
 
 	for i, test := range tests {
@@ -354,6 +360,12 @@ func TestToFoo(t *testing.T) {
 			t.Fatalf("bad")
 		}
 
+		switch vs := sockaddr.ToVSock(test.sa); {
+		case vs == nil && test.passVSock,
+			vs != nil && !test.passVSock:
+			t.Fatalf("bad")
+		}
+
 		switch ip := sockaddr.ToIPAddr(test.sa); {
 		case ip == nil && test.passIP,
 			ip != nil && !test.passIP:
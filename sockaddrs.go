@@ -5,6 +5,8 @@ package sockaddr
 
 import (
 	"bytes"
+	"fmt"
+	"net/netip"
 	"sort"
 )
 
@@ -14,6 +16,21 @@ type SockAddrs []SockAddr
 func (s SockAddrs) Len() int      { return len(s) }
 func (s SockAddrs) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
+// NewSockAddrsFromNetipPrefixes creates a SockAddrs from a list of
+// netip.Prefix values.  See NewIPAddrFromNetipPrefix() for details.
+func NewSockAddrsFromNetipPrefixes(prefixes []netip.Prefix) (SockAddrs, error) {
+	sas := make(SockAddrs, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		ipAddr, err := NewIPAddrFromNetipPrefix(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert %v to a SockAddr: %v", prefix, err)
+		}
+		sas = append(sas, ipAddr)
+	}
+
+	return sas, nil
+}
+
 // CmpAddrFunc is the function signature that must be met to be used in the
 // OrderedAddrBy multiAddrSorter
 type CmpAddrFunc func(p1, p2 *SockAddr) int
@@ -105,6 +122,8 @@ func AscAddress(p1Ptr, p2Ptr *SockAddr) int {
 		return v.CmpAddress(p2)
 	case UnixSock:
 		return v.CmpAddress(p2)
+	case VSock:
+		return v.CmpAddress(p2)
 	default:
 		return sortDeferDecision
 	}
@@ -121,6 +140,8 @@ func AscPort(p1Ptr, p2Ptr *SockAddr) int {
 		return v.CmpPort(p2)
 	case IPv6Addr:
 		return v.CmpPort(p2)
+	case VSock:
+		return v.CmpPort(p2)
 	default:
 		return sortDeferDecision
 	}
@@ -162,12 +183,12 @@ func AscNetworkSize(p1Ptr, p2Ptr *SockAddr) int {
 }
 
 // AscType is a sorting function to sort "more secure" types before
-// "less-secure" types.
+// "less-secure" types: UNIX sockets, vsock, IPv4, then IPv6.
 func AscType(p1Ptr, p2Ptr *SockAddr) int {
 	p1 := *p1Ptr
 	p2 := *p2Ptr
-	p1Type := p1.Type()
-	p2Type := p2.Type()
+	p1Type := sockAddrTypeRank(p1.Type())
+	p2Type := sockAddrTypeRank(p2.Type())
 	switch {
 	case p1Type < p2Type:
 		return sortReceiverBeforeArg
@@ -180,6 +201,23 @@ func AscType(p1Ptr, p2Ptr *SockAddr) int {
 	}
 }
 
+// sockAddrTypeRank returns the AscType() sort order of a SockAddrType.
+// Host-local transports sort before network transports.
+func sockAddrTypeRank(t SockAddrType) int {
+	switch t {
+	case TypeUnix:
+		return 1
+	case TypeVSock:
+		return 2
+	case TypeIPv4:
+		return 3
+	case TypeIPv6:
+		return 4
+	default:
+		return 0
+	}
+}
+
 // FilterByType returns two lists: a list of matched and unmatched SockAddrs
 func (sas SockAddrs) FilterByType(type_ SockAddrType) (matched, excluded SockAddrs) {
 	matched = make(SockAddrs, 0, len(sas))
@@ -194,3 +232,27 @@ func (sas SockAddrs) FilterByType(type_ SockAddrType) (matched, excluded SockAdd
 	}
 	return matched, excluded
 }
+
+// NetipAddrs returns the netip.Addr of every IP address in sas.  Non-IP
+// SockAddrs are skipped.
+func (sas SockAddrs) NetipAddrs() []netip.Addr {
+	addrs := make([]netip.Addr, 0, len(sas))
+	for _, sa := range sas {
+		if ip := ToIPAddr(sa); ip != nil {
+			addrs = append(addrs, (*ip).NetipAddr())
+		}
+	}
+	return addrs
+}
+
+// NetipPrefixes returns the netip.Prefix of every IP address in sas.  Non-IP
+// SockAddrs are skipped.
+func (sas SockAddrs) NetipPrefixes() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(sas))
+	for _, sa := range sas {
+		if ip := ToIPAddr(sa); ip != nil {
+			prefixes = append(prefixes, (*ip).NetipPrefix())
+		}
+	}
+	return prefixes
+}
@@ -0,0 +1,223 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+)
+
+// ScanError is returned by the sql.Scanner implementations when a database
+// value can not be converted into a SockAddr.
+type ScanError struct {
+	// Dest is the name of the type being scanned into (e.g. "IPv4Addr").
+	Dest string
+
+	// Src is the value passed to Scan().
+	Src any
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("unable to scan %T into a %s: %v", e.Src, e.Dest, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// Value implements driver.Valuer and stores the MarshalText() form.
+func (ipv4 IPv4Addr) Value() (driver.Value, error) {
+	text, err := ipv4.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner.  See SockAddrMarshaler.Scan() for the
+// accepted formats, of which only IPv4 addresses are accepted.
+func (ipv4 *IPv4Addr) Scan(src any) error {
+	sa, err := scanSockAddr(src, "IPv4Addr", func(s string) (SockAddr, error) {
+		var v IPv4Addr
+		err := v.UnmarshalText([]byte(s))
+		return v, err
+	})
+	if err != nil {
+		return err
+	}
+
+	v, ok := sa.(IPv4Addr)
+	if !ok {
+		return &ScanError{Dest: "IPv4Addr", Src: src, Err: fmt.Errorf("%s is not an IPv4 address", sa)}
+	}
+	*ipv4 = v
+	return nil
+}
+
+// Value implements driver.Valuer and stores the MarshalText() form.
+func (ipv6 IPv6Addr) Value() (driver.Value, error) {
+	text, err := ipv6.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner.  See SockAddrMarshaler.Scan() for the
+// accepted formats, of which only IPv6 addresses are accepted.
+func (ipv6 *IPv6Addr) Scan(src any) error {
+	sa, err := scanSockAddr(src, "IPv6Addr", func(s string) (SockAddr, error) {
+		var v IPv6Addr
+		err := v.UnmarshalText([]byte(s))
+		return v, err
+	})
+	if err != nil {
+		return err
+	}
+
+	v, ok := sa.(IPv6Addr)
+	if !ok {
+		return &ScanError{Dest: "IPv6Addr", Src: src, Err: fmt.Errorf("%s is not an IPv6 address", sa)}
+	}
+	*ipv6 = v
+	return nil
+}
+
+// Value implements driver.Valuer and stores the unquoted path.
+func (us UnixSock) Value() (driver.Value, error) {
+	return us.path, nil
+}
+
+// Scan implements sql.Scanner and accepts the path as a string or []byte.
+func (us *UnixSock) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return us.UnmarshalText([]byte(v))
+	case []byte:
+		return us.UnmarshalText(v)
+	default:
+		return &ScanError{Dest: "UnixSock", Src: src, Err: fmt.Errorf("unsupported type")}
+	}
+}
+
+// Value implements driver.Valuer and stores the MarshalText() form.
+func (vs VSock) Value() (driver.Value, error) {
+	return vs.String(), nil
+}
+
+// Scan implements sql.Scanner and accepts the MarshalText() form as a string
+// or []byte.
+func (vs *VSock) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return &ScanError{Dest: "VSock", Src: src, Err: fmt.Errorf("unsupported type")}
+	}
+
+	vsock, err := NewVSock(text)
+	if err != nil {
+		return &ScanError{Dest: "VSock", Src: src, Err: err}
+	}
+	*vs = vsock
+	return nil
+}
+
+// Value implements driver.Valuer and stores the MarshalText() form of the
+// SockAddr, with UnixSocks prefixed by "unix:", or NULL if the SockAddr is nil.
+func (s SockAddrMarshaler) Value() (driver.Value, error) {
+	if s.SockAddr == nil {
+		return nil, nil
+	}
+
+	text, err := marshalSockAddrText(s.SockAddr)
+	if err != nil {
+		return nil, err
+	}
+	return text, nil
+}
+
+// Scan implements sql.Scanner.  A NULL value sets the SockAddr to nil.  Text
+// is in the Value() format, or any format accepted by NewSockAddr(), and may
+// be a string or []byte.  A []byte of 4, 16 or 17 bytes is always a raw
+// address: 4 bytes for an IPv4 host address, 16 bytes for an IPv6 host
+// address, or 17 bytes for an IPv6 address followed by its prefix length.
+// Text of those lengths must be scanned from a string.
+func (s *SockAddrMarshaler) Scan(src any) error {
+	if src == nil {
+		s.SockAddr = nil
+		return nil
+	}
+
+	sa, err := scanSockAddr(src, "SockAddr", unmarshalSockAddrText)
+	if err != nil {
+		return err
+	}
+	s.SockAddr = sa
+	return nil
+}
+
+// scanSockAddr converts src into a SockAddr using parse for text and the raw
+// 4, 16 and 17 byte address encodings for binary data.
+func scanSockAddr(src any, dest string, parse func(string) (SockAddr, error)) (SockAddr, error) {
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		switch len(v) {
+		case IPv4len, IPv6len, IPv6len + 1:
+			sa, err := scanRawAddr(v)
+			if err != nil {
+				return nil, &ScanError{Dest: dest, Src: src, Err: err}
+			}
+			return sa, nil
+		}
+		text = string(v)
+	case nil:
+		return nil, &ScanError{Dest: dest, Src: src, Err: fmt.Errorf("NULL is not a valid address")}
+	default:
+		return nil, &ScanError{Dest: dest, Src: src, Err: fmt.Errorf("unsupported type")}
+	}
+
+	sa, err := parse(text)
+	if err != nil {
+		return nil, &ScanError{Dest: dest, Src: src, Err: err}
+	}
+	return sa, nil
+}
+
+// scanRawAddr converts a raw 4 byte IPv4 address, 16 byte IPv6 address, or 17
+// byte IPv6 address and prefix length into an IPAddr.
+func scanRawAddr(b []byte) (IPAddr, error) {
+	switch len(b) {
+	case IPv4len:
+		return IPv4Addr{
+			Address: IPv4Address(binary.BigEndian.Uint32(b)),
+			Mask:    IPv4HostMask,
+		}, nil
+	case IPv6len, IPv6len + 1:
+		maskBits := IPv6len * 8
+		if len(b) == IPv6len+1 {
+			maskBits = int(b[IPv6len])
+			if maskBits > IPv6len*8 {
+				return nil, fmt.Errorf("invalid IPv6 prefix length %d", maskBits)
+			}
+		}
+		return IPv6Addr{
+			Address: IPv6Address(Uint128FromBytes([IPv6len]byte(b))),
+			Mask:    IPv6Mask(mask128(maskBits)),
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid address length %d", len(b))
+	}
+}
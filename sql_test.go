@@ -0,0 +1,223 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+// fakeDriver is a database/sql driver that stores every row passed to Exec()
+// in memory and returns all of them from Query(), regardless of the SQL.  Each
+// DSN is a separate database.
+type fakeDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeDB
+}
+
+type fakeDB struct {
+	mu   sync.Mutex
+	rows [][]driver.Value
+}
+
+type fakeConn struct{ d *fakeDB }
+type fakeStmt struct{ d *fakeDB }
+
+type fakeRows struct {
+	rows [][]driver.Value
+	pos  int
+}
+
+func (d *fakeDriver) Open(dsn string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.dbs[dsn] == nil {
+		d.dbs[dsn] = &fakeDB{}
+	}
+	return fakeConn{d.dbs[dsn]}, nil
+}
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (fakeConn) Close() error                          { return nil }
+func (fakeConn) Begin() (driver.Tx, error)             { return nil, errors.New("not supported") }
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.rows = append(s.d.rows, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	return &fakeRows{rows: s.d.rows}, nil
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (*fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos == len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+var fakeDBCount atomic.Int64
+
+func init() {
+	sql.Register("sockaddr-fake", &fakeDriver{dbs: make(map[string]*fakeDB)})
+}
+
+// openFakeDB returns a new, empty database backed by fakeDriver.
+func openFakeDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sockaddr-fake", strconv.FormatInt(fakeDBCount.Add(1), 10))
+	if err != nil {
+		t.Fatalf("unable to open the fake database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSockAddr_SQLRoundTrip(t *testing.T) {
+	db := openFakeDB(t)
+
+	ipv4 := sockaddr.IPv4Addr{Address: 0x0a000000, Mask: 0xff000000, Port: 8300}
+	ipv6 := sockaddr.MustIPv6Addr("[fe80::1%eth0]:8080")
+	us := sockaddr.MustUnixSock("/var/run/agent.sock")
+	vs := sockaddr.MustVSock("vsock://3:1024")
+	sam := sockaddr.SockAddrMarshaler{SockAddr: sockaddr.MustIPv6Addr("2001:db8::/32")}
+	samUnix := sockaddr.SockAddrMarshaler{SockAddr: sockaddr.MustUnixSock("agent.sock")}
+	null := sockaddr.SockAddrMarshaler{}
+
+	if _, err := db.Exec("INSERT", ipv4, ipv6, us, vs, sam, samUnix, null); err != nil {
+		t.Fatalf("unable to insert: %v", err)
+	}
+
+	var (
+		ipv4Out    sockaddr.IPv4Addr
+		ipv6Out    sockaddr.IPv6Addr
+		usOut      sockaddr.UnixSock
+		vsOut      sockaddr.VSock
+		samOut     sockaddr.SockAddrMarshaler
+		samUnixOut sockaddr.SockAddrMarshaler
+		nullOut    = sockaddr.SockAddrMarshaler{SockAddr: ipv4}
+	)
+	if err := db.QueryRow("SELECT").Scan(&ipv4Out, &ipv6Out, &usOut, &vsOut, &samOut, &samUnixOut, &nullOut); err != nil {
+		t.Fatalf("unable to scan: %v", err)
+	}
+
+	for _, test := range []struct{ in, out any }{
+		{ipv4, ipv4Out},
+		{ipv6, ipv6Out},
+		{us, usOut},
+		{vs, vsOut},
+		{sam.SockAddr, samOut.SockAddr},
+		{samUnix.SockAddr, samUnixOut.SockAddr},
+		{nil, nullOut.SockAddr},
+	} {
+		if !reflect.DeepEqual(test.in, test.out) {
+			t.Errorf("expected %#v, received %#v", test.in, test.out)
+		}
+	}
+}
+
+func TestSockAddr_SQLScan(t *testing.T) {
+	ipv6Raw := sockaddr.MustIPv6Addr("2001:db8::1").NetIP()
+
+	tests := []struct {
+		name     string
+		src      any
+		scanner  func() sql.Scanner
+		expected string
+	}{
+		{"ipv4 string", "192.168.0.1:53", func() sql.Scanner { return &sockaddr.IPv4Addr{} }, "192.168.0.1:53"},
+		{"ipv4 text bytes", []byte("192.168.0.0/16"), func() sql.Scanner { return &sockaddr.IPv4Addr{} }, "192.168.0.0/16"},
+		{"ipv4 raw", []byte{192, 168, 0, 1}, func() sql.Scanner { return &sockaddr.IPv4Addr{} }, "192.168.0.1"},
+		{"ipv6 raw", []byte(*ipv6Raw), func() sql.Scanner { return &sockaddr.IPv6Addr{} }, "2001:db8::1"},
+		{"ipv6 raw prefix", append([]byte(*ipv6Raw), 64), func() sql.Scanner { return &sockaddr.IPv6Addr{} }, "2001:db8::1/64"},
+		{"ipv6 text", "2001:db8::/32", func() sql.Scanner { return &sockaddr.IPv6Addr{} }, "2001:db8::/32"},
+		{"unix bytes", []byte("/tmp/foo"), func() sql.Scanner { return &sockaddr.UnixSock{} }, `"/tmp/foo"`},
+		{"vsock", "vsock://2:22", func() sql.Scanner { return &sockaddr.VSock{} }, "vsock://2:22"},
+		{"marshaler raw ipv4", []byte{10, 0, 0, 1}, func() sql.Scanner { return &sockaddr.SockAddrMarshaler{} }, "10.0.0.1"},
+		{"marshaler raw ipv6", append([]byte(*ipv6Raw), 128), func() sql.Scanner { return &sockaddr.SockAddrMarshaler{} }, "2001:db8::1"},
+		{"marshaler raw unspecified ipv4", []byte{0, 0, 0, 0}, func() sql.Scanner { return &sockaddr.SockAddrMarshaler{} }, "0.0.0.0"},
+		{"marshaler raw printable ipv4", []byte{64, 65, 66, 67}, func() sql.Scanner { return &sockaddr.SockAddrMarshaler{} }, "64.65.66.67"},
+		{"marshaler raw slash ipv4", []byte{47, 1, 2, 3}, func() sql.Scanner { return &sockaddr.SockAddrMarshaler{} }, "47.1.2.3"},
+		{"marshaler raw unspecified ipv6", []byte(*sockaddr.MustIPv6Addr("::").NetIP()), func() sql.Scanner { return &sockaddr.SockAddrMarshaler{} }, "::"},
+		{"marshaler raw loopback ipv6", []byte(*sockaddr.MustIPv6Addr("::1").NetIP()), func() sql.Scanner { return &sockaddr.SockAddrMarshaler{} }, "::1"},
+		{"marshaler unix", "/tmp/foo", func() sql.Scanner { return &sockaddr.SockAddrMarshaler{} }, `"/tmp/foo"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scanner := test.scanner()
+			if err := scanner.Scan(test.src); err != nil {
+				t.Fatalf("unable to scan %v: %v", test.src, err)
+			}
+			if s := scanner.(interface{ String() string }).String(); s != test.expected {
+				t.Errorf("expected %s, received %s", test.expected, s)
+			}
+		})
+	}
+}
+
+func TestSockAddr_SQLScanErrors(t *testing.T) {
+	ipv6Raw := []byte(*sockaddr.MustIPv6Addr("2001:db8::1").NetIP())
+
+	tests := []struct {
+		name    string
+		src     any
+		scanner sql.Scanner
+	}{
+		{"ipv4 null", nil, &sockaddr.IPv4Addr{}},
+		{"ipv4 int", int64(1), &sockaddr.IPv4Addr{}},
+		{"ipv4 invalid", "not an address", &sockaddr.IPv4Addr{}},
+		{"ipv4 from ipv6 raw", ipv6Raw, &sockaddr.IPv4Addr{}},
+		{"ipv6 from ipv4 raw", []byte{10, 0, 0, 1}, &sockaddr.IPv6Addr{}},
+		{"ipv6 bad prefix", append(ipv6Raw, 129), &sockaddr.IPv6Addr{}},
+		{"ipv6 bad length", []byte{0xff, 1, 2, 3, 4}, &sockaddr.IPv6Addr{}},
+		{"unix null", nil, &sockaddr.UnixSock{}},
+		{"vsock invalid", "vsock://3", &sockaddr.VSock{}},
+		{"marshaler float", 1.5, &sockaddr.SockAddrMarshaler{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.scanner.Scan(test.src)
+			var scanErr *sockaddr.ScanError
+			if !errors.As(err, &scanErr) {
+				t.Fatalf("expected a *ScanError, received %v", err)
+			}
+			if scanErr.Src != nil && !reflect.DeepEqual(scanErr.Src, test.src) {
+				t.Errorf("expected Src %v, received %v", test.src, scanErr.Src)
+			}
+			if scanErr.Unwrap() == nil {
+				t.Errorf("expected an underlying error")
+			}
+		})
+	}
+}
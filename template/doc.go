@@ -19,6 +19,14 @@ through its Parse() call.  For example:
     }
     fmt.Printf("My Private IP address is: %s\n", results)
 
+ParseWithProvider() evaluates a template against the interfaces, addresses and
+default route of a sockaddr.InterfaceProvider instead of the host's.  All of the
+Get* functions and the "default" sort use the provider, so a
+sockaddr.StaticInterfaceProvider fixture makes the output reproducible:
+
+    p := sockaddr.NewStaticInterfaceProvider(ifAddrs, "eth0")
+    results, err := template.ParseWithProvider(`{{ GetPrivateIP }}`, p)
+
 Below is a list of builtin template functions and details re: their usage.  It
 is possible to add additional functions by calling ParseIfAddrsTemplate
 directly.
@@ -49,12 +57,29 @@ Example:
 
 
 `GetDefaultInterfaces` - Returns one IfAddr for every IP that is on the
-interface containing the default route for the host.
+interface containing the default route for the host.  An address is only
+included when the default route of its address family uses its interface.
 
 Example:
 
     {{ GetDefaultInterfaces }}
 
+`GetDefaultIPv4Interfaces` - Returns one IfAddr for every IPv4 address on the
+interfaces with an IPv4 default route, ordered by the metric of the default
+routes.
+
+Example:
+
+    {{ GetDefaultIPv4Interfaces | limit 1 | attr "address" }}
+
+`GetDefaultIPv6Interfaces` - Returns one IfAddr for every IPv6 address on the
+interfaces with an IPv6 default route, ordered by the metric of the default
+routes.
+
+Example:
+
+    {{ GetDefaultIPv6Interfaces | include "rfc" "4193" | attr "address" }}
+
 `GetPrivateInterfaces` - Returns one IfAddr for every forwardable IP address
 that is included in RFC 6890 and whose interface is marked as up.  NOTE: RFC 6890 is a more exhaustive
 version of RFC1918 because it spans IPv4 and IPv6, however, RFC6890 does permit the
@@ -74,6 +99,17 @@ Example:
     {{ GetPublicInterfaces | sort "default" | join "name" " " }}
 
 
+`GetInterfacesForDestination` - Returns the IfAddr of the address the host
+would use as the source address to reach the given destination.  The route to
+the destination is found by a longest-prefix match over the routing table,
+which makes this the right address on multi-homed hosts where the destination
+is reached through a secondary network rather than the default route.
+
+Example:
+
+    {{ GetInterfacesForDestination "10.1.2.3" | attr "address" }}
+
+
 `GetPrivateIP` - Helper function that returns a string of the first IP address
 from GetPrivateInterfaces.
 
@@ -129,6 +165,10 @@ criteria is comma separated (`,`):
   - `-address`: Descending sort of IfAddrs by Address
   - `default`, `+default`: Ascending sort of IfAddrs, IfAddr with a default route first
   - `-default`: Descending sort of IfAddrs, IfAttr with default route last
+  - `index`, `+index`: Ascending sort of IfAddrs by interface index
+  - `-index`: Descending sort of IfAddrs by interface index
+  - `mtu`, `+mtu`: Ascending sort of IfAddrs by interface MTU
+  - `-mtu`: Descending sort of IfAddrs by interface MTU
   - `name`, `+name`: Ascending sort of IfAddrs by lexical ordering of interface name
   - `-name`: Descending sort of IfAddrs by lexical ordering of interface name
   - `port`, `+port`: Ascending sort of IfAddrs by port number
@@ -139,9 +179,14 @@ criteria is comma separated (`,`):
     by their netmask (larger networks first)
   - `-size`: Descending sort of IfAddrs by their network size as determined by their
     netmask (smaller networks first)
+  - `speed`, `+speed`: Ascending sort of IfAddrs by the speed of their interface's
+    link (unknown speeds first)
+  - `-speed`: Descending sort of IfAddrs by the speed of their interface's link
+    (fastest links first, unknown speeds last)
   - `type`, `+type`: Ascending sort of IfAddrs by the type of the IfAddr (Unix,
-    IPv4, then IPv6)
-  - `-type`: Descending sort of IfAddrs by the type of the IfAddr (IPv6, IPv4, Unix)
+    VSock, IPv4, then IPv6)
+  - `-type`: Descending sort of IfAddrs by the type of the IfAddr (IPv6, IPv4,
+    VSock, Unix)
 
 Example:
 
@@ -153,13 +198,28 @@ arguments.  Both `exclude` and `include` take two arguments.  The list of
 available filtering criteria is:
   - "address": Filter IfAddrs based on a regexp matching the string representation
     of the address
+  - "carrier": Filter IfAddrs based on whether their interface's link has a
+    carrier, `up` or `down` (e.g. no cable attached).  Only reported on Linux.
   - "flag","flags": Filter IfAddrs based on the list of flags specified.  Multiple
     flags can be passed together using the pipe character (`|`) to create an inclusive
     bitmask of flags.  The list of flags is included below.
+  - "kind": Filter IfAddrs based on the kind of their interface.  Multiple kinds
+    can be passed together using the pipe character (`|`).  Kinds include:
+    `bond`, `bridge`, `loopback`, `physical`, `tap`, `tun`, `veth`, `vlan`,
+    `wireguard`, and `virtual`, as well as other kinds reported by Linux such
+    as `macvlan` or `vxlan`.  Kinds other than `loopback` are only reported on
+    Linux.
   - "name": Filter IfAddrs based on a regexp matching the interface name.
   - "network": Filter IfAddrs based on whether a netowkr is included in a given
-    CIDR.  More than one CIDR can be passed in if each network is separated by
-    the pipe character (`|`).
+    CIDR or address range (e.g. `10.0.0.5-10.0.1.200`).  More than one CIDR or
+    range can be passed in if each network is separated by the pipe character
+    (`|`).
+  - "operstate": Filter IfAddrs based on the operational state of their interface,
+    which unlike the `up` flag tells whether the link can pass packets.  Multiple
+    states can be passed together using the pipe character (`|`).  States are:
+    `up`, `down`, `lowerlayerdown`, `dormant`, `testing`, `notpresent`, and
+    `unknown` (reported by e.g. loopback and tunnel interfaces).  Only reported
+    on Linux.
   - "port": Filter IfAddrs based on an exact match of the port number (number must
     be expressed as a string)
   - "rfc", "rfcs": Filter IfAddrs based on the matching RFC.  If more than one RFC
@@ -167,11 +227,13 @@ available filtering criteria is:
   - "size": Filter IfAddrs based on the exact match of the mask size.
   - "type": Filter IfAddrs based on their SockAddr type.  Multiple types can be
     specified together by using the pipe character (`|`).  Valid types include:
-    `ip`, `ipv4`, `ipv6`, and `unix`.
+    `ip`, `ipv4`, `ipv6`, `unix`, and `vsock`.
 
 Example:
 
     {{ GetPrivateInterfaces | exclude "type" "IPv6" }}
+    {{ GetAllInterfaces | include "kind" "physical" | include "type" "IPv4" | attr "address" }}
+    {{ GetPrivateInterfaces | include "operstate" "up" | sort "-speed" | attr "address" }}
 
 
 `unique`: Removes duplicate entries from the IfAddrs list, assuming the list has
@@ -233,6 +295,33 @@ Example:
     {{ GetPrivateInterfaces | include "flags" "forwardable|up" | include "type" "IPv4" | math "network" "+2" | attr "address" }}
 
 
+`subnets`: Replaces each IP network in the list with its subnets of the given
+prefix length.  The prefix length must not be shorter than the network's own
+prefix length.  At most 65536 results are returned.
+
+Example:
+
+    {{ GetPrivateInterfaces | include "type" "IPv4" | subnets 26 | join "address" " " }}
+
+
+`hosts`: Replaces each IP network in the list with its usable host addresses.
+At most 65536 results are returned.
+
+Example:
+
+    {{ GetPrivateInterfaces | include "type" "IPv4" | limit 1 | hosts | limit 4 | join "address" " " }}
+
+
+`eui64`: Replaces each IPv6 network in the list with the stateless address
+autoconfiguration (SLAAC) address derived from the modified EUI-64 form of its
+interface's hardware address.  Networks longer than a /64 and interfaces
+without a 48-bit or 64-bit hardware address are dropped.
+
+Example:
+
+    {{ GetAllInterfaces | include "type" "IPv6" | eui64 | unique "address" | join "address" " " }}
+
+
 `attr`: Extracts a single attribute of the first member of the list and returns
 it as a string.  `attr` takes a single attribute name.  The list of available
 attributes is type-specific and shared between `join`.  See below for a list of
@@ -279,6 +368,19 @@ Example:
   - `unspecified`: Is the IfAddr the IPv6 unspecified address?
   - `up`: Is the interface up?
 
+Address flags for `exclude` and `include` (Linux only, matched against the
+address rather than its interface):
+  - `dadfailed`: Did duplicate address detection fail?
+  - `deprecated`: Is the address past its preferred lifetime?
+  - `noprefixroute`: Was the address added without a prefix route?
+  - `permanent`: Was the address configured statically?
+  - `temporary`: Is the address an IPv6 privacy address (or a secondary IPv4 address)?
+  - `tentative`: Is duplicate address detection still in progress?
+
+Example:
+
+    {{ GetAllInterfaces | include "type" "IPv6" | exclude "flags" "tentative" | exclude "flags" "deprecated" | attr "address" }}
+
 
 Attributes for `attr`, `Attr`, and `join`:
 
@@ -286,6 +388,20 @@ SockAddr Type:
   - `string`
   - `type`
 
+IfAddr Type:
+  - `address_flags`: flags of the address (e.g. `temporary|deprecated`), IPv6 on Linux only
+  - `carrier`: whether the link has a carrier (`up` or `down`), Linux only
+  - `duplex`: duplex of the link (`full` or `half`), Linux only
+  - `flags`: flags of the interface (e.g. `up|broadcast|multicast`)
+  - `hardware_addr`: hardware address of the interface (e.g. `02:42:ac:11:00:02`)
+  - `index`: index of the interface
+  - `kind`: kind of the interface (e.g. `physical`, `bridge` or `veth`)
+  - `mtu`: MTU of the interface
+  - `name`: name of the interface
+  - `operstate`: operational state of the interface (e.g. `up` or `down`), Linux only
+  - `scope`: scope of the address (`global`, `site`, `link` or `host`), Linux only
+  - `speed`: speed of the link in Mb/s, Linux only
+
 IPAddr Type:
   - `address`
   - `binary`
@@ -298,6 +414,7 @@ IPAddr Type:
   - `network`
   - `octets`: Decimal values per byte
   - `port`
+  - `reverse_dns`: PTR name of the address (e.g. `1.0.0.127.in-addr.arpa.`)
   - `size`: Number of hosts in the network
 
 IPv4Addr Type:
@@ -305,10 +422,22 @@ IPv4Addr Type:
   - `uint32`: unsigned integer representation of the value
 
 IPv6Addr Type:
+  - `embedded_ipv4`: IPv4 address embedded by NAT64 (`64:ff9b::/96`), 6to4, Teredo or ISATAP
+  - `eui64`: `true` if the interface identifier is derived from a MAC address
+  - `eui64_mac`: MAC address embedded in an EUI-64 derived address
+  - `expanded`: address without zero compression (e.g. `2001:0db8:0000:0000:0000:0000:0000:0001`)
+  - `teredo_client`: public IPv4 address and port of a Teredo client
+  - `teredo_server`: IPv4 address of a Teredo server
   - `uint128`: unsigned integer representation of the value
+  - `zone`: IPv6 zone (scope) identifier, e.g. the interface of a link-local address
 
 UnixSock Type:
   - `path`
+  - `abstract`: `true` if the socket is a Linux abstract socket (e.g. `@name`)
+
+VSock Type:
+  - `cid`: context identifier
+  - `port`
 
 */
 package template
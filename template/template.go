@@ -39,6 +39,16 @@ func init() {
 		// host.
 		"GetDefaultInterfaces": sockaddr.GetDefaultInterfaces,
 
+		// GetDefaultIPv4Interfaces - Returns one IfAddr for every IPv4
+		// address on the interfaces with an IPv4 default route, ordered
+		// by the metric of the default routes.
+		"GetDefaultIPv4Interfaces": sockaddr.GetDefaultIPv4Interfaces,
+
+		// GetDefaultIPv6Interfaces - Returns one IfAddr for every IPv6
+		// address on the interfaces with an IPv6 default route, ordered
+		// by the metric of the default routes.
+		"GetDefaultIPv6Interfaces": sockaddr.GetDefaultIPv6Interfaces,
+
 		// GetPrivateInterfaces - Returns one IfAddr for every IP that
 		// matches RFC 6890, are attached to the interface with the
 		// default route, and are forwardable IP addresses.  NOTE: RFC
@@ -53,6 +63,12 @@ func init() {
 		// match RFC 6890, are attached to the default route, and are
 		// forwardable.
 		"GetPublicInterfaces": sockaddr.GetPublicInterfaces,
+
+		// GetInterfacesForDestination - Returns the IfAddr of the
+		// address the host would use as the source address to reach
+		// the given destination, found by a longest-prefix match over
+		// the routing table.
+		"GetInterfacesForDestination": sockaddr.GetInterfacesForDestination,
 	}
 
 	SortFuncs = template.FuncMap{
@@ -75,6 +91,15 @@ func init() {
 		// Misc math functions that operate on a single IfAddr input
 		"math": sockaddr.IfAddrsMath,
 
+		// Expand each IP network into its host addresses or its subnets of
+		// the given prefix length.
+		"hosts":   sockaddr.HostIfAddrs,
+		"subnets": sockaddr.SubnetIfAddrs,
+
+		// Replace each IPv6 network with the SLAAC address derived from the
+		// interface's hardware address.
+		"eui64": sockaddr.EUI64IfAddrs,
+
 		// Return a Private RFC 6890 IP address string that is attached
 		// to the default route and a forwardable address.
 		"GetPrivateIP": sockaddr.GetPrivateIP,
@@ -127,6 +152,22 @@ func Parse(input string) (string, error) {
 	return ParseIfAddrs(input, addrs)
 }
 
+// ParseWithProvider parses input as template input using the interfaces,
+// addresses and default route supplied by p, then returns the string output if
+// there are no errors.  The `Get*Interfaces` and `Get*IP` functions and the
+// "default" sort of the template also use p, including its routing table when
+// p is a sockaddr.RouteProvider, so the output does not depend on the host's
+// network configuration when p is a sockaddr.StaticInterfaceProvider.
+// `GetInterfacesForDestination` requires p to also be a sockaddr.RouteProvider.
+func ParseWithProvider(input string, p sockaddr.InterfaceProvider) (string, error) {
+	addrs, err := sockaddr.GetAllInterfacesFrom(p)
+	if err != nil {
+		return "", fmt.Errorf("unable to query interface addresses: %w", err)
+	}
+
+	return parseIfAddrsTemplate(input, addrs, template.New("sockaddr.Parse"), providerFuncs(p))
+}
+
 // ParseIfAddrs parses input as template input using the IfAddrs inputs, then
 // returns the string output if there are no errors.
 func ParseIfAddrs(input string, ifAddrs sockaddr.IfAddrs) (string, error) {
@@ -136,12 +177,19 @@ func ParseIfAddrs(input string, ifAddrs sockaddr.IfAddrs) (string, error) {
 // ParseIfAddrsTemplate parses input as template input using the IfAddrs inputs,
 // then returns the string output if there are no errors.
 func ParseIfAddrsTemplate(input string, ifAddrs sockaddr.IfAddrs, tmplIn *template.Template) (string, error) {
+	return parseIfAddrsTemplate(input, ifAddrs, tmplIn, nil)
+}
+
+// parseIfAddrsTemplate is ParseIfAddrsTemplate() with overrideFuncs added
+// after the standard function maps.
+func parseIfAddrsTemplate(input string, ifAddrs sockaddr.IfAddrs, tmplIn *template.Template, overrideFuncs template.FuncMap) (string, error) {
 	// Create a template, add the function map, and parse the text.
 	tmpl, err := tmplIn.Option("missingkey=error").
 		Funcs(SourceFuncs).
 		Funcs(SortFuncs).
 		Funcs(FilterFuncs).
 		Funcs(HelperFuncs).
+		Funcs(overrideFuncs).
 		Parse(input)
 	if err != nil {
 		return "", fmt.Errorf("unable to parse template %+q: %w", input, err)
@@ -155,3 +203,56 @@ func ParseIfAddrsTemplate(input string, ifAddrs sockaddr.IfAddrs, tmplIn *templa
 
 	return outWriter.String(), nil
 }
+
+// providerFuncs returns the template functions that query the host's network
+// configuration, bound to p.
+func providerFuncs(p sockaddr.InterfaceProvider) template.FuncMap {
+	return template.FuncMap{
+		"GetAllInterfaces": func() (sockaddr.IfAddrs, error) {
+			return sockaddr.GetAllInterfacesFrom(p)
+		},
+		"GetDefaultInterfaces": func() (sockaddr.IfAddrs, error) {
+			return sockaddr.GetDefaultInterfacesFrom(p)
+		},
+		"GetDefaultIPv4Interfaces": func() (sockaddr.IfAddrs, error) {
+			return sockaddr.GetDefaultIPv4InterfacesFrom(p)
+		},
+		"GetDefaultIPv6Interfaces": func() (sockaddr.IfAddrs, error) {
+			return sockaddr.GetDefaultIPv6InterfacesFrom(p)
+		},
+		"GetPrivateInterfaces": func() (sockaddr.IfAddrs, error) {
+			return sockaddr.GetPrivateInterfacesFrom(p)
+		},
+		"GetPublicInterfaces": func() (sockaddr.IfAddrs, error) {
+			return sockaddr.GetPublicInterfacesFrom(p)
+		},
+		"GetInterfacesForDestination": func(dst string) (sockaddr.IfAddrs, error) {
+			rp, ok := p.(sockaddr.RouteProvider)
+			if !ok {
+				return nil, fmt.Errorf("%T does not provide a routing table", p)
+			}
+			return sockaddr.GetInterfacesForDestinationFrom(rp, dst)
+		},
+		"sort": func(selectorParam string, inputIfAddrs sockaddr.IfAddrs) (sockaddr.IfAddrs, error) {
+			return sockaddr.SortIfByFrom(p, selectorParam, inputIfAddrs)
+		},
+		"GetPrivateIP": func() (string, error) {
+			return sockaddr.GetPrivateIPFrom(p)
+		},
+		"GetPrivateIPs": func() (string, error) {
+			return sockaddr.GetPrivateIPsFrom(p)
+		},
+		"GetPublicIP": func() (string, error) {
+			return sockaddr.GetPublicIPFrom(p)
+		},
+		"GetPublicIPs": func() (string, error) {
+			return sockaddr.GetPublicIPsFrom(p)
+		},
+		"GetInterfaceIP": func(namedIfRE string) (string, error) {
+			return sockaddr.GetInterfaceIPFrom(p, namedIfRE)
+		},
+		"GetInterfaceIPs": func(namedIfRE string) (string, error) {
+			return sockaddr.GetInterfaceIPsFrom(p, namedIfRE)
+		},
+	}
+}
@@ -195,6 +195,11 @@ func TestSockAddr_Parse(t *testing.T) {
 			input:  `{{. | include "name" "^(en|lo)0$" | exclude "name" "^en0$" | sort "type" | sort "address" | join "address" " " }}`,
 			output: `127.0.0.1 ::1 fe80::1`,
 		},
+		{
+			name:   `include "network" range`,
+			input:  `{{. | include "network" "127.0.0.0-127.255.255.255|::-::1" | sort "type,address" | join "address" " " }}`,
+			output: `127.0.0.1 ::1`,
+		},
 		{
 			name:   `"dot" pipeline, IPv4 type`,
 			input:  `{{. | include "type" "IPv4" | include "name" "^lo0$" | sort "type" | sort "address" }}`,
@@ -377,6 +382,41 @@ func TestSockAddr_Parse(t *testing.T) {
 			input:  `{{. | include "name" "^lo0$" | include "type" "IP" | sort "+type,+address" | math "network" "-4278190088" | join "address" " " }}`,
 			output: `127.255.255.248 ::1 fe80::ffff:ffff:ff:fff8`,
 		},
+		{
+			name:   "subnets",
+			input:  `{{. | include "name" "^en0$" | include "type" "IPv4" | subnets 26 | join "address" " " }}`,
+			output: `192.168.0.0 192.168.0.64 192.168.0.128 192.168.0.192`,
+		},
+		{
+			name:   "subnets attr",
+			input:  `{{. | include "name" "^en0$" | include "type" "IPv4" | subnets 25 | offset 1 | attr "string" }}`,
+			output: `192.168.0.128/25`,
+		},
+		{
+			name:  "subnets invalid prefix",
+			input: `{{. | include "name" "^en0$" | include "type" "IPv4" | subnets 16 | join "address" " " }}`,
+			fail:  true,
+		},
+		{
+			name:   "hosts",
+			input:  `{{. | include "name" "^en0$" | include "type" "IPv4" | hosts | limit 3 | join "address" " " }}`,
+			output: `192.168.0.1 192.168.0.2 192.168.0.3`,
+		},
+		{
+			name:  "hosts too many",
+			input: `{{. | include "name" "^en0$" | include "type" "IPv6" | hosts | limit 3 | join "address" " " }}`,
+			fail:  true,
+		},
+		{
+			name:   "eui64",
+			input:  `{{. | include "name" "^en0$" | include "type" "IPv6" | eui64 | unique "address" | join "address" " " }}`,
+			output: `fe80::623e:5fff:fe48:75ff 2406:7400:63:ef5:623e:5fff:fe48:75ff`,
+		},
+		{
+			name:   "eui64 attrs",
+			input:  `{{. | include "name" "^en0$" | include "type" "IPv6" | eui64 | attr "eui64" }} {{. | include "name" "^en0$" | include "type" "IPv6" | eui64 | attr "eui64_mac" }} {{. | include "name" "^en0$" | include "type" "IPv6" | attr "eui64" }}`,
+			output: `true 60:3e:5f:48:75:ff false`,
+		},
 	}
 
 	for i, test := range tests {
@@ -396,3 +436,149 @@ func TestSockAddr_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseWithProvider(t *testing.T) {
+	lo0 := net.Interface{
+		Index: 1,
+		MTU:   16384,
+		Name:  "lo0",
+		Flags: net.FlagUp | net.FlagLoopback | net.FlagMulticast,
+	}
+	eth0 := net.Interface{
+		Index:        2,
+		MTU:          1500,
+		Name:         "eth0",
+		HardwareAddr: net.HardwareAddr{0x02, 0x42, 0xac, 0x11, 0x00, 0x02},
+		Flags:        net.FlagUp | net.FlagBroadcast | net.FlagMulticast,
+	}
+	eth1 := net.Interface{
+		Index:        3,
+		MTU:          1500,
+		Name:         "eth1",
+		HardwareAddr: net.HardwareAddr{0x02, 0x42, 0xac, 0x12, 0x00, 0x02},
+		Flags:        net.FlagUp | net.FlagBroadcast | net.FlagMulticast,
+	}
+	p := sockaddr.NewStaticInterfaceProvider(sockaddr.IfAddrs{
+		{SockAddr: sockaddr.MustIPv4Addr("127.0.0.1/8"), Interface: lo0, Kind: sockaddr.InterfaceKindLoopback, OperState: sockaddr.OperStateUnknown},
+		{SockAddr: sockaddr.MustIPv4Addr("10.0.0.5/16"), Interface: eth0, Kind: sockaddr.InterfaceKindPhysical, OperState: sockaddr.OperStateUp, Carrier: sockaddr.LinkCarrierUp, Speed: 10000, Duplex: "full"},
+		{SockAddr: sockaddr.MustIPv4Addr("172.17.0.2/16"), Interface: eth1, Kind: sockaddr.InterfaceKindPhysical, OperState: sockaddr.OperStateUp, Carrier: sockaddr.LinkCarrierUp, Speed: 1000, Duplex: "full"},
+		{SockAddr: sockaddr.MustIPv4Addr("17.5.6.7/24"), Interface: eth1, Kind: sockaddr.InterfaceKindPhysical, OperState: sockaddr.OperStateUp, Carrier: sockaddr.LinkCarrierUp, Speed: 1000, Duplex: "full"},
+	}, "eth1")
+	p.RouteTable = []sockaddr.Route{
+		{Destination: sockaddr.MustIPv4Addr("0.0.0.0/0"), Gateway: sockaddr.MustIPv4Addr("172.17.0.1"), Interface: "eth1", Family: sockaddr.TypeIPv4},
+		{Destination: sockaddr.MustIPv4Addr("172.17.0.0/16"), Interface: "eth1", Family: sockaddr.TypeIPv4},
+		{Destination: sockaddr.MustIPv4Addr("17.5.6.0/24"), Interface: "eth1", Family: sockaddr.TypeIPv4},
+		{Destination: sockaddr.MustIPv4Addr("10.0.0.0/16"), Interface: "eth0", Family: sockaddr.TypeIPv4},
+		{Destination: sockaddr.MustIPv4Addr("10.1.0.0/16"), Gateway: sockaddr.MustIPv4Addr("10.0.0.1"), Interface: "eth0", Family: sockaddr.TypeIPv4},
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		output string
+	}{
+		{
+			name:   "dot",
+			input:  `{{ . | join "address" " " }}`,
+			output: `127.0.0.1 10.0.0.5 172.17.0.2 17.5.6.7`,
+		},
+		{
+			name:   "GetAllInterfaces",
+			input:  `{{ GetAllInterfaces | include "name" "eth" | join "name" " " }}`,
+			output: `eth0 eth1 eth1`,
+		},
+		{
+			name:   "GetDefaultInterfaces",
+			input:  `{{ GetDefaultInterfaces | join "address" " " }}`,
+			output: `172.17.0.2 17.5.6.7`,
+		},
+		{
+			name:   "GetDefaultIPv4Interfaces",
+			input:  `{{ GetDefaultIPv4Interfaces | join "address" " " }}`,
+			output: `172.17.0.2 17.5.6.7`,
+		},
+		{
+			name:   "GetDefaultIPv6Interfaces without IPv6 default route",
+			input:  `{{ GetDefaultIPv6Interfaces | join "address" " " }}`,
+			output: ``,
+		},
+		{
+			name:   "GetPrivateInterfaces",
+			input:  `{{ GetPrivateInterfaces | attr "address" }}`,
+			output: `172.17.0.2`,
+		},
+		{
+			name:   "GetPublicInterfaces",
+			input:  `{{ GetPublicInterfaces | attr "address" }}`,
+			output: `17.5.6.7`,
+		},
+		{
+			name:   "sort default",
+			input:  `{{ GetAllInterfaces | include "type" "IPv4" | sort "default,address" | join "address" " " }}`,
+			output: `17.5.6.7 172.17.0.2 10.0.0.5 127.0.0.1`,
+		},
+		{
+			name:   "sort -speed",
+			input:  `{{ GetAllInterfaces | include "operstate" "up" | sort "-speed,address" | join "address" " " }}`,
+			output: `10.0.0.5 17.5.6.7 172.17.0.2`,
+		},
+		{
+			name:   "link state attrs",
+			input:  `{{ with $eth0 := GetAllInterfaces | include "name" "eth0" }}{{ $eth0 | attr "kind" }} {{ $eth0 | attr "operstate" }} {{ $eth0 | attr "carrier" }} {{ $eth0 | attr "speed" }} {{ $eth0 | attr "duplex" }}{{ end }}`,
+			output: `physical up up 10000 full`,
+		},
+		{
+			name:   "GetPrivateIP",
+			input:  `{{ GetPrivateIP }}`,
+			output: `172.17.0.2`,
+		},
+		{
+			name:   "GetPrivateIPs",
+			input:  `{{ GetPrivateIPs }}`,
+			output: `10.0.0.5 172.17.0.2`,
+		},
+		{
+			name:   "GetPublicIP",
+			input:  `{{ GetPublicIP }}`,
+			output: `17.5.6.7`,
+		},
+		{
+			name:   "GetPublicIPs",
+			input:  `{{ GetPublicIPs }}`,
+			output: `17.5.6.7`,
+		},
+		{
+			name:   "GetInterfaceIP",
+			input:  `{{ GetInterfaceIP "eth0" }}`,
+			output: `10.0.0.5`,
+		},
+		{
+			name:   "GetInterfaceIPs",
+			input:  `{{ GetInterfaceIPs "eth1" }}`,
+			output: `172.17.0.2 17.5.6.7`,
+		},
+		{
+			name:   "GetInterfacesForDestination secondary network",
+			input:  `{{ GetInterfacesForDestination "10.1.2.3" | attr "address" }}`,
+			output: `10.0.0.5`,
+		},
+		{
+			name:   "GetInterfacesForDestination default route",
+			input:  `{{ GetInterfacesForDestination "8.8.8.8" | attr "address" }}`,
+			output: `172.17.0.2`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := socktmpl.ParseWithProvider(test.input, p)
+			if err != nil {
+				t.Fatalf("%q: bad: %v", test.name, err)
+			}
+
+			if out != test.output {
+				t.Fatalf("%q: Expected %+q, received %+q", test.name, test.output, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package template
+
+import (
+	"context"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+// Watch renders input using the host's interfaces and returns the output, then
+// re-renders input whenever the interfaces or routes may have changed until
+// ctx is done.  Only outputs that differ from the previous one are sent on the
+// returned channel, which is closed when ctx is done.
+func Watch(ctx context.Context, input string) (string, <-chan string, error) {
+	return WatchWithOptions(ctx, input, sockaddr.WatchOptions{})
+}
+
+// WatchWithOptions is Watch() using opts to watch the interfaces.  If
+// opts.Provider is set, input is rendered with ParseWithProvider() using it.
+// Input is re-rendered on every notification of WatchNotifications(), so
+// changes that are not visible in the interfaces, such as a new default
+// route, are picked up too.  Renderings that fail after the first are skipped
+// and the previous output is kept.
+func WatchWithOptions(ctx context.Context, input string, opts sockaddr.WatchOptions) (string, <-chan string, error) {
+	p := opts.Provider
+	if p == nil {
+		p = sockaddr.OSInterfaceProvider{}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	notify := sockaddr.WatchNotifications(ctx, opts)
+
+	output, err := ParseWithProvider(input, p)
+	if err != nil {
+		cancel()
+		return "", nil, err
+	}
+
+	outputs := make(chan string)
+	go func() {
+		defer cancel()
+		defer close(outputs)
+
+		last := output
+		for range notify {
+			out, err := ParseWithProvider(input, p)
+			if err != nil || out == last {
+				continue
+			}
+			last = out
+
+			select {
+			case outputs <- out:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return output, outputs, nil
+}
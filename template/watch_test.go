@@ -0,0 +1,155 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package template_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+	socktmpl "github.com/hashicorp/go-sockaddr/template"
+)
+
+// watchProvider is an InterfaceProvider whose addresses can be replaced while
+// it is being watched.
+type watchProvider struct {
+	mu sync.Mutex
+	p  *sockaddr.StaticInterfaceProvider
+}
+
+func (w *watchProvider) set(addrs ...string) {
+	eth0 := net.Interface{Index: 2, MTU: 1500, Name: "eth0", Flags: net.FlagUp | net.FlagBroadcast}
+	var ifAddrs sockaddr.IfAddrs
+	for _, addr := range addrs {
+		ifAddrs = append(ifAddrs, sockaddr.IfAddr{SockAddr: sockaddr.MustIPAddr(addr), Interface: eth0})
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.p = sockaddr.NewStaticInterfaceProvider(ifAddrs, "eth0")
+}
+
+// setDefaultRoute replaces the routing table with an IPv4 default route
+// through ifName.
+func (w *watchProvider) setDefaultRoute(ifName string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.p.RouteTable = []sockaddr.Route{
+		{Destination: sockaddr.MustIPv4Addr("0.0.0.0/0"), Interface: ifName, Family: sockaddr.TypeIPv4},
+	}
+}
+
+func (w *watchProvider) Interfaces() ([]net.Interface, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.p.Interfaces()
+}
+
+func (w *watchProvider) Addrs(intf net.Interface) ([]net.Addr, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.p.Addrs(intf)
+}
+
+func (w *watchProvider) DefaultInterfaceName() (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.p.DefaultInterfaceName()
+}
+
+func (w *watchProvider) Routes() ([]sockaddr.Route, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.p.Routes()
+}
+
+func TestWatchWithOptions(t *testing.T) {
+	p := &watchProvider{}
+	p.set("10.0.0.5/24")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	output, outputs, err := socktmpl.WatchWithOptions(ctx, `{{ GetPrivateIP }}`, sockaddr.WatchOptions{
+		Provider:     p,
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != "10.0.0.5" {
+		t.Fatalf("got %q, want %q", output, "10.0.0.5")
+	}
+
+	// Adding an address that does not change the output is not reported,
+	// so the first output received is the renumbered address.
+	p.set("10.0.0.5/24", "2001:db8::5/64")
+	time.Sleep(10 * time.Millisecond)
+	p.set("10.0.0.9/24", "2001:db8::5/64")
+
+	select {
+	case output := <-outputs:
+		if output != "10.0.0.9" {
+			t.Errorf("got %q, want %q", output, "10.0.0.9")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the output to change")
+	}
+
+	cancel()
+	for range outputs {
+		// Drain any output sent before the watch stopped.
+	}
+}
+
+func TestWatchWithOptions_Routes(t *testing.T) {
+	p := &watchProvider{}
+	p.set("10.0.0.5/24", "10.1.0.5/24")
+	p.setDefaultRoute("eth0")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	input := `{{ GetInterfacesForDestination "8.8.8.8" | join "name" " " }}`
+	output, outputs, err := socktmpl.WatchWithOptions(ctx, input, sockaddr.WatchOptions{
+		Provider:     p,
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != "eth0" {
+		t.Fatalf("got %q, want %q", output, "eth0")
+	}
+
+	// A route change leaves the interfaces untouched but must still
+	// re-render the template.
+	p.setDefaultRoute("")
+
+	select {
+	case output := <-outputs:
+		if output != "" {
+			t.Errorf("got %q, want %q", output, "")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the output to change")
+	}
+
+	cancel()
+	for range outputs {
+		// Drain any output sent before the watch stopped.
+	}
+}
+
+func TestWatchWithOptions_Errors(t *testing.T) {
+	p := &watchProvider{}
+	p.set("10.0.0.5/24")
+
+	if _, _, err := socktmpl.WatchWithOptions(context.Background(), `{{ GetPrivateIP `, sockaddr.WatchOptions{Provider: p}); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+)
+
+// Uint128 is a fixed-size, unsigned 128-bit integer stored as a pair of
+// uint64 values.  Uint128 is the value type underneath IPv6Address,
+// IPv6Network, and IPv6Mask.  All arithmetic wraps modulo 2^128 and none of
+// the methods allocate.
+type Uint128 struct {
+	Hi uint64
+	Lo uint64
+}
+
+// maxUint128 has all 128 bits set.
+var maxUint128 = Uint128{Hi: ^uint64(0), Lo: ^uint64(0)}
+
+// Uint128FromBigInt returns the low 128 bits of bi as a Uint128.  Negative
+// values are converted using their two's complement representation.
+func Uint128FromBigInt(bi *big.Int) Uint128 {
+	x := new(big.Int).And(bi, maxUint128.BigInt())
+	var b [16]byte
+	x.FillBytes(b[:])
+	return Uint128FromBytes(b)
+}
+
+// Uint128FromBytes returns the big endian value of b as a Uint128.
+func Uint128FromBytes(b [16]byte) Uint128 {
+	return Uint128{
+		Hi: binary.BigEndian.Uint64(b[:8]),
+		Lo: binary.BigEndian.Uint64(b[8:]),
+	}
+}
+
+// mask128 returns a Uint128 with the upper maskBits set.  maskBits is clamped
+// to the range 0-128.
+func mask128(maskBits int) Uint128 {
+	switch {
+	case maskBits <= 0:
+		return Uint128{}
+	case maskBits >= 128:
+		return maxUint128
+	}
+	return maxUint128.Lsh(uint(128 - maskBits))
+}
+
+// Add returns u+v.
+func (u Uint128) Add(v Uint128) Uint128 {
+	lo, carry := bits.Add64(u.Lo, v.Lo, 0)
+	hi, _ := bits.Add64(u.Hi, v.Hi, carry)
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// addInt64 returns u+i where i may be negative.
+func (u Uint128) addInt64(i int64) Uint128 {
+	if i < 0 {
+		return u.Sub(Uint128{Lo: uint64(-i)})
+	}
+	return u.Add(Uint128{Lo: uint64(i)})
+}
+
+// And returns u&v.
+func (u Uint128) And(v Uint128) Uint128 {
+	return Uint128{Hi: u.Hi & v.Hi, Lo: u.Lo & v.Lo}
+}
+
+// AndNot returns u&^v.
+func (u Uint128) AndNot(v Uint128) Uint128 {
+	return Uint128{Hi: u.Hi &^ v.Hi, Lo: u.Lo &^ v.Lo}
+}
+
+// BigInt returns u as a newly allocated big.Int.
+func (u Uint128) BigInt() *big.Int {
+	b := u.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+// Bytes returns the big endian representation of u.
+func (u Uint128) Bytes() [16]byte {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], u.Hi)
+	binary.BigEndian.PutUint64(b[8:], u.Lo)
+	return b
+}
+
+// Cmp follows the Cmp() standard protocol and returns -1 if u < v, 0 if u ==
+// v, or 1 if u > v.
+func (u Uint128) Cmp(v Uint128) int {
+	switch {
+	case u.Hi < v.Hi:
+		return -1
+	case u.Hi > v.Hi:
+		return 1
+	case u.Lo < v.Lo:
+		return -1
+	case u.Lo > v.Lo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsZero returns true if u is zero.
+func (u Uint128) IsZero() bool {
+	return u.Hi == 0 && u.Lo == 0
+}
+
+// LeadingZeros returns the number of leading zero bits in u.
+func (u Uint128) LeadingZeros() int {
+	if u.Hi != 0 {
+		return bits.LeadingZeros64(u.Hi)
+	}
+	return 64 + bits.LeadingZeros64(u.Lo)
+}
+
+// Lsh returns u<<n.
+func (u Uint128) Lsh(n uint) Uint128 {
+	switch {
+	case n >= 128:
+		return Uint128{}
+	case n >= 64:
+		return Uint128{Hi: u.Lo << (n - 64)}
+	case n == 0:
+		return u
+	}
+	return Uint128{Hi: u.Hi<<n | u.Lo>>(64-n), Lo: u.Lo << n}
+}
+
+// Not returns ^u.
+func (u Uint128) Not() Uint128 {
+	return Uint128{Hi: ^u.Hi, Lo: ^u.Lo}
+}
+
+// OnesCount returns the number of one bits in u.
+func (u Uint128) OnesCount() int {
+	return bits.OnesCount64(u.Hi) + bits.OnesCount64(u.Lo)
+}
+
+// Or returns u|v.
+func (u Uint128) Or(v Uint128) Uint128 {
+	return Uint128{Hi: u.Hi | v.Hi, Lo: u.Lo | v.Lo}
+}
+
+// Rsh returns u>>n.
+func (u Uint128) Rsh(n uint) Uint128 {
+	switch {
+	case n >= 128:
+		return Uint128{}
+	case n >= 64:
+		return Uint128{Lo: u.Hi >> (n - 64)}
+	case n == 0:
+		return u
+	}
+	return Uint128{Hi: u.Hi >> n, Lo: u.Lo>>n | u.Hi<<(64-n)}
+}
+
+// String returns the base 10 representation of u.
+func (u Uint128) String() string {
+	return u.Text(10)
+}
+
+// Sub returns u-v.
+func (u Uint128) Sub(v Uint128) Uint128 {
+	lo, borrow := bits.Sub64(u.Lo, v.Lo, 0)
+	hi, _ := bits.Sub64(u.Hi, v.Hi, borrow)
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// Text returns the representation of u in the given base.  Base 2 and 16 are
+// formatted without allocating a big.Int.
+func (u Uint128) Text(base int) string {
+	switch {
+	case u.Hi == 0:
+		return strconv.FormatUint(u.Lo, base)
+	case base == 2:
+		return strconv.FormatUint(u.Hi, 2) + fmt.Sprintf("%064b", u.Lo)
+	case base == 16:
+		return strconv.FormatUint(u.Hi, 16) + fmt.Sprintf("%016x", u.Lo)
+	default:
+		return u.BigInt().Text(base)
+	}
+}
+
+// TrailingZeros returns the number of trailing zero bits in u.
+func (u Uint128) TrailingZeros() int {
+	if u.Lo != 0 {
+		return bits.TrailingZeros64(u.Lo)
+	}
+	return 64 + bits.TrailingZeros64(u.Hi)
+}
+
+// Xor returns u^v.
+func (u Uint128) Xor(v Uint128) Uint128 {
+	return Uint128{Hi: u.Hi ^ v.Hi, Lo: u.Lo ^ v.Lo}
+}
@@ -0,0 +1,111 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"math/big"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestUint128(t *testing.T) {
+	maxU := sockaddr.Uint128{Hi: ^uint64(0), Lo: ^uint64(0)}
+	one := sockaddr.Uint128{Lo: 1}
+
+	tests := []struct {
+		name string
+		got  sockaddr.Uint128
+		want sockaddr.Uint128
+	}{
+		{"add carry", sockaddr.Uint128{Lo: ^uint64(0)}.Add(one), sockaddr.Uint128{Hi: 1}},
+		{"add wrap", maxU.Add(one), sockaddr.Uint128{}},
+		{"sub borrow", sockaddr.Uint128{Hi: 1}.Sub(one), sockaddr.Uint128{Lo: ^uint64(0)}},
+		{"sub wrap", sockaddr.Uint128{}.Sub(one), maxU},
+		{"and", maxU.And(sockaddr.Uint128{Hi: 0xf0, Lo: 0x0f}), sockaddr.Uint128{Hi: 0xf0, Lo: 0x0f}},
+		{"andnot", maxU.AndNot(sockaddr.Uint128{Lo: ^uint64(0)}), sockaddr.Uint128{Hi: ^uint64(0)}},
+		{"or", sockaddr.Uint128{Hi: 1}.Or(one), sockaddr.Uint128{Hi: 1, Lo: 1}},
+		{"xor", maxU.Xor(maxU), sockaddr.Uint128{}},
+		{"not", sockaddr.Uint128{}.Not(), maxU},
+		{"lsh 0", one.Lsh(0), one},
+		{"lsh 1", one.Lsh(1), sockaddr.Uint128{Lo: 2}},
+		{"lsh 64", one.Lsh(64), sockaddr.Uint128{Hi: 1}},
+		{"lsh 127", one.Lsh(127), sockaddr.Uint128{Hi: 1 << 63}},
+		{"lsh 128", one.Lsh(128), sockaddr.Uint128{}},
+		{"rsh 1", sockaddr.Uint128{Hi: 1}.Rsh(1), sockaddr.Uint128{Lo: 1 << 63}},
+		{"rsh 64", sockaddr.Uint128{Hi: 1}.Rsh(64), one},
+		{"rsh 128", maxU.Rsh(128), sockaddr.Uint128{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.got != test.want {
+				t.Errorf("Expected %#v, received %#v", test.want, test.got)
+			}
+		})
+	}
+}
+
+func TestUint128_BigInt(t *testing.T) {
+	tests := []struct {
+		input string
+		u     sockaddr.Uint128
+	}{
+		{"0", sockaddr.Uint128{}},
+		{"1", sockaddr.Uint128{Lo: 1}},
+		{"18446744073709551616", sockaddr.Uint128{Hi: 1}},
+		{"340282366920938463463374607431768211455", sockaddr.Uint128{Hi: ^uint64(0), Lo: ^uint64(0)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			bi, ok := new(big.Int).SetString(test.input, 10)
+			if !ok {
+				t.Fatalf("Unable to parse %q", test.input)
+			}
+
+			if u := sockaddr.Uint128FromBigInt(bi); u != test.u {
+				t.Errorf("Expected %#v, received %#v", test.u, u)
+			}
+
+			if s := test.u.String(); s != test.input {
+				t.Errorf("Expected String() to be %q, received %q", test.input, s)
+			}
+
+			if got := test.u.BigInt(); got.Cmp(bi) != 0 {
+				t.Errorf("Expected BigInt() to be %v, received %v", bi, got)
+			}
+
+			if s, want := test.u.Text(16), bi.Text(16); s != want {
+				t.Errorf("Expected Text(16) to be %q, received %q", want, s)
+			}
+
+			if s, want := test.u.Text(2), bi.Text(2); s != want {
+				t.Errorf("Expected Text(2) to be %q, received %q", want, s)
+			}
+
+			if u := sockaddr.Uint128FromBytes(test.u.Bytes()); u != test.u {
+				t.Errorf("Expected Bytes() to round trip %#v, received %#v", test.u, u)
+			}
+		})
+	}
+}
+
+func TestUint128_Cmp(t *testing.T) {
+	tests := []struct {
+		x, y sockaddr.Uint128
+		cmp  int
+	}{
+		{sockaddr.Uint128{}, sockaddr.Uint128{}, 0},
+		{sockaddr.Uint128{Lo: 1}, sockaddr.Uint128{Lo: 2}, -1},
+		{sockaddr.Uint128{Hi: 1}, sockaddr.Uint128{Lo: ^uint64(0)}, 1},
+		{sockaddr.Uint128{Hi: 1, Lo: 1}, sockaddr.Uint128{Hi: 1, Lo: 0}, 1},
+	}
+
+	for i, test := range tests {
+		if c := test.x.Cmp(test.y); c != test.cmp {
+			t.Errorf("[%d] Expected Cmp() to return %d, received %d", i, test.cmp, c)
+		}
+	}
+}
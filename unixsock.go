@@ -22,15 +22,42 @@ func init() {
 	unixAttrInit()
 }
 
+// unixAbstractPrefix is the prefix used by the net package to denote a Linux
+// abstract namespace socket.
+const unixAbstractPrefix = "@"
+
 // NewUnixSock creates an UnixSock from a string path.  String can be in the
 // form of either URI-based string (e.g. `file:///etc/passwd`), an absolute
 // path (e.g. `/etc/passwd`), or a relative path (e.g. `./foo`).
+//
+// On Linux, a name beginning with '@' (e.g. `@myservice`) or a NUL byte (as
+// found in sockaddr_un and /proc/net/unix) is an abstract namespace socket and
+// is normalized to the '@' form accepted by net.Dial().  An empty path
+// requests autobind when used as a listen address.
 func NewUnixSock(s string) (ret UnixSock, err error) {
+	if strings.HasPrefix(s, "\x00") {
+		s = unixAbstractPrefix + s[1:]
+	}
+
 	ret.path = s
 	return ret, nil
 }
 
-// Contains returns true if sa and us have the same path
+// Abstract returns true if the UnixSock is a Linux abstract namespace socket.
+func (us UnixSock) Abstract() bool {
+	return strings.HasPrefix(us.path, unixAbstractPrefix)
+}
+
+// Autobind returns true if the UnixSock has an empty path.  Listening on an
+// empty path asks the Linux kernel to autobind the socket to a unique abstract
+// name.
+func (us UnixSock) Autobind() bool {
+	return us.path == ""
+}
+
+// Contains returns true if sa and us have the same path.  Abstract names are
+// only ever equal to an identical abstract name and are never contained by a
+// filesystem path.
 func (us UnixSock) Contains(sa SockAddr) bool {
 	usb, ok := sa.(UnixSock)
 	if !ok {
@@ -58,18 +85,21 @@ func (us UnixSock) CmpAddress(sa SockAddr) int {
 func (us UnixSock) CmpRFC(rfcNum uint, sa SockAddr) int { return sortDeferDecision }
 
 // DialPacketArgs returns the arguments required to be passed to net.DialUnix()
-// with the `unixgram` network type.
+// with the `unixgram` network type.  Abstract names are returned in their '@'
+// form.
 func (us UnixSock) DialPacketArgs() (network, dialArgs string) {
 	return "unixgram", us.path
 }
 
 // DialStreamArgs returns the arguments required to be passed to net.DialUnix()
-// with the `unix` network type.
+// with the `unix` network type.  Abstract names are returned in their '@'
+// form.
 func (us UnixSock) DialStreamArgs() (network, dialArgs string) {
 	return "unix", us.path
 }
 
-// Equal returns true if a SockAddr is equal to the receiving UnixSock.
+// Equal returns true if a SockAddr is equal to the receiving UnixSock.  An
+// abstract name is never equal to a filesystem path.
 func (us UnixSock) Equal(sa SockAddr) bool {
 	usb, ok := sa.(UnixSock)
 	if !ok {
@@ -84,13 +114,15 @@ func (us UnixSock) Equal(sa SockAddr) bool {
 }
 
 // ListenPacketArgs returns the arguments required to be passed to
-// net.ListenUnixgram() with the `unixgram` network type.
+// net.ListenUnixgram() with the `unixgram` network type.  An autobind UnixSock
+// returns an empty address.
 func (us UnixSock) ListenPacketArgs() (network, dialArgs string) {
 	return "unixgram", us.path
 }
 
 // ListenStreamArgs returns the arguments required to be passed to
-// net.ListenUnix() with the `unix` network type.
+// net.ListenUnix() with the `unix` network type.  An autobind UnixSock returns
+// an empty address.
 func (us UnixSock) ListenStreamArgs() (network, dialArgs string) {
 	return "unix", us.path
 }
@@ -105,7 +137,8 @@ func MustUnixSock(addr string) UnixSock {
 	return us
 }
 
-// Path returns the given path of the UnixSock
+// Path returns the given path of the UnixSock.  Abstract names are returned
+// with a leading '@'.
 func (us UnixSock) Path() string {
 	return us.path
 }
@@ -141,11 +174,15 @@ func unixAttrInit() {
 	// Sorted for human readability
 	unixAttrs = []AttrName{
 		"path",
+		"abstract",
 	}
 
 	unixAttrMap = map[AttrName]func(us UnixSock) string{
 		"path": func(us UnixSock) string {
 			return us.Path()
 		},
+		"abstract": func(us UnixSock) string {
+			return fmt.Sprintf("%t", us.Abstract())
+		},
 	}
 }
@@ -4,6 +4,10 @@
 package sockaddr_test
 
 import (
+	"fmt"
+	"net"
+	"os"
+	"runtime"
 	"testing"
 
 	sockaddr "github.com/hashicorp/go-sockaddr"
@@ -86,6 +90,24 @@ func TestUnixSock_Equal(t *testing.T) {
 			sa:    sockaddr.MustIPv6Addr("::1"),
 			equal: false,
 		},
+		{
+			name:  "abstract",
+			input: sockaddr.MustUnixSock("@foo"),
+			sa:    sockaddr.MustUnixSock("\x00foo"),
+			equal: true,
+		},
+		{
+			name:  "abstract vs relative path",
+			input: sockaddr.MustUnixSock("@foo"),
+			sa:    sockaddr.MustUnixSock("./@foo"),
+			equal: false,
+		},
+		{
+			name:  "abstract vs path",
+			input: sockaddr.MustUnixSock("@/tmp/foo"),
+			sa:    sockaddr.MustUnixSock("/tmp/foo"),
+			equal: false,
+		},
 	}
 
 	for i, test := range tests {
@@ -102,8 +124,83 @@ func TestUnixSock_Equal(t *testing.T) {
 	}
 }
 
+func TestUnixSock_Abstract(t *testing.T) {
+	tests := []struct {
+		input    string
+		path     string
+		abstract bool
+		autobind bool
+		sockAddr bool
+	}{
+		{input: "/tmp/foo", path: "/tmp/foo", sockAddr: true},
+		{input: "./foo", path: "./foo", sockAddr: true},
+		{input: "@myservice", path: "@myservice", abstract: true, sockAddr: true},
+		{input: "\x00myservice", path: "@myservice", abstract: true, sockAddr: true},
+		{input: "@", path: "@", abstract: true},
+		{input: "", path: "", autobind: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			us, err := sockaddr.NewUnixSock(test.input)
+			if err != nil {
+				t.Fatalf("unable to create a UnixSock from %+q: %v", test.input, err)
+			}
+
+			if p := us.Path(); p != test.path {
+				t.Errorf("expected path %+q, received %+q", test.path, p)
+			}
+
+			if us.Abstract() != test.abstract {
+				t.Errorf("expected Abstract() to be %t", test.abstract)
+			}
+
+			if us.Autobind() != test.autobind {
+				t.Errorf("expected Autobind() to be %t", test.autobind)
+			}
+
+			if attr, err := sockaddr.Attr(us, "abstract"); err != nil || attr != fmt.Sprintf("%t", test.abstract) {
+				t.Errorf("expected abstract attribute %t, received %q (%v)", test.abstract, attr, err)
+			}
+
+			if _, args := us.DialStreamArgs(); args != test.path {
+				t.Errorf("expected DialStreamArgs() %+q, received %+q", test.path, args)
+			}
+
+			sa, err := sockaddr.NewSockAddr(test.input)
+			switch {
+			case test.sockAddr && err != nil:
+				t.Errorf("expected NewSockAddr(%+q) to succeed: %v", test.input, err)
+			case test.sockAddr && !sa.Equal(us):
+				t.Errorf("expected NewSockAddr(%+q) to return %v, received %v", test.input, us, sa)
+			}
+		})
+	}
+}
+
+func TestUnixSock_AbstractListen(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("abstract UNIX sockets are only supported on Linux")
+	}
+
+	us := sockaddr.MustUnixSock(fmt.Sprintf("@go-sockaddr-test-%d", os.Getpid()))
+	network, listenArgs := us.ListenStreamArgs()
+	l, err := net.Listen(network, listenArgs)
+	if err != nil {
+		t.Fatalf("unable to listen on %v: %v", us, err)
+	}
+	defer l.Close()
+
+	network, dialArgs := us.DialStreamArgs()
+	conn, err := net.Dial(network, dialArgs)
+	if err != nil {
+		t.Fatalf("unable to dial %v: %v", us, err)
+	}
+	conn.Close()
+}
+
 func TestUnixSockAttrs(t *testing.T) {
-	const expectedNumAttrs = 1
+	const expectedNumAttrs = 2
 	usa := sockaddr.UnixSockAttrs()
 	if len(usa) != expectedNumAttrs {
 		t.Fatalf("wrong number of UnixSockAttrs: %d vs %d", len(usa), expectedNumAttrs)
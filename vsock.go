@@ -0,0 +1,224 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VSockCID is a named type representing an AF_VSOCK context identifier.
+type VSockCID uint32
+
+// VSockPort is a named type representing an AF_VSOCK port number.
+type VSockPort uint32
+
+const (
+	// VSockCIDHypervisor is the well-known CID of the hypervisor.
+	VSockCIDHypervisor VSockCID = 0
+
+	// VSockCIDLocal is the well-known CID for local communication
+	// (loopback).
+	VSockCIDLocal VSockCID = 1
+
+	// VSockCIDHost is the well-known CID of the host.
+	VSockCIDHost VSockCID = 2
+
+	// VSockCIDAny is the wildcard CID (VMADDR_CID_ANY) used when listening.
+	VSockCIDAny VSockCID = 0xffffffff
+
+	// VSockPortAny is the wildcard port (VMADDR_PORT_ANY).
+	VSockPortAny VSockPort = 0xffffffff
+)
+
+// vsockScheme is the URI scheme prefix used to represent a VSock as a string.
+const vsockScheme = "vsock://"
+
+// VSock implements the SockAddr interface for AF_VSOCK addresses used for
+// communication between virtual machines and their host.  A VSock is
+// addressed by a context identifier (CID) and a port.
+type VSock struct {
+	SockAddr
+	CID  VSockCID
+	Port VSockPort
+}
+
+// vsockAttrMap is a map of the VSock type-specific attributes.
+var vsockAttrMap map[AttrName]func(VSock) string
+var vsockAttrs []AttrName
+
+func init() {
+	vsockAttrInit()
+}
+
+// NewVSock creates a VSock from a string.  String can be in the form of a
+// URI (e.g. `vsock://3:1024`) or a bare `cid:port` pair (e.g. `3:1024`).
+func NewVSock(s string) (VSock, error) {
+	addr := strings.TrimPrefix(s, vsockScheme)
+
+	cidStr, portStr, found := strings.Cut(addr, ":")
+	if !found {
+		return VSock{}, fmt.Errorf("unable to parse %+q as a vsock address: missing port", s)
+	}
+
+	cid, err := strconv.ParseUint(cidStr, 10, 32)
+	if err != nil {
+		return VSock{}, fmt.Errorf("unable to parse the CID in %+q as a vsock address: %v", s, err)
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 32)
+	if err != nil {
+		return VSock{}, fmt.Errorf("unable to parse the port in %+q as a vsock address: %v", s, err)
+	}
+
+	return VSock{
+		CID:  VSockCID(cid),
+		Port: VSockPort(port),
+	}, nil
+}
+
+// MustVSock is a helper method that must return a VSock or panic on invalid
+// input.
+func MustVSock(addr string) VSock {
+	vs, err := NewVSock(addr)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to create a VSock from %+q: %v", addr, err))
+	}
+	return vs
+}
+
+// CmpAddress follows the Cmp() standard protocol and returns:
+//
+//   - -1 If the receiver should sort first because its CID is lower than arg
+//   - 0 if the SockAddr arg is not a VSock, or is a VSock with the same CID.
+//   - 1 If the argument should sort first.
+func (vs VSock) CmpAddress(sa SockAddr) int {
+	vsb, ok := sa.(VSock)
+	if !ok {
+		return sortDeferDecision
+	}
+
+	switch {
+	case vs.CID == vsb.CID:
+		return sortDeferDecision
+	case vs.CID < vsb.CID:
+		return sortReceiverBeforeArg
+	default:
+		return sortArgBeforeReceiver
+	}
+}
+
+// CmpPort follows the Cmp() standard protocol and returns:
+//
+//   - -1 If the receiver should sort first because its port is lower than arg
+//   - 0 if the SockAddr arg is not a VSock, or is a VSock with the same port.
+//   - 1 If the argument should sort first.
+func (vs VSock) CmpPort(sa SockAddr) int {
+	vsb, ok := sa.(VSock)
+	if !ok {
+		return sortDeferDecision
+	}
+
+	switch {
+	case vs.Port == vsb.Port:
+		return sortDeferDecision
+	case vs.Port < vsb.Port:
+		return sortReceiverBeforeArg
+	default:
+		return sortArgBeforeReceiver
+	}
+}
+
+// CmpRFC doesn't make sense for a VSock, so just return defer decision
+func (vs VSock) CmpRFC(rfcNum uint, sa SockAddr) int { return sortDeferDecision }
+
+// Contains returns true if sa is a VSock with the same CID and port
+func (vs VSock) Contains(sa SockAddr) bool {
+	return vs.Equal(sa)
+}
+
+// DialPacketArgs returns empty arguments because datagram vsock sockets are
+// not supported.
+func (vs VSock) DialPacketArgs() (network, dialArgs string) {
+	return "", ""
+}
+
+// DialStreamArgs returns the network name and `cid:port` address of the
+// VSock.  The standard library does not support AF_VSOCK, so these arguments
+// are intended for a vsock-aware dialer.
+func (vs VSock) DialStreamArgs() (network, dialArgs string) {
+	return "vsock", vs.addressString()
+}
+
+// Equal returns true if a SockAddr is equal to the receiving VSock.
+func (vs VSock) Equal(sa SockAddr) bool {
+	vsb, ok := sa.(VSock)
+	if !ok {
+		return false
+	}
+
+	return vs.CID == vsb.CID && vs.Port == vsb.Port
+}
+
+// ListenPacketArgs returns empty arguments because datagram vsock sockets are
+// not supported.
+func (vs VSock) ListenPacketArgs() (network, listenArgs string) {
+	return "", ""
+}
+
+// ListenStreamArgs returns the network name and `cid:port` address of the
+// VSock.  See DialStreamArgs().
+func (vs VSock) ListenStreamArgs() (network, listenArgs string) {
+	return "vsock", vs.addressString()
+}
+
+// String returns the URI representation of the VSock (e.g. `vsock://3:1024`).
+func (vs VSock) String() string {
+	return vsockScheme + vs.addressString()
+}
+
+// Type is used as a type switch and returns TypeVSock
+func (VSock) Type() SockAddrType {
+	return TypeVSock
+}
+
+// addressString returns the `cid:port` form of the VSock.
+func (vs VSock) addressString() string {
+	return fmt.Sprintf("%d:%d", vs.CID, vs.Port)
+}
+
+// VSockAttrs returns a list of attributes supported by the VSock type
+func VSockAttrs() []AttrName {
+	return vsockAttrs
+}
+
+// VSockAttr returns a string representation of an attribute for the given
+// VSock.
+func VSockAttr(vs VSock, attrName AttrName) string {
+	fn, found := vsockAttrMap[attrName]
+	if !found {
+		return ""
+	}
+
+	return fn(vs)
+}
+
+// vsockAttrInit is called once at init()
+func vsockAttrInit() {
+	// Sorted for human readability
+	vsockAttrs = []AttrName{
+		"cid",
+		"port",
+	}
+
+	vsockAttrMap = map[AttrName]func(vs VSock) string{
+		"cid": func(vs VSock) string {
+			return fmt.Sprintf("%d", vs.CID)
+		},
+		"port": func(vs VSock) string {
+			return fmt.Sprintf("%d", vs.Port)
+		},
+	}
+}
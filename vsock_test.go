@@ -0,0 +1,199 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr_test
+
+import (
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestVSock_New(t *testing.T) {
+	tests := []struct {
+		input string
+		cid   sockaddr.VSockCID
+		port  sockaddr.VSockPort
+		str   string
+		pass  bool
+	}{
+		{input: "vsock://3:1024", cid: 3, port: 1024, str: "vsock://3:1024", pass: true},
+		{input: "2:22", cid: sockaddr.VSockCIDHost, port: 22, str: "vsock://2:22", pass: true},
+		{input: "vsock://4294967295:80", cid: sockaddr.VSockCIDAny, port: 80, str: "vsock://4294967295:80", pass: true},
+		{input: "vsock://3", pass: false},
+		{input: "vsock://3:", pass: false},
+		{input: "vsock://:1024", pass: false},
+		{input: "vsock://4294967296:1024", pass: false},
+		{input: "vsock://3:-1", pass: false},
+		{input: "vsock://host:1024", pass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			vs, err := sockaddr.NewVSock(test.input)
+			if !test.pass {
+				if err == nil {
+					t.Fatalf("expected %+q to fail, received %v", test.input, vs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to create a VSock from %+q: %v", test.input, err)
+			}
+
+			if vs.CID != test.cid || vs.Port != test.port {
+				t.Errorf("expected CID %d and port %d, received %d and %d", test.cid, test.port, vs.CID, vs.Port)
+			}
+
+			if s := vs.String(); s != test.str {
+				t.Errorf("expected String() %+q, received %+q", test.str, s)
+			}
+
+			if vs.Type() != sockaddr.TypeVSock {
+				t.Errorf("expected type %v, received %v", sockaddr.TypeVSock, vs.Type())
+			}
+
+			sa, err := sockaddr.NewSockAddr(vs.String())
+			if err != nil {
+				t.Fatalf("unable to create a SockAddr from %+q: %v", vs.String(), err)
+			}
+			if !sa.Equal(vs) {
+				t.Errorf("expected NewSockAddr(%+q) to return %v, received %v", vs.String(), vs, sa)
+			}
+		})
+	}
+}
+
+func TestVSock_Args(t *testing.T) {
+	vs := sockaddr.MustVSock("vsock://3:1024")
+
+	tests := []struct {
+		name    string
+		fn      func() (string, string)
+		network string
+		address string
+	}{
+		{"DialPacketArgs", vs.DialPacketArgs, "", ""},
+		{"DialStreamArgs", vs.DialStreamArgs, "vsock", "3:1024"},
+		{"ListenPacketArgs", vs.ListenPacketArgs, "", ""},
+		{"ListenStreamArgs", vs.ListenStreamArgs, "vsock", "3:1024"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			network, address := test.fn()
+			if network != test.network || address != test.address {
+				t.Errorf("expected %q %q, received %q %q", test.network, test.address, network, address)
+			}
+		})
+	}
+}
+
+func TestVSock_Cmp(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        sockaddr.VSock
+		b        sockaddr.SockAddr
+		cmpAddr  int
+		cmpPort  int
+		equal    bool
+		contains bool
+	}{
+		{
+			name:     "equal",
+			a:        sockaddr.MustVSock("vsock://3:1024"),
+			b:        sockaddr.MustVSock("vsock://3:1024"),
+			equal:    true,
+			contains: true,
+		},
+		{
+			name:    "lower cid",
+			a:       sockaddr.MustVSock("vsock://2:1024"),
+			b:       sockaddr.MustVSock("vsock://3:1024"),
+			cmpAddr: -1,
+		},
+		{
+			name:    "higher port",
+			a:       sockaddr.MustVSock("vsock://3:1025"),
+			b:       sockaddr.MustVSock("vsock://3:1024"),
+			cmpPort: 1,
+		},
+		{
+			name: "ipv4",
+			a:    sockaddr.MustVSock("vsock://3:1024"),
+			b:    sockaddr.MustIPv4Addr("3.0.0.0:1024"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if c := test.a.CmpAddress(test.b); c != test.cmpAddr {
+				t.Errorf("expected CmpAddress() %d, received %d", test.cmpAddr, c)
+			}
+			if c := test.a.CmpPort(test.b); c != test.cmpPort {
+				t.Errorf("expected CmpPort() %d, received %d", test.cmpPort, c)
+			}
+			if e := test.a.Equal(test.b); e != test.equal {
+				t.Errorf("expected Equal() %t, received %t", test.equal, e)
+			}
+			if c := test.a.Contains(test.b); c != test.contains {
+				t.Errorf("expected Contains() %t, received %t", test.contains, c)
+			}
+		})
+	}
+}
+
+func TestVSock_Sort(t *testing.T) {
+	sas := sockaddr.SockAddrs{
+		sockaddr.MustIPv6Addr("[::1]:80"),
+		sockaddr.MustVSock("vsock://3:1025"),
+		sockaddr.MustIPv4Addr("127.0.0.1:443"),
+		sockaddr.MustUnixSock("/tmp/foo"),
+		sockaddr.MustVSock("vsock://2:1024"),
+	}
+
+	sockaddr.OrderedAddrBy(sockaddr.AscType, sockaddr.AscPort).Sort(sas)
+
+	expected := []string{
+		`"/tmp/foo"`,
+		"vsock://2:1024",
+		"vsock://3:1025",
+		"127.0.0.1:443",
+		"[::1]:80",
+	}
+	for i, sa := range sas {
+		if s := sa.String(); s != expected[i] {
+			t.Errorf("[%d] expected %s, received %s", i, expected[i], s)
+		}
+	}
+}
+
+func TestVSock_Attrs(t *testing.T) {
+	ifAddrs := sockaddr.IfAddrs{
+		{SockAddr: sockaddr.MustIPv4Addr("127.0.0.1")},
+		{SockAddr: sockaddr.MustVSock("vsock://3:1024")},
+	}
+
+	matched, _, err := sockaddr.IfByType("vsock", ifAddrs)
+	if err != nil {
+		t.Fatalf("unable to filter by vsock type: %v", err)
+	}
+	if len(matched) != 1 || matched[0].Type() != sockaddr.TypeVSock {
+		t.Fatalf("expected a single vsock match, received %v", matched)
+	}
+
+	for attr, expected := range map[sockaddr.AttrName]string{
+		"cid":    "3",
+		"port":   "1024",
+		"type":   "VSOCK",
+		"string": "vsock://3:1024",
+	} {
+		val, err := matched[0].Attr(attr)
+		if err != nil {
+			t.Fatalf("unable to get attribute %q: %v", attr, err)
+		}
+		if val != expected {
+			t.Errorf("expected attribute %q to be %q, received %q", attr, expected, val)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+)
+
+// DefaultWatchPollInterval is the interval at which WatchInterfaces() polls
+// the interfaces when WatchOptions.PollInterval is not set.
+const DefaultWatchPollInterval = 5 * time.Second
+
+// IfAddrEventType is the kind of change reported by an IfAddrEvent.
+type IfAddrEventType int
+
+const (
+	// IfAddrAdded reports an address that was added to an interface.
+	IfAddrAdded IfAddrEventType = iota + 1
+
+	// IfAddrRemoved reports an address that was removed from an interface.
+	IfAddrRemoved
+
+	// IfAddrChanged reports an address whose prefix length, address flags
+	// or scope, interface attributes (flags, MTU, index or hardware address)
+	// or link state (kind, operstate, carrier, speed or duplex) changed, e.g.
+	// when an IPv6 address stops being tentative or a cable is unplugged.
+	IfAddrChanged
+)
+
+// String returns the name of the event type.
+func (t IfAddrEventType) String() string {
+	switch t {
+	case IfAddrAdded:
+		return "added"
+	case IfAddrRemoved:
+		return "removed"
+	case IfAddrChanged:
+		return "changed"
+	default:
+		return fmt.Sprintf("IfAddrEventType(%d)", int(t))
+	}
+}
+
+// IfAddrEvent is a change to the addresses of the host's interfaces reported
+// by WatchInterfaces().
+type IfAddrEvent struct {
+	Type IfAddrEventType
+
+	// IfAddr is the address after the change, or the removed address for
+	// IfAddrRemoved events.
+	IfAddr IfAddr
+
+	// Old is the address before the change.  It is only set for
+	// IfAddrChanged events.
+	Old IfAddr
+}
+
+// String returns the event type and the address, e.g. "added eth0 10.0.0.5/8".
+func (e IfAddrEvent) String() string {
+	return fmt.Sprintf("%s %s %s", e.Type, e.IfAddr.Name, e.IfAddr.SockAddr)
+}
+
+// WatchOptions configures WatchInterfaces().
+type WatchOptions struct {
+	// Provider supplies the interfaces to watch.  If nil, the host's
+	// interfaces are watched through OSInterfaceProvider.
+	Provider InterfaceProvider
+
+	// PollInterval is the interval at which the interfaces are compared
+	// when they are polled.  If zero, DefaultWatchPollInterval is used.
+	PollInterval time.Duration
+
+	// ForcePolling disables the platform's change notifications (netlink on
+	// Linux) and always polls the interfaces.
+	ForcePolling bool
+}
+
+// WatchNotifications returns a channel that receives a value whenever the
+// host's network configuration may have changed, until ctx is done, at which
+// point the channel is closed.  On Linux, a value is sent whenever netlink
+// reports a link, address or route change.  Elsewhere, or when opts.Provider
+// is set, opts.ForcePolling is true or netlink is not available, a value is
+// sent every opts.PollInterval.  Values that arrive while one is pending are
+// merged into it.
+func WatchNotifications(ctx context.Context, opts WatchOptions) <-chan struct{} {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultWatchPollInterval
+	}
+
+	var netlink <-chan struct{}
+	if opts.Provider == nil && !opts.ForcePolling {
+		// Netlink is only a wakeup source.  If it can not be opened, fall
+		// back to polling.
+		if ch, err := interfaceNotifications(ctx); err == nil {
+			netlink = ch
+		}
+	}
+
+	notify := make(chan struct{}, 1)
+	go watchNotifications(ctx, interval, netlink, notify)
+	return notify
+}
+
+// watchNotifications sends a value on notify whenever netlink fires, or every
+// interval if netlink is nil.  If netlink is closed, values are sent every
+// interval from then on.
+func watchNotifications(ctx context.Context, interval time.Duration, netlink <-chan struct{}, notify chan<- struct{}) {
+	defer close(notify)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	tick := ticker.C
+	if netlink != nil {
+		ticker.Stop()
+		tick = nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case _, ok := <-netlink:
+			if !ok {
+				netlink = nil
+				ticker.Reset(interval)
+				tick = ticker.C
+			}
+		}
+
+		select {
+		case notify <- struct{}{}:
+		default:
+			// A notification is already pending.
+		}
+	}
+}
+
+// WatchInterfaces reports changes to the host's interface addresses until ctx
+// is done, at which point the returned channel is closed.  The interfaces are
+// read once before WatchInterfaces() returns and only later changes are
+// reported.  The interfaces are re-read whenever WatchNotifications() fires.
+// Errors reading the interfaces after the first read are ignored and the read
+// is retried at the next notification.  The channel is unbuffered, so the
+// caller must keep receiving from it for the watch to make progress.
+func WatchInterfaces(ctx context.Context, opts WatchOptions) (<-chan IfAddrEvent, error) {
+	p := opts.Provider
+	if p == nil {
+		p = OSInterfaceProvider{}
+	}
+
+	// Notifications are subscribed to before the first read so that no
+	// change is missed in between.
+	ctx, cancel := context.WithCancel(ctx)
+	notify := WatchNotifications(ctx, opts)
+
+	prev, err := GetAllInterfacesFrom(p)
+	if err != nil {
+		// Stop the notifications, which closes the netlink socket.
+		cancel()
+		return nil, err
+	}
+
+	events := make(chan IfAddrEvent)
+	go func() {
+		defer cancel()
+		watchInterfaces(ctx, p, notify, prev, events)
+	}()
+	return events, nil
+}
+
+// watchInterfaces re-reads the interfaces of p whenever notify fires and sends
+// the differences to prev on events.
+func watchInterfaces(ctx context.Context, p InterfaceProvider, notify <-chan struct{}, prev IfAddrs, events chan<- IfAddrEvent) {
+	defer close(events)
+
+	for range notify {
+		cur, err := GetAllInterfacesFrom(p)
+		if err != nil {
+			continue
+		}
+
+		for _, event := range diffIfAddrs(prev, cur) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+		prev = cur
+	}
+}
+
+// ifAddrKey identifies an address across reads of the interfaces: the name of
+// its interface and the address without its prefix length.
+func ifAddrKey(ifAddr IfAddr) string {
+	if ipAddr, ok := ifAddr.SockAddr.(IPAddr); ok {
+		return ifAddr.Name + " " + ipAddr.NetipAddr().String()
+	}
+	return ifAddr.Name + " " + ifAddr.SockAddr.String()
+}
+
+// diffIfAddrs returns the events that turn prev into cur: the removed
+// addresses in the order of prev, followed by the added and changed addresses
+// in the order of cur.
+func diffIfAddrs(prev, cur IfAddrs) []IfAddrEvent {
+	prevByKey := make(map[string]IfAddr, len(prev))
+	for _, ifAddr := range prev {
+		prevByKey[ifAddrKey(ifAddr)] = ifAddr
+	}
+	curByKey := make(map[string]struct{}, len(cur))
+	for _, ifAddr := range cur {
+		curByKey[ifAddrKey(ifAddr)] = struct{}{}
+	}
+
+	var events []IfAddrEvent
+	for _, ifAddr := range prev {
+		if _, ok := curByKey[ifAddrKey(ifAddr)]; !ok {
+			events = append(events, IfAddrEvent{Type: IfAddrRemoved, IfAddr: ifAddr})
+		}
+	}
+
+	for _, ifAddr := range cur {
+		old, ok := prevByKey[ifAddrKey(ifAddr)]
+		switch {
+		case !ok:
+			events = append(events, IfAddrEvent{Type: IfAddrAdded, IfAddr: ifAddr})
+		case !ifAddrEqual(old, ifAddr):
+			events = append(events, IfAddrEvent{Type: IfAddrChanged, IfAddr: ifAddr, Old: old})
+		}
+	}
+
+	return events
+}
+
+// ifAddrEqual returns true if a and b have the same address, prefix length,
+// address flags and scope, interface attributes and link state.
+func ifAddrEqual(a, b IfAddr) bool {
+	return a.SockAddr.Equal(b.SockAddr) &&
+		a.AddrFlags == b.AddrFlags &&
+		a.AddrScope == b.AddrScope &&
+		interfaceEqual(a.Interface, b.Interface) &&
+		a.Kind == b.Kind &&
+		a.OperState == b.OperState &&
+		a.Carrier == b.Carrier &&
+		a.Speed == b.Speed &&
+		a.Duplex == b.Duplex
+}
+
+// interfaceEqual returns true if a and b have the same attributes.
+func interfaceEqual(a, b net.Interface) bool {
+	return a.Index == b.Index &&
+		a.MTU == b.MTU &&
+		a.Name == b.Name &&
+		a.Flags == b.Flags &&
+		slices.Equal(a.HardwareAddr, b.HardwareAddr)
+}
@@ -0,0 +1,76 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+)
+
+// Netlink multicast groups from include/uapi/linux/rtnetlink.h.
+const (
+	rtmgrpLink       = 0x1
+	rtmgrpIPv4IfAddr = 0x10
+	rtmgrpIPv4Route  = 0x40
+	rtmgrpIPv6IfAddr = 0x100
+	rtmgrpIPv6Route  = 0x400
+)
+
+// interfaceNotifications returns a channel that receives a value whenever the
+// kernel reports a link, address or route change over netlink.  The channel is closed
+// when ctx is done or the netlink socket fails.
+func interfaceNotifications(ctx context.Context) (<-chan struct{}, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+
+	sa := &syscall.SockaddrNetlink{
+		Family: syscall.AF_NETLINK,
+		Groups: rtmgrpLink | rtmgrpIPv4IfAddr | rtmgrpIPv6IfAddr | rtmgrpIPv4Route | rtmgrpIPv6Route,
+	}
+	if err := syscall.Bind(fd, sa); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+
+	// A non-blocking socket is handled by the runtime poller, so closing
+	// the file interrupts a pending Read.
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("setnonblock", err)
+	}
+	f := os.NewFile(uintptr(fd), "netlink")
+
+	go func() {
+		<-ctx.Done()
+		f.Close()
+	}()
+
+	notify := make(chan struct{}, 1)
+	go func() {
+		defer close(notify)
+
+		buf := make([]byte, os.Getpagesize())
+		for {
+			// The messages are not decoded: any message means the
+			// interfaces and routes must be re-read.  An overrun receive buffer
+			// (ENOBUFS) also means messages were lost, so it is
+			// reported as a change too.
+			if _, err := f.Read(buf); err != nil && !errors.Is(err, syscall.ENOBUFS) {
+				return
+			}
+
+			select {
+			case notify <- struct{}{}:
+			default:
+				// A notification is already pending.
+			}
+		}
+	}()
+
+	return notify, nil
+}
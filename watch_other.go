@@ -0,0 +1,17 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !linux
+
+package sockaddr
+
+import (
+	"context"
+	"errors"
+)
+
+// interfaceNotifications is not supported on this platform, so
+// WatchNotifications() polls.
+func interfaceNotifications(context.Context) (<-chan struct{}, error) {
+	return nil, errors.New("interface notifications not supported on this platform")
+}
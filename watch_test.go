@@ -0,0 +1,215 @@
+// Copyright IBM Corp. 2016, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package sockaddr
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeProvider is an InterfaceProvider whose addresses can be replaced while
+// it is being watched.
+type fakeProvider struct {
+	mu  sync.Mutex
+	p   *StaticInterfaceProvider
+	err error
+}
+
+func (f *fakeProvider) set(ifAddrs IfAddrs) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.p = NewStaticInterfaceProvider(ifAddrs, "eth0")
+	f.err = nil
+}
+
+func (f *fakeProvider) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeProvider) Interfaces() ([]net.Interface, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.p.Interfaces()
+}
+
+func (f *fakeProvider) Addrs(intf net.Interface) ([]net.Addr, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.p.Addrs(intf)
+}
+
+func (f *fakeProvider) DefaultInterfaceName() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.p.DefaultInterfaceName()
+}
+
+var (
+	watchEth0 = net.Interface{Index: 2, MTU: 1500, Name: "eth0", Flags: net.FlagUp | net.FlagBroadcast}
+	watchTun0 = net.Interface{Index: 3, MTU: 1400, Name: "tun0", Flags: net.FlagUp | net.FlagPointToPoint}
+)
+
+func Test_diffIfAddrs(t *testing.T) {
+	eth0Down := watchEth0
+	eth0Down.Flags &^= net.FlagUp
+
+	tests := []struct {
+		name   string
+		prev   IfAddrs
+		cur    IfAddrs
+		events []string
+	}{
+		{
+			name: "unchanged",
+			prev: IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0}},
+			cur:  IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0}},
+		},
+		{
+			name:   "renumbered",
+			prev:   IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0}},
+			cur:    IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.9/24"), Interface: watchEth0}},
+			events: []string{"removed eth0 10.0.0.5/24", "added eth0 10.0.0.9/24"},
+		},
+		{
+			name: "vpn up",
+			prev: IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0}},
+			cur: IfAddrs{
+				{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0},
+				{SockAddr: MustIPv4Addr("172.16.0.2/32"), Interface: watchTun0},
+			},
+			events: []string{"added tun0 172.16.0.2"},
+		},
+		{
+			name: "vpn down",
+			prev: IfAddrs{
+				{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0},
+				{SockAddr: MustIPv4Addr("172.16.0.2/32"), Interface: watchTun0},
+			},
+			cur:    IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0}},
+			events: []string{"removed tun0 172.16.0.2"},
+		},
+		{
+			name:   "prefix length changed",
+			prev:   IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0}},
+			cur:    IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/16"), Interface: watchEth0}},
+			events: []string{"changed eth0 10.0.0.5/16"},
+		},
+		{
+			name:   "interface went down",
+			prev:   IfAddrs{{SockAddr: MustIPv6Addr("2001:db8::5/64"), Interface: watchEth0}},
+			cur:    IfAddrs{{SockAddr: MustIPv6Addr("2001:db8::5/64"), Interface: eth0Down}},
+			events: []string{"changed eth0 2001:db8::5/64"},
+		},
+		{
+			name:   "duplicate address detection completed",
+			prev:   IfAddrs{{SockAddr: MustIPv6Addr("2001:db8::5/64"), Interface: watchEth0, AddrFlags: AddrFlagTentative}},
+			cur:    IfAddrs{{SockAddr: MustIPv6Addr("2001:db8::5/64"), Interface: watchEth0, AddrFlags: AddrFlagPermanent}},
+			events: []string{"changed eth0 2001:db8::5/64"},
+		},
+		{
+			name:   "cable unplugged",
+			prev:   IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0, OperState: OperStateUp, Carrier: LinkCarrierUp, Speed: 1000, Duplex: "full"}},
+			cur:    IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0, OperState: OperStateDown, Carrier: LinkCarrierDown}},
+			events: []string{"changed eth0 10.0.0.5/24"},
+		},
+		{
+			name:   "link speed renegotiated",
+			prev:   IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0, Kind: InterfaceKindPhysical, Speed: 1000, Duplex: "full"}},
+			cur:    IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0, Kind: InterfaceKindPhysical, Speed: 100, Duplex: "half"}},
+			events: []string{"changed eth0 10.0.0.5/24"},
+		},
+		{
+			name:   "address moved to another interface",
+			prev:   IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0}},
+			cur:    IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchTun0}},
+			events: []string{"removed eth0 10.0.0.5/24", "added tun0 10.0.0.5/24"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events := diffIfAddrs(test.prev, test.cur)
+			if len(events) != len(test.events) {
+				t.Fatalf("got %d events, want %d: %v", len(events), len(test.events), events)
+			}
+			for i, event := range events {
+				if got := event.String(); got != test.events[i] {
+					t.Errorf("event %d: got %q, want %q", i, got, test.events[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWatchInterfaces_Polling(t *testing.T) {
+	p := &fakeProvider{}
+	p.set(IfAddrs{{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := WatchInterfaces(ctx, WatchOptions{Provider: p, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A failed read is skipped rather than reported as removed addresses.
+	p.fail(ErrNoInterface)
+	time.Sleep(10 * time.Millisecond)
+
+	p.set(IfAddrs{
+		{SockAddr: MustIPv4Addr("10.0.0.5/24"), Interface: watchEth0},
+		{SockAddr: MustIPv4Addr("172.16.0.2/32"), Interface: watchTun0},
+	})
+	select {
+	case event := <-events:
+		if event.Type != IfAddrAdded || event.IfAddr.Name != "tun0" {
+			t.Errorf("unexpected event: %v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for an event")
+	}
+
+	cancel()
+	for range events {
+		// Drain any event sent before the watch stopped.
+	}
+}
+
+func TestWatchNotifications_Polling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	notify := WatchNotifications(ctx, WatchOptions{ForcePolling: true, PollInterval: time.Millisecond})
+
+	// Every poll is reported, whether or not anything changed.
+	for range 2 {
+		select {
+		case <-notify:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for a notification")
+		}
+	}
+
+	cancel()
+	for range notify {
+		// Drain any notification sent before the watch stopped.
+	}
+}
+
+func TestWatchInterfaces_Errors(t *testing.T) {
+	p := &fakeProvider{}
+	p.set(nil)
+	p.fail(ErrNoInterface)
+
+	if _, err := WatchInterfaces(context.Background(), WatchOptions{Provider: p}); err == nil {
+		t.Fatalf("expected an error")
+	}
+}